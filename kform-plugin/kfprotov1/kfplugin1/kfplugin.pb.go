@@ -69,6 +69,58 @@ func (Severity) EnumDescriptor() ([]byte, []int) {
 	return file_kfplugin_proto_rawDescGZIP(), []int{0}
 }
 
+// Interrupt tells why an operation did not complete
+type Interrupt int32
+
+const (
+	Interrupt_NOT_INTERRUPTED Interrupt = 0
+	// the operation did not complete within its timeout
+	Interrupt_TIMEOUT Interrupt = 1
+	// the operation got canceled, e.g. the provider was stopped
+	Interrupt_CANCELED Interrupt = 2
+)
+
+// Enum value maps for Interrupt.
+var (
+	Interrupt_name = map[int32]string{
+		0: "NOT_INTERRUPTED",
+		1: "TIMEOUT",
+		2: "CANCELED",
+	}
+	Interrupt_value = map[string]int32{
+		"NOT_INTERRUPTED": 0,
+		"TIMEOUT":         1,
+		"CANCELED":        2,
+	}
+)
+
+func (x Interrupt) Enum() *Interrupt {
+	p := new(Interrupt)
+	*p = x
+	return p
+}
+
+func (x Interrupt) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Interrupt) Descriptor() protoreflect.EnumDescriptor {
+	return file_kfplugin_proto_enumTypes[1].Descriptor()
+}
+
+func (Interrupt) Type() protoreflect.EnumType {
+	return &file_kfplugin_proto_enumTypes[1]
+}
+
+func (x Interrupt) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Interrupt.Descriptor instead.
+func (Interrupt) EnumDescriptor() ([]byte, []int) {
+	return file_kfplugin_proto_rawDescGZIP(), []int{1}
+}
+
 type Scope int32
 
 const (
@@ -102,11 +154,11 @@ func (x Scope) String() string {
 }
 
 func (Scope) Descriptor() protoreflect.EnumDescriptor {
-	return file_kfplugin_proto_enumTypes[1].Descriptor()
+	return file_kfplugin_proto_enumTypes[2].Descriptor()
 }
 
 func (Scope) Type() protoreflect.EnumType {
-	return &file_kfplugin_proto_enumTypes[1]
+	return &file_kfplugin_proto_enumTypes[2]
 }
 
 func (x Scope) Number() protoreflect.EnumNumber {
@@ -115,7 +167,7 @@ func (x Scope) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Scope.Descriptor instead.
 func (Scope) EnumDescriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{1}
+	return file_kfplugin_proto_rawDescGZIP(), []int{2}
 }
 
 type Capabilities struct {
@@ -384,6 +436,46 @@ func (*UpdateResource) Descriptor() ([]byte, []int) {
 	return file_kfplugin_proto_rawDescGZIP(), []int{6}
 }
 
+// ValidateResource validates the obj of a resource before it is created or
+// updated, no object is read or changed
+type ValidateResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ValidateResource) Reset() {
+	*x = ValidateResource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kfplugin_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResource) ProtoMessage() {}
+
+func (x *ValidateResource) ProtoReflect() protoreflect.Message {
+	mi := &file_kfplugin_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResource.ProtoReflect.Descriptor instead.
+func (*ValidateResource) Descriptor() ([]byte, []int) {
+	return file_kfplugin_proto_rawDescGZIP(), []int{7}
+}
+
 type DeleteResource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -393,7 +485,7 @@ type DeleteResource struct {
 func (x *DeleteResource) Reset() {
 	*x = DeleteResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[7]
+		mi := &file_kfplugin_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -406,7 +498,7 @@ func (x *DeleteResource) String() string {
 func (*DeleteResource) ProtoMessage() {}
 
 func (x *DeleteResource) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[7]
+	mi := &file_kfplugin_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,7 +511,7 @@ func (x *DeleteResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResource.ProtoReflect.Descriptor instead.
 func (*DeleteResource) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{7}
+	return file_kfplugin_proto_rawDescGZIP(), []int{8}
 }
 
 type StopProvider struct {
@@ -431,7 +523,7 @@ type StopProvider struct {
 func (x *StopProvider) Reset() {
 	*x = StopProvider{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[8]
+		mi := &file_kfplugin_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -444,7 +536,7 @@ func (x *StopProvider) String() string {
 func (*StopProvider) ProtoMessage() {}
 
 func (x *StopProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[8]
+	mi := &file_kfplugin_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +549,7 @@ func (x *StopProvider) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopProvider.ProtoReflect.Descriptor instead.
 func (*StopProvider) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{8}
+	return file_kfplugin_proto_rawDescGZIP(), []int{9}
 }
 
 // ServerCapabilities allows providers to communicate additional
@@ -471,7 +563,7 @@ type ServerCapabilities struct {
 func (x *ServerCapabilities) Reset() {
 	*x = ServerCapabilities{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[9]
+		mi := &file_kfplugin_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -484,7 +576,7 @@ func (x *ServerCapabilities) String() string {
 func (*ServerCapabilities) ProtoMessage() {}
 
 func (x *ServerCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[9]
+	mi := &file_kfplugin_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -497,7 +589,7 @@ func (x *ServerCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerCapabilities.ProtoReflect.Descriptor instead.
 func (*ServerCapabilities) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{9}
+	return file_kfplugin_proto_rawDescGZIP(), []int{10}
 }
 
 type Diagnostic struct {
@@ -508,12 +600,18 @@ type Diagnostic struct {
 	Severity Severity `protobuf:"varint,1,opt,name=severity,proto3,enum=kfplugin1.Severity" json:"severity,omitempty"`
 	Detail   string   `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
 	Context  string   `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	Summary  string   `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`
+	// attribute points to the field in the object the diagnostic applies to
+	Attribute *AttributePath `protobuf:"bytes,5,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	// interrupt is set when the diagnostic reports an operation that did
+	// not complete
+	Interrupt Interrupt `protobuf:"varint,6,opt,name=interrupt,proto3,enum=kfplugin1.Interrupt" json:"interrupt,omitempty"`
 }
 
 func (x *Diagnostic) Reset() {
 	*x = Diagnostic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[10]
+		mi := &file_kfplugin_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -526,7 +624,7 @@ func (x *Diagnostic) String() string {
 func (*Diagnostic) ProtoMessage() {}
 
 func (x *Diagnostic) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[10]
+	mi := &file_kfplugin_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +637,7 @@ func (x *Diagnostic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
 func (*Diagnostic) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{10}
+	return file_kfplugin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Diagnostic) GetSeverity() Severity {
@@ -563,6 +661,77 @@ func (x *Diagnostic) GetContext() string {
 	return ""
 }
 
+func (x *Diagnostic) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetAttribute() *AttributePath {
+	if x != nil {
+		return x.Attribute
+	}
+	return nil
+}
+
+func (x *Diagnostic) GetInterrupt() Interrupt {
+	if x != nil {
+		return x.Interrupt
+	}
+	return Interrupt_NOT_INTERRUPTED
+}
+
+// AttributePath identifies a field in an object using the reference tokens
+// of a JSON pointer (RFC 6901), e.g. /spec/containers/0/image is represented
+// as the steps [spec, containers, 0, image].
+type AttributePath struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Steps []string `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *AttributePath) Reset() {
+	*x = AttributePath{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kfplugin_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttributePath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributePath) ProtoMessage() {}
+
+func (x *AttributePath) ProtoReflect() protoreflect.Message {
+	mi := &file_kfplugin_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributePath.ProtoReflect.Descriptor instead.
+func (*AttributePath) Descriptor() ([]byte, []int) {
+	return file_kfplugin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AttributePath) GetSteps() []string {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
 type GVK struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -576,7 +745,7 @@ type GVK struct {
 func (x *GVK) Reset() {
 	*x = GVK{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[11]
+		mi := &file_kfplugin_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -589,7 +758,7 @@ func (x *GVK) String() string {
 func (*GVK) ProtoMessage() {}
 
 func (x *GVK) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[11]
+	mi := &file_kfplugin_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +771,7 @@ func (x *GVK) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GVK.ProtoReflect.Descriptor instead.
 func (*GVK) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{11}
+	return file_kfplugin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GVK) GetGroup() string {
@@ -638,7 +807,7 @@ type NSN struct {
 func (x *NSN) Reset() {
 	*x = NSN{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[12]
+		mi := &file_kfplugin_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -651,7 +820,7 @@ func (x *NSN) String() string {
 func (*NSN) ProtoMessage() {}
 
 func (x *NSN) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[12]
+	mi := &file_kfplugin_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,7 +833,7 @@ func (x *NSN) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NSN.ProtoReflect.Descriptor instead.
 func (*NSN) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{12}
+	return file_kfplugin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NSN) GetNamespace() string {
@@ -703,7 +872,7 @@ type LabelSelector struct {
 func (x *LabelSelector) Reset() {
 	*x = LabelSelector{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[13]
+		mi := &file_kfplugin_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -716,7 +885,7 @@ func (x *LabelSelector) String() string {
 func (*LabelSelector) ProtoMessage() {}
 
 func (x *LabelSelector) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[13]
+	mi := &file_kfplugin_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -729,7 +898,7 @@ func (x *LabelSelector) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LabelSelector.ProtoReflect.Descriptor instead.
 func (*LabelSelector) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{13}
+	return file_kfplugin_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *LabelSelector) GetMatchLabels() map[string]string {
@@ -769,7 +938,7 @@ type LabelSelectorRequirement struct {
 func (x *LabelSelectorRequirement) Reset() {
 	*x = LabelSelectorRequirement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[14]
+		mi := &file_kfplugin_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -782,7 +951,7 @@ func (x *LabelSelectorRequirement) String() string {
 func (*LabelSelectorRequirement) ProtoMessage() {}
 
 func (x *LabelSelectorRequirement) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[14]
+	mi := &file_kfplugin_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,7 +964,7 @@ func (x *LabelSelectorRequirement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LabelSelectorRequirement.ProtoReflect.Descriptor instead.
 func (*LabelSelectorRequirement) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{14}
+	return file_kfplugin_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *LabelSelectorRequirement) GetKey() string {
@@ -828,7 +997,7 @@ type Capabilities_Request struct {
 func (x *Capabilities_Request) Reset() {
 	*x = Capabilities_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[15]
+		mi := &file_kfplugin_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -841,7 +1010,7 @@ func (x *Capabilities_Request) String() string {
 func (*Capabilities_Request) ProtoMessage() {}
 
 func (x *Capabilities_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[15]
+	mi := &file_kfplugin_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,12 +1036,17 @@ type Capabilities_Response struct {
 	ReadDataSources    []string            `protobuf:"bytes,3,rep,name=readDataSources,proto3" json:"readDataSources,omitempty"`
 	ListDataSources    []string            `protobuf:"bytes,4,rep,name=listDataSources,proto3" json:"listDataSources,omitempty"`
 	Resources          []string            `protobuf:"bytes,5,rep,name=resources,proto3" json:"resources,omitempty"`
+	// openapi v3 schema (json) of the provider config
+	ProviderConfigSchema []byte `protobuf:"bytes,6,opt,name=providerConfigSchema,proto3" json:"providerConfigSchema,omitempty"`
+	// openapi v3 schemas (json) of the resources and data sources keyed
+	// by resource type
+	ResourceSchemas map[string][]byte `protobuf:"bytes,7,rep,name=resourceSchemas,proto3" json:"resourceSchemas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *Capabilities_Response) Reset() {
 	*x = Capabilities_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[16]
+		mi := &file_kfplugin_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -885,7 +1059,7 @@ func (x *Capabilities_Response) String() string {
 func (*Capabilities_Response) ProtoMessage() {}
 
 func (x *Capabilities_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[16]
+	mi := &file_kfplugin_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -936,6 +1110,20 @@ func (x *Capabilities_Response) GetResources() []string {
 	return nil
 }
 
+func (x *Capabilities_Response) GetProviderConfigSchema() []byte {
+	if x != nil {
+		return x.ProviderConfigSchema
+	}
+	return nil
+}
+
+func (x *Capabilities_Response) GetResourceSchemas() map[string][]byte {
+	if x != nil {
+		return x.ResourceSchemas
+	}
+	return nil
+}
+
 type Configure_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -947,7 +1135,7 @@ type Configure_Request struct {
 func (x *Configure_Request) Reset() {
 	*x = Configure_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[17]
+		mi := &file_kfplugin_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -960,7 +1148,7 @@ func (x *Configure_Request) String() string {
 func (*Configure_Request) ProtoMessage() {}
 
 func (x *Configure_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[17]
+	mi := &file_kfplugin_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -994,7 +1182,7 @@ type Configure_Response struct {
 func (x *Configure_Response) Reset() {
 	*x = Configure_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[18]
+		mi := &file_kfplugin_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1007,7 +1195,7 @@ func (x *Configure_Response) String() string {
 func (*Configure_Response) ProtoMessage() {}
 
 func (x *Configure_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[18]
+	mi := &file_kfplugin_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,7 +1231,7 @@ type ReadDataSource_Request struct {
 func (x *ReadDataSource_Request) Reset() {
 	*x = ReadDataSource_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[19]
+		mi := &file_kfplugin_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1244,7 @@ func (x *ReadDataSource_Request) String() string {
 func (*ReadDataSource_Request) ProtoMessage() {}
 
 func (x *ReadDataSource_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[19]
+	mi := &file_kfplugin_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1105,7 +1293,7 @@ type ReadDataSource_Response struct {
 func (x *ReadDataSource_Response) Reset() {
 	*x = ReadDataSource_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[20]
+		mi := &file_kfplugin_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1118,7 +1306,7 @@ func (x *ReadDataSource_Response) String() string {
 func (*ReadDataSource_Response) ProtoMessage() {}
 
 func (x *ReadDataSource_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[20]
+	mi := &file_kfplugin_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1157,12 +1345,15 @@ type ListDataSource_Request struct {
 	Scope         Scope          `protobuf:"varint,2,opt,name=scope,proto3,enum=kfplugin1.Scope" json:"scope,omitempty"`
 	Obj           []byte         `protobuf:"bytes,3,opt,name=obj,proto3" json:"obj,omitempty"`
 	LabelSelector *LabelSelector `protobuf:"bytes,4,opt,name=labelSelector,proto3" json:"labelSelector,omitempty"`
+	// namespace restricts the list to a namespace, all namespaces are
+	// listed when empty
+	Namespace string `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
 func (x *ListDataSource_Request) Reset() {
 	*x = ListDataSource_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[21]
+		mi := &file_kfplugin_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1175,7 +1366,7 @@ func (x *ListDataSource_Request) String() string {
 func (*ListDataSource_Request) ProtoMessage() {}
 
 func (x *ListDataSource_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[21]
+	mi := &file_kfplugin_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1219,6 +1410,13 @@ func (x *ListDataSource_Request) GetLabelSelector() *LabelSelector {
 	return nil
 }
 
+func (x *ListDataSource_Request) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
 type ListDataSource_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1231,7 +1429,7 @@ type ListDataSource_Response struct {
 func (x *ListDataSource_Response) Reset() {
 	*x = ListDataSource_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[22]
+		mi := &file_kfplugin_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1244,7 +1442,7 @@ func (x *ListDataSource_Response) String() string {
 func (*ListDataSource_Response) ProtoMessage() {}
 
 func (x *ListDataSource_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[22]
+	mi := &file_kfplugin_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1287,7 +1485,7 @@ type ReadResource_Request struct {
 func (x *ReadResource_Request) Reset() {
 	*x = ReadResource_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[23]
+		mi := &file_kfplugin_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1300,7 +1498,7 @@ func (x *ReadResource_Request) String() string {
 func (*ReadResource_Request) ProtoMessage() {}
 
 func (x *ReadResource_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[23]
+	mi := &file_kfplugin_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1349,7 +1547,7 @@ type ReadResource_Response struct {
 func (x *ReadResource_Response) Reset() {
 	*x = ReadResource_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[24]
+		mi := &file_kfplugin_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1362,7 +1560,7 @@ func (x *ReadResource_Response) String() string {
 func (*ReadResource_Response) ProtoMessage() {}
 
 func (x *ReadResource_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[24]
+	mi := &file_kfplugin_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1406,7 +1604,7 @@ type CreateResource_Request struct {
 func (x *CreateResource_Request) Reset() {
 	*x = CreateResource_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[25]
+		mi := &file_kfplugin_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1419,7 +1617,7 @@ func (x *CreateResource_Request) String() string {
 func (*CreateResource_Request) ProtoMessage() {}
 
 func (x *CreateResource_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[25]
+	mi := &file_kfplugin_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1475,7 +1673,7 @@ type CreateResource_Response struct {
 func (x *CreateResource_Response) Reset() {
 	*x = CreateResource_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[26]
+		mi := &file_kfplugin_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1488,7 +1686,7 @@ func (x *CreateResource_Response) String() string {
 func (*CreateResource_Response) ProtoMessage() {}
 
 func (x *CreateResource_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[26]
+	mi := &file_kfplugin_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1528,12 +1726,14 @@ type UpdateResource_Request struct {
 	DryRun bool   `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
 	NewObj []byte `protobuf:"bytes,4,opt,name=newObj,proto3" json:"newObj,omitempty"`
 	OldObj []byte `protobuf:"bytes,5,opt,name=oldObj,proto3" json:"oldObj,omitempty"`
+	// oldState is the object the provider returned for the old object
+	OldState []byte `protobuf:"bytes,6,opt,name=oldState,proto3" json:"oldState,omitempty"`
 }
 
 func (x *UpdateResource_Request) Reset() {
 	*x = UpdateResource_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[27]
+		mi := &file_kfplugin_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1546,7 +1746,7 @@ func (x *UpdateResource_Request) String() string {
 func (*UpdateResource_Request) ProtoMessage() {}
 
 func (x *UpdateResource_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[27]
+	mi := &file_kfplugin_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1597,6 +1797,13 @@ func (x *UpdateResource_Request) GetOldObj() []byte {
 	return nil
 }
 
+func (x *UpdateResource_Request) GetOldState() []byte {
+	if x != nil {
+		return x.OldState
+	}
+	return nil
+}
+
 type UpdateResource_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1609,7 +1816,7 @@ type UpdateResource_Response struct {
 func (x *UpdateResource_Response) Reset() {
 	*x = UpdateResource_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[28]
+		mi := &file_kfplugin_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1622,7 +1829,7 @@ func (x *UpdateResource_Response) String() string {
 func (*UpdateResource_Response) ProtoMessage() {}
 
 func (x *UpdateResource_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[28]
+	mi := &file_kfplugin_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1652,6 +1859,108 @@ func (x *UpdateResource_Response) GetObj() []byte {
 	return nil
 }
 
+type ValidateResource_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Obj  []byte `protobuf:"bytes,2,opt,name=obj,proto3" json:"obj,omitempty"`
+}
+
+func (x *ValidateResource_Request) Reset() {
+	*x = ValidateResource_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kfplugin_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateResource_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResource_Request) ProtoMessage() {}
+
+func (x *ValidateResource_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_kfplugin_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResource_Request.ProtoReflect.Descriptor instead.
+func (*ValidateResource_Request) Descriptor() ([]byte, []int) {
+	return file_kfplugin_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *ValidateResource_Request) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ValidateResource_Request) GetObj() []byte {
+	if x != nil {
+		return x.Obj
+	}
+	return nil
+}
+
+type ValidateResource_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Diagnostics []*Diagnostic `protobuf:"bytes,1,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+}
+
+func (x *ValidateResource_Response) Reset() {
+	*x = ValidateResource_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kfplugin_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateResource_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResource_Response) ProtoMessage() {}
+
+func (x *ValidateResource_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_kfplugin_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResource_Response.ProtoReflect.Descriptor instead.
+func (*ValidateResource_Response) Descriptor() ([]byte, []int) {
+	return file_kfplugin_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *ValidateResource_Response) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
 type DeleteResource_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1666,7 +1975,7 @@ type DeleteResource_Request struct {
 func (x *DeleteResource_Request) Reset() {
 	*x = DeleteResource_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[29]
+		mi := &file_kfplugin_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1679,7 +1988,7 @@ func (x *DeleteResource_Request) String() string {
 func (*DeleteResource_Request) ProtoMessage() {}
 
 func (x *DeleteResource_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[29]
+	mi := &file_kfplugin_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1692,7 +2001,7 @@ func (x *DeleteResource_Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResource_Request.ProtoReflect.Descriptor instead.
 func (*DeleteResource_Request) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{7, 0}
+	return file_kfplugin_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *DeleteResource_Request) GetName() string {
@@ -1734,7 +2043,7 @@ type DeleteResource_Response struct {
 func (x *DeleteResource_Response) Reset() {
 	*x = DeleteResource_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[30]
+		mi := &file_kfplugin_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1747,7 +2056,7 @@ func (x *DeleteResource_Response) String() string {
 func (*DeleteResource_Response) ProtoMessage() {}
 
 func (x *DeleteResource_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[30]
+	mi := &file_kfplugin_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1760,7 +2069,7 @@ func (x *DeleteResource_Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResource_Response.ProtoReflect.Descriptor instead.
 func (*DeleteResource_Response) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{7, 1}
+	return file_kfplugin_proto_rawDescGZIP(), []int{8, 1}
 }
 
 func (x *DeleteResource_Response) GetDiagnostics() []*Diagnostic {
@@ -1779,7 +2088,7 @@ type StopProvider_Request struct {
 func (x *StopProvider_Request) Reset() {
 	*x = StopProvider_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[31]
+		mi := &file_kfplugin_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1792,7 +2101,7 @@ func (x *StopProvider_Request) String() string {
 func (*StopProvider_Request) ProtoMessage() {}
 
 func (x *StopProvider_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[31]
+	mi := &file_kfplugin_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1805,7 +2114,7 @@ func (x *StopProvider_Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopProvider_Request.ProtoReflect.Descriptor instead.
 func (*StopProvider_Request) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{8, 0}
+	return file_kfplugin_proto_rawDescGZIP(), []int{9, 0}
 }
 
 type StopProvider_Response struct {
@@ -1819,7 +2128,7 @@ type StopProvider_Response struct {
 func (x *StopProvider_Response) Reset() {
 	*x = StopProvider_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kfplugin_proto_msgTypes[32]
+		mi := &file_kfplugin_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1832,7 +2141,7 @@ func (x *StopProvider_Response) String() string {
 func (*StopProvider_Response) ProtoMessage() {}
 
 func (x *StopProvider_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_kfplugin_proto_msgTypes[32]
+	mi := &file_kfplugin_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1845,7 +2154,7 @@ func (x *StopProvider_Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopProvider_Response.ProtoReflect.Descriptor instead.
 func (*StopProvider_Response) Descriptor() ([]byte, []int) {
-	return file_kfplugin_proto_rawDescGZIP(), []int{8, 1}
+	return file_kfplugin_proto_rawDescGZIP(), []int{9, 1}
 }
 
 func (x *StopProvider_Response) GetDiagnostics() []*Diagnostic {
@@ -1859,9 +2168,9 @@ var File_kfplugin_proto protoreflect.FileDescriptor
 
 var file_kfplugin_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x09, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x22, 0xa0, 0x02, 0x0a, 0x0c,
+	0x12, 0x09, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x22, 0xf9, 0x03, 0x0a, 0x0c,
 	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x09, 0x0a, 0x07,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x84, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0xdd, 0x03, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
 	0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c,
 	0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
@@ -1877,203 +2186,249 @@ var file_kfplugin_proto_rawDesc = []byte{
 	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
 	0x0f, 0x6c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
 	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x73,
-	0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x1a, 0x21, 0x0a, 0x07, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x43,
-	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69,
-	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67,
-	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
-	0x69, 0x63, 0x73, 0x22, 0xc0, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x57, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31,
-	0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x6f, 0x62, 0x6a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x1a,
-	0x55, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64,
-	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61,
-	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
-	0x74, 0x69, 0x63, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x22, 0x81, 0x02, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x97, 0x01, 0x0a, 0x07, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
-	0x6f, 0x62, 0x6a, 0x12, 0x3e, 0x0a, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x66, 0x70,
-	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x1a, 0x55, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31,
-	0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61,
-	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x22, 0xbe, 0x01, 0x0a, 0x0c, 0x52,
-	0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x57, 0x0a, 0x07, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x63,
-	0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f,
-	0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x6f, 0x62, 0x6a, 0x1a, 0x55, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69,
-	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x22, 0xd8, 0x01, 0x0a, 0x0e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x6f,
-	0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a,
-	0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6b,
-	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05,
-	0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x10, 0x0a,
-	0x03, 0x6f, 0x62, 0x6a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x1a,
-	0x55, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64,
-	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61,
-	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
-	0x74, 0x69, 0x63, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x22, 0xf7, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x8d, 0x01, 0x0a, 0x07, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70,
-	0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x77,
-	0x4f, 0x62, 0x6a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6e, 0x65, 0x77, 0x4f, 0x62,
-	0x6a, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x6c, 0x64, 0x4f, 0x62, 0x6a, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x06, 0x6f, 0x6c, 0x64, 0x4f, 0x62, 0x6a, 0x1a, 0x55, 0x0a, 0x08, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
-	0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70,
-	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
-	0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x10,
-	0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a,
-	0x22, 0xc6, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x1a, 0x6f, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x32,
+	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x14, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x12, 0x5f, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x66,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x73, 0x1a, 0x42, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x73, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x1a, 0x21, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52,
+	0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0xc0, 0x01, 0x0a,
+	0x0e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a,
+	0x57, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26,
+	0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e,
+	0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x1a, 0x55, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x22,
+	0x9f, 0x02, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x1a, 0xb5, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
 	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
 	0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63,
-	0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72,
-	0x79, 0x52, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52,
-	0x75, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x6f, 0x62, 0x6a, 0x1a, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69,
-	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x5e, 0x0a, 0x0c, 0x53, 0x74, 0x6f,
-	0x70, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x1a, 0x09, 0x0a, 0x07, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69,
-	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22,
-	0x6f, 0x0a, 0x0a, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x2f, 0x0a,
-	0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x13, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x65, 0x76, 0x65,
-	0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16,
-	0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x22, 0x49, 0x0a, 0x03, 0x47, 0x56, 0x4b, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x37, 0x0a, 0x03, 0x4e,
-	0x53, 0x4e, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x22, 0xed, 0x01, 0x0a, 0x0d, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x4b, 0x0a, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x66,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x73, 0x12, 0x4f, 0x0a, 0x10, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
-	0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53,
-	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x10, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x7f, 0x0a, 0x18, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x15, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
-	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6b, 0x65, 0x79, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x2a, 0x31, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
-	0x79, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57,
-	0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x2a, 0x30, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70,
-	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x0d,
-	0x0a, 0x09, 0x4e, 0x41, 0x4d, 0x45, 0x53, 0x50, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a,
-	0x07, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x10, 0x02, 0x32, 0x8a, 0x06, 0x0a, 0x08, 0x50,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x51, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x61, 0x62,
-	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
-	0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x09, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a,
-	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
-	0x21, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62,
+	0x6a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x12, 0x3e, 0x0a, 0x0d,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0d, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x55, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62,
+	0x6a, 0x22, 0xbe, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x1a, 0x57, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x1a, 0x55, 0x0a, 0x08, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e,
+	0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b,
+	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f,
+	0x62, 0x6a, 0x22, 0xd8, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x6f, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e,
+	0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x1a, 0x55, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6f,
+	0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x22, 0x93, 0x02,
+	0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x1a, 0xa9, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x77, 0x4f, 0x62, 0x6a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x6e, 0x65, 0x77, 0x4f, 0x62, 0x6a, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x6c, 0x64, 0x4f,
+	0x62, 0x6a, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x6c, 0x64, 0x4f, 0x62, 0x6a,
+	0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x6f, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x55, 0x0a, 0x08,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x6f, 0x62, 0x6a, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x2f, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x1a, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0xc6,
+	0x01, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x1a, 0x6f, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x26, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x10, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6f,
+	0x62, 0x6a, 0x1a, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
+	0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e,
+	0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x5e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x1a, 0x09, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
+	0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e,
+	0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xf5, 0x01,
+	0x0a, 0x0a, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x2f, 0x0a, 0x08,
+	0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x09, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b,
+	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x12, 0x32, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x72, 0x75, 0x70, 0x74, 0x22, 0x25, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x22, 0x49, 0x0a, 0x03,
+	0x47, 0x56, 0x4b, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x37, 0x0a, 0x03, 0x4e, 0x53, 0x4e, 0x12, 0x1c,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0xed, 0x01, 0x0a, 0x0d, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x4b, 0x0a, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x4f, 0x0a, 0x10, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x66, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x10,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x3e, 0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x7f, 0x0a, 0x18, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x42, 0x06, 0x0a, 0x04,
+	0x5f, 0x6b, 0x65, 0x79, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x2a, 0x31, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0d, 0x0a,
+	0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49,
+	0x4e, 0x47, 0x10, 0x02, 0x2a, 0x3b, 0x0a, 0x09, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70,
+	0x74, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x52, 0x55,
+	0x50, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55,
+	0x54, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10,
+	0x02, 0x2a, 0x30, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e,
+	0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x41, 0x4d, 0x45, 0x53,
+	0x50, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45,
+	0x52, 0x10, 0x02, 0x32, 0xe9, 0x06, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x12, 0x51, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x1f, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x12, 0x1c, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a,
+	0x0e, 0x52, 0x65, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x21, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64,
 	0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6b, 0x66,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
-	0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e,
-	0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31,
-	0x2e, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x69, 0x77, 0x2d, 0x6e,
-	0x65, 0x70, 0x68, 0x69, 0x6f, 0x2f, 0x6b, 0x38, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x6b, 0x66,
-	0x6f, 0x72, 0x6d, 0x2d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x52,
+	0x65, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x66,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5d, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x23, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f,
+	0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x57, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6b,
+	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x66, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0c,
+	0x53, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x6b,
+	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
+	0x6b, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x69, 0x77, 0x2d, 0x6e, 0x65, 0x70, 0x68, 0x69, 0x6f, 0x2f, 0x6b, 0x38,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x6b, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x66, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2088,90 +2443,102 @@ func file_kfplugin_proto_rawDescGZIP() []byte {
 	return file_kfplugin_proto_rawDescData
 }
 
-var file_kfplugin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_kfplugin_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_kfplugin_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_kfplugin_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_kfplugin_proto_goTypes = []interface{}{
-	(Severity)(0),                    // 0: kfplugin1.Severity
-	(Scope)(0),                       // 1: kfplugin1.Scope
-	(*Capabilities)(nil),             // 2: kfplugin1.Capabilities
-	(*Configure)(nil),                // 3: kfplugin1.Configure
-	(*ReadDataSource)(nil),           // 4: kfplugin1.ReadDataSource
-	(*ListDataSource)(nil),           // 5: kfplugin1.ListDataSource
-	(*ReadResource)(nil),             // 6: kfplugin1.ReadResource
-	(*CreateResource)(nil),           // 7: kfplugin1.CreateResource
-	(*UpdateResource)(nil),           // 8: kfplugin1.UpdateResource
-	(*DeleteResource)(nil),           // 9: kfplugin1.DeleteResource
-	(*StopProvider)(nil),             // 10: kfplugin1.StopProvider
-	(*ServerCapabilities)(nil),       // 11: kfplugin1.ServerCapabilities
-	(*Diagnostic)(nil),               // 12: kfplugin1.Diagnostic
-	(*GVK)(nil),                      // 13: kfplugin1.GVK
-	(*NSN)(nil),                      // 14: kfplugin1.NSN
-	(*LabelSelector)(nil),            // 15: kfplugin1.LabelSelector
-	(*LabelSelectorRequirement)(nil), // 16: kfplugin1.LabelSelectorRequirement
-	(*Capabilities_Request)(nil),     // 17: kfplugin1.Capabilities.Request
-	(*Capabilities_Response)(nil),    // 18: kfplugin1.Capabilities.Response
-	(*Configure_Request)(nil),        // 19: kfplugin1.Configure.Request
-	(*Configure_Response)(nil),       // 20: kfplugin1.Configure.Response
-	(*ReadDataSource_Request)(nil),   // 21: kfplugin1.ReadDataSource.Request
-	(*ReadDataSource_Response)(nil),  // 22: kfplugin1.ReadDataSource.Response
-	(*ListDataSource_Request)(nil),   // 23: kfplugin1.ListDataSource.Request
-	(*ListDataSource_Response)(nil),  // 24: kfplugin1.ListDataSource.Response
-	(*ReadResource_Request)(nil),     // 25: kfplugin1.ReadResource.Request
-	(*ReadResource_Response)(nil),    // 26: kfplugin1.ReadResource.Response
-	(*CreateResource_Request)(nil),   // 27: kfplugin1.CreateResource.Request
-	(*CreateResource_Response)(nil),  // 28: kfplugin1.CreateResource.Response
-	(*UpdateResource_Request)(nil),   // 29: kfplugin1.UpdateResource.Request
-	(*UpdateResource_Response)(nil),  // 30: kfplugin1.UpdateResource.Response
-	(*DeleteResource_Request)(nil),   // 31: kfplugin1.DeleteResource.Request
-	(*DeleteResource_Response)(nil),  // 32: kfplugin1.DeleteResource.Response
-	(*StopProvider_Request)(nil),     // 33: kfplugin1.StopProvider.Request
-	(*StopProvider_Response)(nil),    // 34: kfplugin1.StopProvider.Response
-	nil,                              // 35: kfplugin1.LabelSelector.MatchLabelsEntry
+	(Severity)(0),                     // 0: kfplugin1.Severity
+	(Interrupt)(0),                    // 1: kfplugin1.Interrupt
+	(Scope)(0),                        // 2: kfplugin1.Scope
+	(*Capabilities)(nil),              // 3: kfplugin1.Capabilities
+	(*Configure)(nil),                 // 4: kfplugin1.Configure
+	(*ReadDataSource)(nil),            // 5: kfplugin1.ReadDataSource
+	(*ListDataSource)(nil),            // 6: kfplugin1.ListDataSource
+	(*ReadResource)(nil),              // 7: kfplugin1.ReadResource
+	(*CreateResource)(nil),            // 8: kfplugin1.CreateResource
+	(*UpdateResource)(nil),            // 9: kfplugin1.UpdateResource
+	(*ValidateResource)(nil),          // 10: kfplugin1.ValidateResource
+	(*DeleteResource)(nil),            // 11: kfplugin1.DeleteResource
+	(*StopProvider)(nil),              // 12: kfplugin1.StopProvider
+	(*ServerCapabilities)(nil),        // 13: kfplugin1.ServerCapabilities
+	(*Diagnostic)(nil),                // 14: kfplugin1.Diagnostic
+	(*AttributePath)(nil),             // 15: kfplugin1.AttributePath
+	(*GVK)(nil),                       // 16: kfplugin1.GVK
+	(*NSN)(nil),                       // 17: kfplugin1.NSN
+	(*LabelSelector)(nil),             // 18: kfplugin1.LabelSelector
+	(*LabelSelectorRequirement)(nil),  // 19: kfplugin1.LabelSelectorRequirement
+	(*Capabilities_Request)(nil),      // 20: kfplugin1.Capabilities.Request
+	(*Capabilities_Response)(nil),     // 21: kfplugin1.Capabilities.Response
+	nil,                               // 22: kfplugin1.Capabilities.Response.ResourceSchemasEntry
+	(*Configure_Request)(nil),         // 23: kfplugin1.Configure.Request
+	(*Configure_Response)(nil),        // 24: kfplugin1.Configure.Response
+	(*ReadDataSource_Request)(nil),    // 25: kfplugin1.ReadDataSource.Request
+	(*ReadDataSource_Response)(nil),   // 26: kfplugin1.ReadDataSource.Response
+	(*ListDataSource_Request)(nil),    // 27: kfplugin1.ListDataSource.Request
+	(*ListDataSource_Response)(nil),   // 28: kfplugin1.ListDataSource.Response
+	(*ReadResource_Request)(nil),      // 29: kfplugin1.ReadResource.Request
+	(*ReadResource_Response)(nil),     // 30: kfplugin1.ReadResource.Response
+	(*CreateResource_Request)(nil),    // 31: kfplugin1.CreateResource.Request
+	(*CreateResource_Response)(nil),   // 32: kfplugin1.CreateResource.Response
+	(*UpdateResource_Request)(nil),    // 33: kfplugin1.UpdateResource.Request
+	(*UpdateResource_Response)(nil),   // 34: kfplugin1.UpdateResource.Response
+	(*ValidateResource_Request)(nil),  // 35: kfplugin1.ValidateResource.Request
+	(*ValidateResource_Response)(nil), // 36: kfplugin1.ValidateResource.Response
+	(*DeleteResource_Request)(nil),    // 37: kfplugin1.DeleteResource.Request
+	(*DeleteResource_Response)(nil),   // 38: kfplugin1.DeleteResource.Response
+	(*StopProvider_Request)(nil),      // 39: kfplugin1.StopProvider.Request
+	(*StopProvider_Response)(nil),     // 40: kfplugin1.StopProvider.Response
+	nil,                               // 41: kfplugin1.LabelSelector.MatchLabelsEntry
 }
 var file_kfplugin_proto_depIdxs = []int32{
 	0,  // 0: kfplugin1.Diagnostic.severity:type_name -> kfplugin1.Severity
-	35, // 1: kfplugin1.LabelSelector.matchLabels:type_name -> kfplugin1.LabelSelector.MatchLabelsEntry
-	16, // 2: kfplugin1.LabelSelector.matchExpressions:type_name -> kfplugin1.LabelSelectorRequirement
-	12, // 3: kfplugin1.Capabilities.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	11, // 4: kfplugin1.Capabilities.Response.serverCapabilities:type_name -> kfplugin1.ServerCapabilities
-	12, // 5: kfplugin1.Configure.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	1,  // 6: kfplugin1.ReadDataSource.Request.scope:type_name -> kfplugin1.Scope
-	12, // 7: kfplugin1.ReadDataSource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	1,  // 8: kfplugin1.ListDataSource.Request.scope:type_name -> kfplugin1.Scope
-	15, // 9: kfplugin1.ListDataSource.Request.labelSelector:type_name -> kfplugin1.LabelSelector
-	12, // 10: kfplugin1.ListDataSource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	1,  // 11: kfplugin1.ReadResource.Request.scope:type_name -> kfplugin1.Scope
-	12, // 12: kfplugin1.ReadResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	1,  // 13: kfplugin1.CreateResource.Request.scope:type_name -> kfplugin1.Scope
-	12, // 14: kfplugin1.CreateResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	1,  // 15: kfplugin1.UpdateResource.Request.scope:type_name -> kfplugin1.Scope
-	12, // 16: kfplugin1.UpdateResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	1,  // 17: kfplugin1.DeleteResource.Request.scope:type_name -> kfplugin1.Scope
-	12, // 18: kfplugin1.DeleteResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	12, // 19: kfplugin1.StopProvider.Response.diagnostics:type_name -> kfplugin1.Diagnostic
-	17, // 20: kfplugin1.Provider.Capabilities:input_type -> kfplugin1.Capabilities.Request
-	19, // 21: kfplugin1.Provider.Configure:input_type -> kfplugin1.Configure.Request
-	21, // 22: kfplugin1.Provider.ReadDataSource:input_type -> kfplugin1.ReadDataSource.Request
-	23, // 23: kfplugin1.Provider.ListDataSource:input_type -> kfplugin1.ListDataSource.Request
-	25, // 24: kfplugin1.Provider.ReadResource:input_type -> kfplugin1.ReadResource.Request
-	27, // 25: kfplugin1.Provider.CreateResource:input_type -> kfplugin1.CreateResource.Request
-	29, // 26: kfplugin1.Provider.UpdateResource:input_type -> kfplugin1.UpdateResource.Request
-	31, // 27: kfplugin1.Provider.DeleteResource:input_type -> kfplugin1.DeleteResource.Request
-	33, // 28: kfplugin1.Provider.StopProvider:input_type -> kfplugin1.StopProvider.Request
-	18, // 29: kfplugin1.Provider.Capabilities:output_type -> kfplugin1.Capabilities.Response
-	20, // 30: kfplugin1.Provider.Configure:output_type -> kfplugin1.Configure.Response
-	22, // 31: kfplugin1.Provider.ReadDataSource:output_type -> kfplugin1.ReadDataSource.Response
-	24, // 32: kfplugin1.Provider.ListDataSource:output_type -> kfplugin1.ListDataSource.Response
-	26, // 33: kfplugin1.Provider.ReadResource:output_type -> kfplugin1.ReadResource.Response
-	28, // 34: kfplugin1.Provider.CreateResource:output_type -> kfplugin1.CreateResource.Response
-	30, // 35: kfplugin1.Provider.UpdateResource:output_type -> kfplugin1.UpdateResource.Response
-	32, // 36: kfplugin1.Provider.DeleteResource:output_type -> kfplugin1.DeleteResource.Response
-	34, // 37: kfplugin1.Provider.StopProvider:output_type -> kfplugin1.StopProvider.Response
-	29, // [29:38] is the sub-list for method output_type
-	20, // [20:29] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	15, // 1: kfplugin1.Diagnostic.attribute:type_name -> kfplugin1.AttributePath
+	1,  // 2: kfplugin1.Diagnostic.interrupt:type_name -> kfplugin1.Interrupt
+	41, // 3: kfplugin1.LabelSelector.matchLabels:type_name -> kfplugin1.LabelSelector.MatchLabelsEntry
+	19, // 4: kfplugin1.LabelSelector.matchExpressions:type_name -> kfplugin1.LabelSelectorRequirement
+	14, // 5: kfplugin1.Capabilities.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	13, // 6: kfplugin1.Capabilities.Response.serverCapabilities:type_name -> kfplugin1.ServerCapabilities
+	22, // 7: kfplugin1.Capabilities.Response.resourceSchemas:type_name -> kfplugin1.Capabilities.Response.ResourceSchemasEntry
+	14, // 8: kfplugin1.Configure.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	2,  // 9: kfplugin1.ReadDataSource.Request.scope:type_name -> kfplugin1.Scope
+	14, // 10: kfplugin1.ReadDataSource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	2,  // 11: kfplugin1.ListDataSource.Request.scope:type_name -> kfplugin1.Scope
+	18, // 12: kfplugin1.ListDataSource.Request.labelSelector:type_name -> kfplugin1.LabelSelector
+	14, // 13: kfplugin1.ListDataSource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	2,  // 14: kfplugin1.ReadResource.Request.scope:type_name -> kfplugin1.Scope
+	14, // 15: kfplugin1.ReadResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	2,  // 16: kfplugin1.CreateResource.Request.scope:type_name -> kfplugin1.Scope
+	14, // 17: kfplugin1.CreateResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	2,  // 18: kfplugin1.UpdateResource.Request.scope:type_name -> kfplugin1.Scope
+	14, // 19: kfplugin1.UpdateResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	14, // 20: kfplugin1.ValidateResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	2,  // 21: kfplugin1.DeleteResource.Request.scope:type_name -> kfplugin1.Scope
+	14, // 22: kfplugin1.DeleteResource.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	14, // 23: kfplugin1.StopProvider.Response.diagnostics:type_name -> kfplugin1.Diagnostic
+	20, // 24: kfplugin1.Provider.Capabilities:input_type -> kfplugin1.Capabilities.Request
+	23, // 25: kfplugin1.Provider.Configure:input_type -> kfplugin1.Configure.Request
+	25, // 26: kfplugin1.Provider.ReadDataSource:input_type -> kfplugin1.ReadDataSource.Request
+	27, // 27: kfplugin1.Provider.ListDataSource:input_type -> kfplugin1.ListDataSource.Request
+	35, // 28: kfplugin1.Provider.ValidateResource:input_type -> kfplugin1.ValidateResource.Request
+	29, // 29: kfplugin1.Provider.ReadResource:input_type -> kfplugin1.ReadResource.Request
+	31, // 30: kfplugin1.Provider.CreateResource:input_type -> kfplugin1.CreateResource.Request
+	33, // 31: kfplugin1.Provider.UpdateResource:input_type -> kfplugin1.UpdateResource.Request
+	37, // 32: kfplugin1.Provider.DeleteResource:input_type -> kfplugin1.DeleteResource.Request
+	39, // 33: kfplugin1.Provider.StopProvider:input_type -> kfplugin1.StopProvider.Request
+	21, // 34: kfplugin1.Provider.Capabilities:output_type -> kfplugin1.Capabilities.Response
+	24, // 35: kfplugin1.Provider.Configure:output_type -> kfplugin1.Configure.Response
+	26, // 36: kfplugin1.Provider.ReadDataSource:output_type -> kfplugin1.ReadDataSource.Response
+	28, // 37: kfplugin1.Provider.ListDataSource:output_type -> kfplugin1.ListDataSource.Response
+	36, // 38: kfplugin1.Provider.ValidateResource:output_type -> kfplugin1.ValidateResource.Response
+	30, // 39: kfplugin1.Provider.ReadResource:output_type -> kfplugin1.ReadResource.Response
+	32, // 40: kfplugin1.Provider.CreateResource:output_type -> kfplugin1.CreateResource.Response
+	34, // 41: kfplugin1.Provider.UpdateResource:output_type -> kfplugin1.UpdateResource.Response
+	38, // 42: kfplugin1.Provider.DeleteResource:output_type -> kfplugin1.DeleteResource.Response
+	40, // 43: kfplugin1.Provider.StopProvider:output_type -> kfplugin1.StopProvider.Response
+	34, // [34:44] is the sub-list for method output_type
+	24, // [24:34] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_kfplugin_proto_init() }
@@ -2265,7 +2632,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteResource); i {
+			switch v := v.(*ValidateResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2277,7 +2644,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopProvider); i {
+			switch v := v.(*DeleteResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2289,7 +2656,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerCapabilities); i {
+			switch v := v.(*StopProvider); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2301,7 +2668,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Diagnostic); i {
+			switch v := v.(*ServerCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2313,7 +2680,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GVK); i {
+			switch v := v.(*Diagnostic); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2325,7 +2692,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NSN); i {
+			switch v := v.(*AttributePath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2337,7 +2704,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LabelSelector); i {
+			switch v := v.(*GVK); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2349,7 +2716,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LabelSelectorRequirement); i {
+			switch v := v.(*NSN); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2361,7 +2728,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Capabilities_Request); i {
+			switch v := v.(*LabelSelector); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2373,7 +2740,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Capabilities_Response); i {
+			switch v := v.(*LabelSelectorRequirement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2385,7 +2752,7 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Configure_Request); i {
+			switch v := v.(*Capabilities_Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2397,6 +2764,30 @@ func file_kfplugin_proto_init() {
 			}
 		}
 		file_kfplugin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capabilities_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kfplugin_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Configure_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kfplugin_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Configure_Response); i {
 			case 0:
 				return &v.state
@@ -2408,7 +2799,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadDataSource_Request); i {
 			case 0:
 				return &v.state
@@ -2420,7 +2811,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadDataSource_Response); i {
 			case 0:
 				return &v.state
@@ -2432,7 +2823,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListDataSource_Request); i {
 			case 0:
 				return &v.state
@@ -2444,7 +2835,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListDataSource_Response); i {
 			case 0:
 				return &v.state
@@ -2456,7 +2847,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadResource_Request); i {
 			case 0:
 				return &v.state
@@ -2468,7 +2859,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadResource_Response); i {
 			case 0:
 				return &v.state
@@ -2480,7 +2871,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateResource_Request); i {
 			case 0:
 				return &v.state
@@ -2492,7 +2883,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateResource_Response); i {
 			case 0:
 				return &v.state
@@ -2504,7 +2895,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateResource_Request); i {
 			case 0:
 				return &v.state
@@ -2516,7 +2907,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateResource_Response); i {
 			case 0:
 				return &v.state
@@ -2528,7 +2919,31 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateResource_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kfplugin_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateResource_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kfplugin_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteResource_Request); i {
 			case 0:
 				return &v.state
@@ -2540,7 +2955,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteResource_Response); i {
 			case 0:
 				return &v.state
@@ -2552,7 +2967,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StopProvider_Request); i {
 			case 0:
 				return &v.state
@@ -2564,7 +2979,7 @@ func file_kfplugin_proto_init() {
 				return nil
 			}
 		}
-		file_kfplugin_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_kfplugin_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StopProvider_Response); i {
 			case 0:
 				return &v.state
@@ -2577,14 +2992,14 @@ func file_kfplugin_proto_init() {
 			}
 		}
 	}
-	file_kfplugin_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	file_kfplugin_proto_msgTypes[16].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kfplugin_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   34,
+			NumEnums:      3,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -7,6 +7,7 @@ import (
 
 	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1"
 	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw/logger/log"
 )
 
@@ -64,11 +65,22 @@ func (s *server) cancelContext(ctx context.Context) context.Context {
 	return ctx
 }
 
+// loggerContext returns a context with a logger tagged with the rpc and
+// the address of the resource that triggered the call, such that logs of the
+// provider can be attributed by kform.
+func (s *server) loggerContext(ctx context.Context, rpc string) (context.Context, *slog.Logger) {
+	l := s.l.With("rpc", rpc)
+	if address := kfplugin.GetResourceAddress(ctx); address != "" {
+		l = l.With("resource", address)
+	}
+	return log.IntoContext(ctx, l), l
+}
+
 func (s *server) Capabilities(ctx context.Context, in *kfplugin1.Capabilities_Request) (*kfplugin1.Capabilities_Response, error) {
 	// todo add ctx + tracing
 	rpc := "capabilities"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.Capabilities(ctx, in)
@@ -83,7 +95,7 @@ func (s *server) Configure(ctx context.Context, in *kfplugin1.Configure_Request)
 	// todo add ctx + tracing
 	rpc := "configure"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.Configure(ctx, in)
@@ -97,7 +109,7 @@ func (s *server) StopProvider(ctx context.Context, in *kfplugin1.StopProvider_Re
 	// todo add ctx + tracing
 	rpc := "stopProvider"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.StopProvider(ctx, in)
@@ -118,7 +130,7 @@ func (s *server) ReadDataSource(ctx context.Context, in *kfplugin1.ReadDataSourc
 	// todo add ctx + tracing
 	rpc := "readDataSource"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.ReadDataSource(ctx, in)
@@ -133,7 +145,7 @@ func (s *server) ListDataSource(ctx context.Context, in *kfplugin1.ListDataSourc
 	// todo add ctx + tracing
 	rpc := "listDataSource"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.ListDataSource(ctx, in)
@@ -146,9 +158,9 @@ func (s *server) ListDataSource(ctx context.Context, in *kfplugin1.ListDataSourc
 
 func (s *server) ReadResource(ctx context.Context, in *kfplugin1.ReadResource_Request) (*kfplugin1.ReadResource_Response, error) {
 	// todo add ctx + tracing
-	rpc := "readDataSource"
+	rpc := "readResource"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.ReadResource(ctx, in)
@@ -159,11 +171,26 @@ func (s *server) ReadResource(ctx context.Context, in *kfplugin1.ReadResource_Re
 	return resp, nil
 }
 
+func (s *server) ValidateResource(ctx context.Context, in *kfplugin1.ValidateResource_Request) (*kfplugin1.ValidateResource_Response, error) {
+	// todo add ctx + tracing
+	rpc := "validateResource"
+	ctx = s.cancelContext(ctx)
+	ctx, log := s.loggerContext(ctx, rpc)
+	log.Info(rpc)
+
+	resp, err := s.provider.ValidateResource(ctx, in)
+	if err != nil {
+		log.Error(rpc, "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (s *server) CreateResource(ctx context.Context, in *kfplugin1.CreateResource_Request) (*kfplugin1.CreateResource_Response, error) {
 	// todo add ctx + tracing
-	rpc := "createDataSource"
+	rpc := "createResource"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.CreateResource(ctx, in)
@@ -176,9 +203,9 @@ func (s *server) CreateResource(ctx context.Context, in *kfplugin1.CreateResourc
 
 func (s *server) UpdateResource(ctx context.Context, in *kfplugin1.UpdateResource_Request) (*kfplugin1.UpdateResource_Response, error) {
 	// todo add ctx + tracing
-	rpc := "updateDataSource"
+	rpc := "updateResource"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.UpdateResource(ctx, in)
@@ -191,9 +218,9 @@ func (s *server) UpdateResource(ctx context.Context, in *kfplugin1.UpdateResourc
 
 func (s *server) DeleteResource(ctx context.Context, in *kfplugin1.DeleteResource_Request) (*kfplugin1.DeleteResource_Response, error) {
 	// todo add ctx + tracing
-	rpc := "deleteDataSource"
+	rpc := "deleteResource"
 	ctx = s.cancelContext(ctx)
-	log := s.l
+	ctx, log := s.loggerContext(ctx, rpc)
 	log.Info(rpc)
 
 	resp, err := s.provider.DeleteResource(ctx, in)
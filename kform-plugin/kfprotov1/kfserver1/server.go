@@ -1,7 +1,15 @@
 package kfserver1
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
 
 	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1"
 	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
@@ -27,6 +35,10 @@ func (s serveConfigFunc) ApplyServeOpt(in *ServeConfig) error {
 
 type ServeConfig struct {
 	logger *slog.Logger
+
+	debugCtx     context.Context
+	debugConfig  chan *plugin.ReattachConfig
+	debugCloseCh chan struct{}
 }
 
 // WithGoPluginLogger returns a ServeOpt that will set the logger that
@@ -38,6 +50,65 @@ func WithGoPluginLogger(logger *slog.Logger) ServeOpt {
 	})
 }
 
+// WithDebug returns a ServeOpt that will set the server into debug mode, using
+// the passed options to populate the go-plugin ServeTestConfig.
+func WithDebug(ctx context.Context, config chan *plugin.ReattachConfig, closeCh chan struct{}) ServeOpt {
+	return serveConfigFunc(func(in *ServeConfig) error {
+		in.debugCtx = ctx
+		in.debugConfig = config
+		in.debugCloseCh = closeCh
+		return nil
+	})
+}
+
+// Debug starts the provider in debug mode, e.g. under a debugger. Instead of
+// being started by kform, the provider prints the reattach configuration
+// kform uses to connect to it through the KFORM_REATTACH_PROVIDERS
+// environment variable. Debug blocks till the context is cancelled or the
+// provider is stopped.
+func Debug(ctx context.Context, name string, serverFactory func() kfprotov1.ProviderServer, opts ...ServeOpt) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	config := make(chan *plugin.ReattachConfig)
+	closeCh := make(chan struct{})
+	opts = append(opts, WithDebug(ctx, config, closeCh))
+	if err := Serve(name, serverFactory, opts...); err != nil {
+		return err
+	}
+
+	var reattachConfig *plugin.ReattachConfig
+	select {
+	case reattachConfig = <-config:
+	case <-closeCh:
+		return fmt.Errorf("provider %s exited before it was ready", name)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if err := printReattachConfig(os.Stdout, name, reattachConfig); err != nil {
+		return err
+	}
+
+	// wait till the provider is stopped
+	<-closeCh
+	return nil
+}
+
+// printReattachConfig prints the KFORM_REATTACH_PROVIDERS env var kform
+// uses to reattach to the provider, keyed by the name of the provider
+func printReattachConfig(w io.Writer, name string, reattachConfig *plugin.ReattachConfig) error {
+	b, err := json.Marshal(map[string]kfplugin.ReattachConfig{
+		path.Base(name): kfplugin.NewReattachConfig(reattachConfig),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Provider started, to attach kform set the %s env var:\n\n", kfplugin.EnvReattachProviders)
+	fmt.Fprintf(w, "\t%s='%s'\n\n", kfplugin.EnvReattachProviders, string(b))
+	return nil
+}
+
 func Serve(name string, serverFactory func() kfprotov1.ProviderServer, opts ...ServeOpt) error {
 	conf := ServeConfig{}
 	for _, opt := range opts {
@@ -67,9 +138,16 @@ func Serve(name string, serverFactory func() kfprotov1.ProviderServer, opts ...S
 	}
 
 	// in case of debug this becomes non blocking
+	if conf.debugCtx != nil {
+		serveConfig.Test = &plugin.ServeTestConfig{
+			Context:          conf.debugCtx,
+			ReattachConfigCh: conf.debugConfig,
+			CloseCh:          conf.debugCloseCh,
+		}
+		go plugin.Serve(serveConfig)
+		return nil
+	}
 	plugin.Serve(serveConfig)
 
-	// TODO debug
-
 	return nil
 }
@@ -0,0 +1,57 @@
+package kfserver1
+
+import (
+	"bytes"
+	"net"
+	"regexp"
+	"testing"
+
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"github.com/henderiw-nephio/kform/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintReattachConfig(t *testing.T) {
+	cases := map[string]struct {
+		name string
+		addr net.Addr
+		want string
+	}{
+		"Unix": {
+			name: "kubernetes",
+			addr: &net.UnixAddr{Net: "unix", Name: "/tmp/plugin123"},
+			want: `{"kubernetes":{"protocol_version":1,"pid":1234,"test":true,"addr":{"network":"unix","string":"/tmp/plugin123"}}}`,
+		},
+		"TCP": {
+			name: "kubernetes",
+			addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5000},
+			want: `{"kubernetes":{"protocol_version":1,"pid":1234,"test":true,"addr":{"network":"tcp","string":"127.0.0.1:5000"}}}`,
+		},
+		"Path": {
+			name: "github.com/henderiw-nephio/kform/providers/kubernetes",
+			addr: &net.UnixAddr{Net: "unix", Name: "/tmp/plugin123"},
+			want: `{"kubernetes":{"protocol_version":1,"pid":1234,"test":true,"addr":{"network":"unix","string":"/tmp/plugin123"}}}`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cfg := &plugin.ReattachConfig{ProtocolVersion: 1, Pid: 1234, Test: true, Addr: tc.addr}
+			if err := printReattachConfig(&buf, tc.name, cfg); err != nil {
+				t.Fatalf("cannot print reattach config: %s", err.Error())
+			}
+			m := regexp.MustCompile(kfplugin.EnvReattachProviders + `='(.*)'`).FindStringSubmatch(buf.String())
+			if len(m) != 2 {
+				t.Fatalf("want %s env var in output, got: %s", kfplugin.EnvReattachProviders, buf.String())
+			}
+			assert.Equal(t, tc.want, m[1])
+
+			// kform reattaches to the provider with the printed env var
+			t.Setenv(kfplugin.EnvReattachProviders, m[1])
+			providers, err := kfplugin.GetReattachProviders()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.addr.String(), providers["kubernetes"].Addr.String())
+			assert.Equal(t, cfg.Pid, providers["kubernetes"].Pid)
+		})
+	}
+}
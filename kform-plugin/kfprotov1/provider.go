@@ -21,6 +21,7 @@ type DataSourceServer interface {
 }
 
 type ResourceServer interface {
+	ValidateResource(ctx context.Context, in *kfplugin1.ValidateResource_Request) (*kfplugin1.ValidateResource_Response, error)
 	ReadResource(ctx context.Context, in *kfplugin1.ReadResource_Request) (*kfplugin1.ReadResource_Response, error)
 	CreateResource(ctx context.Context, in *kfplugin1.CreateResource_Request) (*kfplugin1.CreateResource_Response, error)
 	UpdateResource(ctx context.Context, in *kfplugin1.UpdateResource_Request) (*kfplugin1.UpdateResource_Response, error)
@@ -16,6 +16,7 @@ type Provider interface {
 	ReadDataSource(ctx context.Context, req *kfplugin1.ReadDataSource_Request) (*kfplugin1.ReadDataSource_Response, error)
 	ListDataSource(ctx context.Context, req *kfplugin1.ListDataSource_Request) (*kfplugin1.ListDataSource_Response, error)
 	//ReadResource(ctx context.Context, req *kfplugin1.ReadResource_Request) (*kfplugin1.ReadResource_Response, error)
+	ValidateResource(ctx context.Context, req *kfplugin1.ValidateResource_Request) (*kfplugin1.ValidateResource_Response, error)
 	CreateResource(ctx context.Context, req *kfplugin1.CreateResource_Request) (*kfplugin1.CreateResource_Response, error)
 	UpdateResource(ctx context.Context, req *kfplugin1.UpdateResource_Request) (*kfplugin1.UpdateResource_Response, error)
 	DeleteResource(ctx context.Context, req *kfplugin1.DeleteResource_Request) (*kfplugin1.DeleteResource_Response, error)
@@ -93,6 +94,10 @@ func (r *GRPCProvider) ReadResource(ctx context.Context, req *kfplugin1.ReadReso
 	return r.client.ReadResource(ctx, req)
 }
 
+func (r *GRPCProvider) ValidateResource(ctx context.Context, req *kfplugin1.ValidateResource_Request) (*kfplugin1.ValidateResource_Response, error) {
+	return r.client.ValidateResource(ctx, req)
+}
+
 func (r *GRPCProvider) CreateResource(ctx context.Context, req *kfplugin1.CreateResource_Request) (*kfplugin1.CreateResource_Response, error) {
 	return r.client.CreateResource(ctx, req)
 }
@@ -105,6 +110,14 @@ func (r *GRPCProvider) DeleteResource(ctx context.Context, req *kfplugin1.Delete
 	return r.client.DeleteResource(ctx, req)
 }
 
+// Exited returns true when the provider process has exited, e.g. because
+// it crashed.
+func (r *GRPCProvider) Exited() bool {
+	if r.PluginClient == nil {
+		return false
+	}
+	return r.PluginClient.Exited()
+}
 
 func (r *GRPCProvider) Close(ctx context.Context) {
 	log := log.FromContext(ctx)
@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw/logger/log"
+	"google.golang.org/grpc/metadata"
+)
+
+// NewInProcessProvider returns a provider that calls the provider server
+// directly, without a plugin process. The outgoing gRPC metadata of the
+// caller is passed to the server as incoming metadata, like the gRPC
+// transport does for a plugin process.
+func NewInProcessProvider(server kfplugin1.ProviderServer) Provider {
+	return &inProcessProvider{server: server}
+}
+
+type inProcessProvider struct {
+	server kfplugin1.ProviderServer
+}
+
+func (r *inProcessProvider) Capabilities(ctx context.Context, req *kfplugin1.Capabilities_Request) (*kfplugin1.Capabilities_Response, error) {
+	return r.server.Capabilities(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) Configure(ctx context.Context, req *kfplugin1.Configure_Request) (*kfplugin1.Configure_Response, error) {
+	return r.server.Configure(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) StopProvider(ctx context.Context, req *kfplugin1.StopProvider_Request) (*kfplugin1.StopProvider_Response, error) {
+	return r.server.StopProvider(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) ReadDataSource(ctx context.Context, req *kfplugin1.ReadDataSource_Request) (*kfplugin1.ReadDataSource_Response, error) {
+	return r.server.ReadDataSource(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) ListDataSource(ctx context.Context, req *kfplugin1.ListDataSource_Request) (*kfplugin1.ListDataSource_Response, error) {
+	return r.server.ListDataSource(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) ValidateResource(ctx context.Context, req *kfplugin1.ValidateResource_Request) (*kfplugin1.ValidateResource_Response, error) {
+	return r.server.ValidateResource(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) CreateResource(ctx context.Context, req *kfplugin1.CreateResource_Request) (*kfplugin1.CreateResource_Response, error) {
+	return r.server.CreateResource(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) UpdateResource(ctx context.Context, req *kfplugin1.UpdateResource_Request) (*kfplugin1.UpdateResource_Response, error) {
+	return r.server.UpdateResource(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) DeleteResource(ctx context.Context, req *kfplugin1.DeleteResource_Request) (*kfplugin1.DeleteResource_Response, error) {
+	return r.server.DeleteResource(toIncoming(ctx), req)
+}
+
+func (r *inProcessProvider) Close(ctx context.Context) {
+	log := log.FromContext(ctx)
+	log.Debug("inProcessProvider: Close")
+	if _, err := r.server.StopProvider(toIncoming(ctx), &kfplugin1.StopProvider_Request{}); err != nil {
+		log.Debug("cannot stop provider", "error", err.Error())
+	}
+}
+
+// toIncoming returns a context with the outgoing gRPC metadata of the context
+// as incoming metadata
+func toIncoming(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
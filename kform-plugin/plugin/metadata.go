@@ -0,0 +1,72 @@
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ResourceAddressKey is the gRPC metadata key used to pass the address of
+// the resource that triggered a provider call, such that the provider can
+// tag its logs with it.
+const ResourceAddressKey = "kform-resource-address"
+
+// ModuleKey is the gRPC metadata key used to pass the module of the resource
+// that triggered a provider call.
+const ModuleKey = "kform-module"
+
+// RunIDKey is the gRPC metadata key used to pass the id of the kform run that
+// triggered a provider call.
+const RunIDKey = "kform-run-id"
+
+// Owner identifies the kform run and resource that manage an object, the
+// provider stamps the owner on the objects it manages such that it can refuse
+// to change objects owned by someone else.
+type Owner struct {
+	Module  string
+	Address string
+	RunID   string
+}
+
+// WithResourceAddress returns a context that passes the resource address
+// to the provider in the outgoing gRPC metadata.
+func WithResourceAddress(ctx context.Context, address string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, ResourceAddressKey, address)
+}
+
+// GetResourceAddress returns the resource address of the incoming gRPC
+// metadata or an empty string if not present.
+func GetResourceAddress(ctx context.Context) string {
+	return getIncoming(ctx, ResourceAddressKey)
+}
+
+// WithOwner returns a context that passes the owner to the provider in the
+// outgoing gRPC metadata.
+func WithOwner(ctx context.Context, owner Owner) context.Context {
+	return metadata.AppendToOutgoingContext(ctx,
+		ResourceAddressKey, owner.Address,
+		ModuleKey, owner.Module,
+		RunIDKey, owner.RunID,
+	)
+}
+
+// GetOwner returns the owner of the incoming gRPC metadata, the fields are
+// empty when not present.
+func GetOwner(ctx context.Context) Owner {
+	return Owner{
+		Module:  getIncoming(ctx, ModuleKey),
+		Address: getIncoming(ctx, ResourceAddressKey),
+		RunID:   getIncoming(ctx, RunIDKey),
+	}
+}
+
+func getIncoming(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
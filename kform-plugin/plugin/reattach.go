@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/henderiw-nephio/kform/plugin"
+)
+
+// EnvReattachProviders is the environment variable that holds the reattach
+// configuration of the providers that run in debug mode, keyed by the
+// provider name, e.g.
+//
+//	KFORM_REATTACH_PROVIDERS='{"kubernetes":{"protocol_version":1,"pid":1234,"test":true,"addr":{"network":"unix","string":"/tmp/plugin123"}}}'
+const EnvReattachProviders = "KFORM_REATTACH_PROVIDERS"
+
+// ReattachConfig is the json representation of a plugin.ReattachConfig
+type ReattachConfig struct {
+	ProtocolVersion int                `json:"protocol_version"`
+	Pid             int                `json:"pid"`
+	Test            bool               `json:"test"`
+	Addr            ReattachConfigAddr `json:"addr"`
+}
+
+// ReattachConfigAddr is the json representation of a net.Addr
+type ReattachConfigAddr struct {
+	Network string `json:"network"`
+	String  string `json:"string"`
+}
+
+// NewReattachConfig returns the json representation of the plugin.ReattachConfig
+func NewReattachConfig(cfg *plugin.ReattachConfig) ReattachConfig {
+	return ReattachConfig{
+		ProtocolVersion: cfg.ProtocolVersion,
+		Pid:             cfg.Pid,
+		Test:            cfg.Test,
+		Addr: ReattachConfigAddr{
+			Network: cfg.Addr.Network(),
+			String:  cfg.Addr.String(),
+		},
+	}
+}
+
+// GetPluginReattachConfig returns the plugin.ReattachConfig to connect to the
+// plugin
+func (r ReattachConfig) GetPluginReattachConfig() (*plugin.ReattachConfig, error) {
+	var addr net.Addr
+	var err error
+	switch r.Addr.Network {
+	case "unix":
+		addr, err = net.ResolveUnixAddr("unix", r.Addr.String)
+	case "tcp":
+		addr, err = net.ResolveTCPAddr("tcp", r.Addr.String)
+	default:
+		return nil, fmt.Errorf("unknown address type %q for %s", r.Addr.Network, r.Addr.String)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ReattachConfig{
+		ProtocolVersion: r.ProtocolVersion,
+		Pid:             r.Pid,
+		Test:            r.Test,
+		Addr:            addr,
+	}, nil
+}
+
+// GetReattachProviders returns the reattach configuration of the providers
+// from the KFORM_REATTACH_PROVIDERS environment variable keyed by provider name.
+func GetReattachProviders() (map[string]*plugin.ReattachConfig, error) {
+	reattachProviders := map[string]*plugin.ReattachConfig{}
+	v := os.Getenv(EnvReattachProviders)
+	if v == "" {
+		return reattachProviders, nil
+	}
+	cfgs := map[string]ReattachConfig{}
+	if err := json.Unmarshal([]byte(v), &cfgs); err != nil {
+		return nil, fmt.Errorf("cannot parse %s, err: %s", EnvReattachProviders, err.Error())
+	}
+	for name, cfg := range cfgs {
+		reattachCfg, err := cfg.GetPluginReattachConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s for provider %s, err: %s", EnvReattachProviders, name, err.Error())
+		}
+		reattachProviders[name] = reattachCfg
+	}
+	return reattachProviders, nil
+}
@@ -0,0 +1,56 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReattachProviders(t *testing.T) {
+	cases := map[string]struct {
+		env       string
+		want      map[string]string
+		expectErr bool
+	}{
+		"Empty": {
+			want: map[string]string{},
+		},
+		"Unix": {
+			env:  `{"kubernetes":{"protocol_version":1,"pid":1234,"test":true,"addr":{"network":"unix","string":"/tmp/plugin123"}}}`,
+			want: map[string]string{"kubernetes": "unix:/tmp/plugin123"},
+		},
+		"Multiple": {
+			env: `{"kubernetes":{"protocol_version":1,"pid":1234,"test":true,"addr":{"network":"unix","string":"/tmp/plugin123"}},` +
+				`"resourcebackend":{"protocol_version":1,"pid":1235,"test":true,"addr":{"network":"tcp","string":"127.0.0.1:5000"}}}`,
+			want: map[string]string{"kubernetes": "unix:/tmp/plugin123", "resourcebackend": "tcp:127.0.0.1:5000"},
+		},
+		"InvalidJSON": {
+			env:       `{"kubernetes":`,
+			expectErr: true,
+		},
+		"UnknownNetwork": {
+			env:       `{"kubernetes":{"protocol_version":1,"pid":1234,"addr":{"network":"udp","string":"127.0.0.1:5000"}}}`,
+			expectErr: true,
+		},
+		"InvalidAddress": {
+			env:       `{"kubernetes":{"protocol_version":1,"pid":1234,"addr":{"network":"tcp","string":"localhost"}}}`,
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnvReattachProviders, tc.env)
+			providers, err := GetReattachProviders()
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			got := map[string]string{}
+			for name, cfg := range providers {
+				got[name] = cfg.Addr.Network() + ":" + cfg.Addr.String()
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package plugin
+
+// EnvStateDir is the environment variable that holds the state directory of
+// kform. Kform sets it for the providers it starts, such that a provider can
+// persist data next to the state, e.g.
+//
+//	KFORM_STATE_DIR=/home/user/pkg/.kform
+const EnvStateDir = "KFORM_STATE_DIR"
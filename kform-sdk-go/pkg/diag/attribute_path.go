@@ -0,0 +1,40 @@
+package diag
+
+import (
+	"strings"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+)
+
+// NewAttributePath returns an attribute path with the given steps,
+// e.g. NewAttributePath("spec", "containers", "0", "image")
+func NewAttributePath(steps ...string) *kfplugin1.AttributePath {
+	return &kfplugin1.AttributePath{Steps: steps}
+}
+
+// AttributePathFromPointer returns an attribute path from a JSON pointer
+// (RFC 6901), e.g. /spec/containers/0/image
+func AttributePathFromPointer(pointer string) *kfplugin1.AttributePath {
+	if pointer == "" || pointer == "/" {
+		return NewAttributePath()
+	}
+	steps := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, step := range steps {
+		steps[i] = strings.ReplaceAll(strings.ReplaceAll(step, "~1", "/"), "~0", "~")
+	}
+	return NewAttributePath(steps...)
+}
+
+// AttributePathToPointer returns the JSON pointer (RFC 6901) representation
+// of the attribute path.
+func AttributePathToPointer(p *kfplugin1.AttributePath) string {
+	if p == nil || len(p.Steps) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, step := range p.Steps {
+		sb.WriteString("/")
+		sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(step, "~", "~0"), "/", "~1"))
+	}
+	return sb.String()
+}
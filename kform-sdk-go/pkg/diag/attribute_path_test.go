@@ -0,0 +1,41 @@
+package diag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributePathPointer(t *testing.T) {
+	cases := map[string]struct {
+		steps   []string
+		pointer string
+	}{
+		"Empty": {
+			pointer: "",
+		},
+		"Steps": {
+			steps:   []string{"spec", "containers", "0", "image"},
+			pointer: "/spec/containers/0/image",
+		},
+		"Slash": {
+			steps:   []string{"metadata", "annotations", "app.kubernetes.io/name"},
+			pointer: "/metadata/annotations/app.kubernetes.io~1name",
+		},
+		"Tilde": {
+			steps:   []string{"metadata", "annotations", "a~b"},
+			pointer: "/metadata/annotations/a~0b",
+		},
+		// ~01 is an escaped ~ followed by 1, not an escaped /
+		"TildeOne": {
+			steps:   []string{"a~1"},
+			pointer: "/a~01",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.pointer, AttributePathToPointer(NewAttributePath(tc.steps...)))
+			assert.Equal(t, tc.steps, AttributePathFromPointer(tc.pointer).Steps)
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 
 type Diagnostic struct {
 	*kfplugin1.Diagnostic
+	// Position is the location in the source file the diagnostic applies to.
+	// It is only known on the client side and not part of the protocol.
+	Position *Position
 }
 
 var severities = []kfplugin1.Severity{kfplugin1.Severity_ERROR, kfplugin1.Severity_WARNING}
@@ -32,6 +35,42 @@ func (r Diagnostic) GetContext() string {
 	return r.Context
 }
 
+func (r Diagnostic) GetSummary() string {
+	return r.Summary
+}
+
+func (r Diagnostic) GetAttribute() *kfplugin1.AttributePath {
+	return r.Attribute
+}
+
+// WithSummary sets the summary of the diagnostic
+func (r Diagnostic) WithSummary(summary string) Diagnostic {
+	r.Diagnostic = r.Get()
+	r.Summary = summary
+	return r
+}
+
+// WithAttribute sets the attribute path in the object the diagnostic
+// applies to
+func (r Diagnostic) WithAttribute(path *kfplugin1.AttributePath) Diagnostic {
+	r.Diagnostic = r.Get()
+	r.Attribute = path
+	return r
+}
+
+func (r Diagnostic) GetPosition() *Position {
+	return r.Position
+}
+
+// WithPosition sets the location in the source file the diagnostic
+// applies to
+func (r Diagnostic) WithPosition(pos Position) Diagnostic {
+	if pos.IsValid() {
+		r.Position = &pos
+	}
+	return r
+}
+
 func (r Diagnostic) Validate() error {
 	var valid bool
 	for _, sev := range severities {
@@ -51,6 +90,15 @@ func (r Diagnostic) GetDetails() string {
 
 	sb.WriteString(fmt.Sprintf(", severity=%s", r.Severity))
 	sb.WriteString(fmt.Sprintf(", context=%s", r.Context))
+	if r.Position != nil {
+		sb.WriteString(fmt.Sprintf(", position=%s", r.Position.String()))
+	}
+	if r.Summary != "" {
+		sb.WriteString(fmt.Sprintf(", summary=%s", r.Summary))
+	}
+	if path := AttributePathToPointer(r.Attribute); path != "" {
+		sb.WriteString(fmt.Sprintf(", attribute=%s", path))
+	}
 	if r.Detail != "" {
 		sb.WriteString(fmt.Sprintf(", detail=%s", r.Detail))
 	}
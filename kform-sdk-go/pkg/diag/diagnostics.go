@@ -24,8 +24,36 @@ func (r Diagnostics) Error() error {
 	for _, d := range r {
 		d := d
 		if d.Severity == kfplugin1.Severity_ERROR {
+			if path := AttributePathToPointer(d.Attribute); path != "" {
+				err = errors.Join(err, fmt.Errorf("ctx: %s, attribute: %s, detail: %s", d.Context, path, d.Detail))
+				continue
+			}
 			err = errors.Join(err, fmt.Errorf("ctx: %s, detail: %s", d.Context, d.Detail))
 		}
 	}
 	return err
 }
+
+// HasTimeout returns true if one of the diagnostics reports that an
+// operation exceeded its timeout.
+func (r Diagnostics) HasTimeout() bool {
+	for _, d := range r {
+		d := d
+		if d.Severity == kfplugin1.Severity_ERROR && d.Interrupt == kfplugin1.Interrupt_TIMEOUT {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCanceled returns true if one of the diagnostics reports that an
+// operation got canceled.
+func (r Diagnostics) HasCanceled() bool {
+	for _, d := range r {
+		d := d
+		if d.Severity == kfplugin1.Severity_ERROR && d.Interrupt == kfplugin1.Interrupt_CANCELED {
+			return true
+		}
+	}
+	return false
+}
@@ -98,3 +98,66 @@ func ErrorfWithContext(ctx string, format string, a ...interface{}) Diagnostics
 		DiagErrorfWithContext(ctx, format, a...).Get(),
 	}
 }
+
+// DiagTimeoutf returns the diagnostic of an operation that did not complete
+// within its timeout
+func DiagTimeoutf(format string, a ...interface{}) Diagnostic {
+	d := DiagErrorf(format, a...)
+	d.Interrupt = kfplugin1.Interrupt_TIMEOUT
+	return d
+}
+
+func Timeoutf(format string, a ...interface{}) Diagnostics {
+	return Diagnostics{
+		DiagTimeoutf(format, a...).Get(),
+	}
+}
+
+// DiagCanceledf returns the diagnostic of an operation that got canceled
+// before it completed
+func DiagCanceledf(format string, a ...interface{}) Diagnostic {
+	d := DiagErrorf(format, a...)
+	d.Interrupt = kfplugin1.Interrupt_CANCELED
+	return d
+}
+
+func Canceledf(format string, a ...interface{}) Diagnostics {
+	return Diagnostics{
+		DiagCanceledf(format, a...).Get(),
+	}
+}
+
+func DiagErrorfWithPath(path *kfplugin1.AttributePath, format string, a ...interface{}) Diagnostic {
+	return Diagnostic{
+		Diagnostic: &kfplugin1.Diagnostic{
+			Severity:  kfplugin1.Severity_ERROR,
+			Detail:    fmt.Sprintf(format, a...),
+			Attribute: path,
+		},
+	}
+}
+
+func DiagWarnfWithPath(path *kfplugin1.AttributePath, format string, a ...interface{}) Diagnostic {
+	return Diagnostic{
+		Diagnostic: &kfplugin1.Diagnostic{
+			Severity:  kfplugin1.Severity_WARNING,
+			Detail:    fmt.Sprintf(format, a...),
+			Attribute: path,
+		},
+	}
+}
+
+func FromErrWithPath(path *kfplugin1.AttributePath, err error) Diagnostics {
+	if err == nil {
+		return nil
+	}
+	return Diagnostics{
+		DiagErrorfWithPath(path, "%s", err.Error()).Get(),
+	}
+}
+
+func ErrorfWithPath(path *kfplugin1.AttributePath, format string, a ...interface{}) Diagnostics {
+	return Diagnostics{
+		DiagErrorfWithPath(path, format, a...).Get(),
+	}
+}
@@ -0,0 +1,26 @@
+package diag
+
+import "fmt"
+
+// Position is the location in a source file a diagnostic applies to.
+// Line and Column start at 1, a zero value indicates an unknown location.
+type Position struct {
+	FileName string
+	Line     int
+	Column   int
+}
+
+func (r Position) IsValid() bool {
+	return r.FileName != "" && r.Line > 0
+}
+
+// String returns the position in the <fileName>:<line>:<column> format
+func (r Position) String() string {
+	if r.Line == 0 {
+		return r.FileName
+	}
+	if r.Column == 0 {
+		return fmt.Sprintf("%s:%d", r.FileName, r.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", r.FileName, r.Line, r.Column)
+}
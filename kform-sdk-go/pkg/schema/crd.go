@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"fmt"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/yaml"
+)
+
+// NewSchemaFromCRD returns the openapi schema of the storage version of the
+// custom resource definition, e.g. to set the schema of the provider config
+// from the generated crd of the provider config.
+func NewSchemaFromCRD(b []byte) (*spec.Schema, error) {
+	crd := &apiext.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(b, crd); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal custom resource definition, err: %s", err.Error())
+	}
+	for _, v := range crd.Spec.Versions {
+		if !v.Storage || v.Schema == nil {
+			continue
+		}
+		s := &spec.Schema{}
+		if err := ConvertJSONSchemaPropsWithPostProcess(v.Schema.OpenAPIV3Schema, s, StripUnsupportedFormatsPostProcess); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("custom resource definition %s has no storage version with a schema", crd.GetName())
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
@@ -23,18 +24,80 @@ type GRPCProviderServer struct {
 	m        sync.Mutex
 }
 
+// operationContext returns a context for a resource operation bounded by the
+// timeout of the operation. The context is also canceled when the provider
+// is stopped.
+func (r *GRPCProviderServer) operationContext(ctx context.Context, res *Resource, key string) (context.Context, context.CancelFunc, time.Duration) {
+	r.m.Lock()
+	stopCh := r.stopCh
+	r.m.Unlock()
+
+	timeout := res.Timeouts.Timeout(key)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopCh:
+			cancel()
+		}
+	}()
+	return ctx, cancel, timeout
+}
+
+type operationResult struct {
+	obj   []byte
+	diags diag.Diagnostics
+}
+
+// runOperation runs the operation and returns when the operation finished
+// or when the context is done, whichever comes first. When the context is
+// done before the operation finished a diagnostic is returned indicating the
+// operation timed out or got canceled, an operation that finished returns
+// its own result.
+func runOperation(ctx context.Context, rpc, name string, timeout time.Duration, fn func(ctx context.Context) ([]byte, diag.Diagnostics)) ([]byte, diag.Diagnostics) {
+	resultCh := make(chan operationResult, 1)
+	go func() {
+		obj, diags := fn(ctx)
+		resultCh <- operationResult{obj: obj, diags: diags}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.obj, result.diags
+	case <-ctx.Done():
+		// the operation might have finished concurrently
+		select {
+		case result := <-resultCh:
+			return result.obj, result.diags
+		default:
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, diag.Timeoutf("%s for %s did not complete within %s", rpc, name, timeout)
+		}
+		return nil, diag.Canceledf("%s for %s canceled", rpc, name)
+	}
+}
+
 func (r *GRPCProviderServer) Capabilities(ctx context.Context, req *kfplugin1.Capabilities_Request) (*kfplugin1.Capabilities_Response, error) {
 	// todo add ctx + tracing
 	rpc := "capabilities"
 	log := log.FromContext(ctx)
 	log.Info(rpc)
 
+	configSchema, resourceSchemas, err := r.provider.getSchemas()
+	if err != nil {
+		return &kfplugin1.Capabilities_Response{
+			Diagnostics: diag.FromErr(err),
+		}, nil
+	}
 	return &kfplugin1.Capabilities_Response{
-		Diagnostics:        []*kfplugin1.Diagnostic{},
-		ReadDataSources:    r.provider.getDataSources(),
-		ListDataSources:    r.provider.getListDataSources(),
-		Resources:          r.provider.getResources(),
-		ServerCapabilities: &kfplugin1.ServerCapabilities{},
+		Diagnostics:          []*kfplugin1.Diagnostic{},
+		ReadDataSources:      r.provider.getDataSources(),
+		ListDataSources:      r.provider.getListDataSources(),
+		Resources:            r.provider.getResources(),
+		ServerCapabilities:   &kfplugin1.ServerCapabilities{},
+		ProviderConfigSchema: configSchema,
+		ResourceSchemas:      resourceSchemas,
 	}, nil
 }
 
@@ -90,7 +153,11 @@ func (r *GRPCProviderServer) ReadDataSource(ctx context.Context, req *kfplugin1.
 		}, nil
 	}
 
-	d, diags := res.ReadContext(ctx, &ResourceObject{Scope: req.Scope, Obj: req.Obj}, r.provider.providerMetaConfig)
+	ctx, cancel, timeout := r.operationContext(ctx, res, TimeoutRead)
+	defer cancel()
+	d, diags := runOperation(ctx, "readDataSource", req.GetName(), timeout, func(ctx context.Context) ([]byte, diag.Diagnostics) {
+		return res.ReadContext(ctx, &ResourceObject{Scope: req.Scope, Obj: req.Obj}, r.provider.providerMetaConfig)
+	})
 
 	log.Info("readDataSource done")
 
@@ -118,7 +185,16 @@ func (r *GRPCProviderServer) ListDataSource(ctx context.Context, req *kfplugin1.
 		}, nil
 	}
 
-	obj, diags := res.ListContext(ctx, &ResourceObject{Scope: req.Scope, Obj: req.Obj}, r.provider.providerMetaConfig)
+	ctx, cancel, timeout := r.operationContext(ctx, res, TimeoutList)
+	defer cancel()
+	obj, diags := runOperation(ctx, "listDataSource", req.GetName(), timeout, func(ctx context.Context) ([]byte, diag.Diagnostics) {
+		return res.ListContext(ctx, &ResourceObject{
+			Scope:         req.Scope,
+			Obj:           req.Obj,
+			LabelSelector: req.LabelSelector,
+			Namespace:     req.Namespace,
+		}, r.provider.providerMetaConfig)
+	})
 
 	log.Info("listDataSource done")
 
@@ -128,6 +204,32 @@ func (r *GRPCProviderServer) ListDataSource(ctx context.Context, req *kfplugin1.
 	}, nil
 }
 
+// ValidateResource validates the obj of the resource, a resource without
+// validation is valid
+func (r *GRPCProviderServer) ValidateResource(ctx context.Context, req *kfplugin1.ValidateResource_Request) (*kfplugin1.ValidateResource_Response, error) {
+	// todo add ctx + tracing
+	log := log.FromContext(ctx)
+	log.Info("validateResource...")
+
+	res, ok := r.provider.ResourceMap[req.GetName()]
+	if !ok {
+		return &kfplugin1.ValidateResource_Response{
+			Diagnostics: diag.Errorf("cannot validate resource, resourceType not found, got: %s", req.GetName()),
+		}, nil
+	}
+	if res.ValidateContext == nil {
+		return &kfplugin1.ValidateResource_Response{}, nil
+	}
+
+	diags := res.ValidateContext(ctx, &ResourceObject{Obj: req.Obj}, r.provider.providerMetaConfig)
+
+	log.Info("validateResource done")
+
+	return &kfplugin1.ValidateResource_Response{
+		Diagnostics: diags,
+	}, nil
+}
+
 func (r *GRPCProviderServer) ReadResource(ctx context.Context, req *kfplugin1.ReadResource_Request) (*kfplugin1.ReadResource_Response, error) {
 	// todo add ctx + tracing
 	log := log.FromContext(ctx)
@@ -146,7 +248,11 @@ func (r *GRPCProviderServer) ReadResource(ctx context.Context, req *kfplugin1.Re
 		}, nil
 	}
 
-	obj, diags := res.ReadContext(ctx, &ResourceObject{Scope: req.Scope, Obj: req.Obj}, r.provider.providerMetaConfig)
+	ctx, cancel, timeout := r.operationContext(ctx, res, TimeoutRead)
+	defer cancel()
+	obj, diags := runOperation(ctx, "readResource", req.GetName(), timeout, func(ctx context.Context) ([]byte, diag.Diagnostics) {
+		return res.ReadContext(ctx, &ResourceObject{Scope: req.Scope, Obj: req.Obj}, r.provider.providerMetaConfig)
+	})
 
 	log.Info("readResource done")
 
@@ -174,7 +280,11 @@ func (r *GRPCProviderServer) CreateResource(ctx context.Context, req *kfplugin1.
 		}, nil
 	}
 
-	obj, diags := res.CreateContext(ctx, &ResourceObject{Scope: req.Scope, DryRun: req.DryRun, Obj: req.Obj}, r.provider.providerMetaConfig)
+	ctx, cancel, timeout := r.operationContext(ctx, res, TimeoutCreate)
+	defer cancel()
+	obj, diags := runOperation(ctx, "createResource", req.GetName(), timeout, func(ctx context.Context) ([]byte, diag.Diagnostics) {
+		return res.CreateContext(ctx, &ResourceObject{Scope: req.Scope, DryRun: req.DryRun, Obj: req.Obj}, r.provider.providerMetaConfig)
+	})
 
 	log.Info("createResource done")
 
@@ -202,7 +312,11 @@ func (r *GRPCProviderServer) UpdateResource(ctx context.Context, req *kfplugin1.
 		}, nil
 	}
 
-	obj, diags := res.UpdateContext(ctx, &ResourceObject{Scope: req.Scope, DryRun: req.DryRun, Obj: req.NewObj, OldObj: req.OldObj}, r.provider.providerMetaConfig)
+	ctx, cancel, timeout := r.operationContext(ctx, res, TimeoutUpdate)
+	defer cancel()
+	obj, diags := runOperation(ctx, "updateResource", req.GetName(), timeout, func(ctx context.Context) ([]byte, diag.Diagnostics) {
+		return res.UpdateContext(ctx, &ResourceObject{Scope: req.Scope, DryRun: req.DryRun, Obj: req.NewObj, OldObj: req.OldObj, OldState: req.OldState}, r.provider.providerMetaConfig)
+	})
 
 	log.Info("updateResource done")
 
@@ -226,11 +340,15 @@ func (r *GRPCProviderServer) DeleteResource(ctx context.Context, req *kfplugin1.
 
 	if res.DeleteContext == nil {
 		return &kfplugin1.DeleteResource_Response{
-			Diagnostics: diag.Errorf("cannot delete resource, createContext not initialized, for: %s", req.GetName()),
+			Diagnostics: diag.Errorf("cannot delete resource, deleteContext not initialized, for: %s", req.GetName()),
 		}, nil
 	}
 
-	diags := res.DeleteContext(ctx, &ResourceObject{Scope: req.Scope, DryRun: req.DryRun, Obj: req.Obj}, r.provider.providerMetaConfig)
+	ctx, cancel, timeout := r.operationContext(ctx, res, TimeoutDelete)
+	defer cancel()
+	_, diags := runOperation(ctx, "deleteResource", req.GetName(), timeout, func(ctx context.Context) ([]byte, diag.Diagnostics) {
+		return nil, res.DeleteContext(ctx, &ResourceObject{Scope: req.Scope, DryRun: req.DryRun, Obj: req.Obj}, r.provider.providerMetaConfig)
+	})
 
 	log.Info("deleteResource done")
 
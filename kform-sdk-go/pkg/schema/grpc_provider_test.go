@@ -0,0 +1,184 @@
+package schema
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestRunOperation(t *testing.T) {
+	cases := map[string]struct {
+		timeout      time.Duration
+		cancel       bool
+		block        bool
+		wantObj      string
+		wantTimeout  bool
+		wantCanceled bool
+	}{
+		"Completed": {
+			timeout: time.Minute,
+			wantObj: "obj",
+		},
+		"Timeout": {
+			timeout:     10 * time.Millisecond,
+			block:       true,
+			wantTimeout: true,
+		},
+		"Canceled": {
+			timeout:      time.Minute,
+			cancel:       true,
+			block:        true,
+			wantCanceled: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+			// the blocked operation only returns after runOperation returned
+			block := make(chan struct{})
+			defer close(block)
+			obj, diags := runOperation(ctx, "createResource", "test", tc.timeout, func(ctx context.Context) ([]byte, diag.Diagnostics) {
+				if tc.block {
+					<-block
+				}
+				return []byte("obj"), nil
+			})
+			if string(obj) != tc.wantObj {
+				t.Errorf("want obj %q, got: %q", tc.wantObj, string(obj))
+			}
+			if diags.HasTimeout() != tc.wantTimeout {
+				t.Errorf("want timeout %t, got diagnostics: %v", tc.wantTimeout, diags)
+			}
+			if diags.HasCanceled() != tc.wantCanceled {
+				t.Errorf("want canceled %t, got diagnostics: %v", tc.wantCanceled, diags)
+			}
+			if !tc.wantTimeout && !tc.wantCanceled && diags.HasError() {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestStopProvider(t *testing.T) {
+	started := make(chan struct{})
+	block := make(chan struct{})
+	defer close(block)
+	s := NewGRPCProviderServer(&Provider{
+		ResourceMap: map[string]*Resource{
+			"test": {
+				CreateContext: func(ctx context.Context, _ *ResourceObject, _ any) ([]byte, diag.Diagnostics) {
+					close(started)
+					<-block
+					return []byte("obj"), nil
+				},
+			},
+		},
+	})
+
+	respCh := make(chan *kfplugin1.CreateResource_Response, 1)
+	go func() {
+		resp, _ := s.CreateResource(context.Background(), &kfplugin1.CreateResource_Request{Name: "test"})
+		respCh <- resp
+	}()
+	<-started
+	if _, err := s.StopProvider(context.Background(), &kfplugin1.StopProvider_Request{}); err != nil {
+		t.Fatalf("stop provider failed: %s", err.Error())
+	}
+
+	select {
+	case resp := <-respCh:
+		diags := diag.Diagnostics(resp.GetDiagnostics())
+		if !diags.HasCanceled() {
+			t.Errorf("want canceled diagnostic, got: %v", diags)
+		}
+		if resp.GetObj() != nil {
+			t.Errorf("want no obj, got: %s", string(resp.GetObj()))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("create resource did not return after the provider was stopped")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	s := NewGRPCProviderServer(&Provider{
+		Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}}},
+		ResourceMap: map[string]*Resource{
+			"test_resource": {Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}}}},
+			"test_noschema": {},
+		},
+		DataSourcesMap: map[string]*Resource{
+			"test_resource": {},
+		},
+	})
+	resp, err := s.Capabilities(context.Background(), &kfplugin1.Capabilities_Request{})
+	if err != nil {
+		t.Fatalf("capabilities failed: %s", err.Error())
+	}
+	if got := string(resp.GetProviderConfigSchema()); got != `{"type":"object"}` {
+		t.Errorf("want provider config schema, got: %s", got)
+	}
+	if len(resp.GetResourceSchemas()) != 1 {
+		t.Errorf("want 1 resource schema, got: %v", resp.GetResourceSchemas())
+	}
+	if got := string(resp.GetResourceSchemas()["test_resource"]); got != `{"type":"object"}` {
+		t.Errorf("want test_resource schema, got: %s", got)
+	}
+}
+
+func TestValidateResource(t *testing.T) {
+	s := NewGRPCProviderServer(&Provider{
+		ResourceMap: map[string]*Resource{
+			"test_resource": {
+				ValidateContext: func(ctx context.Context, d *ResourceObject, _ any) diag.Diagnostics {
+					if string(d.GetObject()) != "valid" {
+						return diag.Errorf("invalid obj, got: %s", string(d.GetObject()))
+					}
+					return nil
+				},
+			},
+			"test_novalidation": {},
+		},
+	})
+	cases := map[string]struct {
+		name      string
+		obj       string
+		expectErr bool
+	}{
+		"Valid": {
+			name: "test_resource",
+			obj:  "valid",
+		},
+		"Invalid": {
+			name:      "test_resource",
+			obj:       "invalid",
+			expectErr: true,
+		},
+		"NoValidation": {
+			name: "test_novalidation",
+			obj:  "invalid",
+		},
+		"UnknownResource": {
+			name:      "test_unknown",
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp, err := s.ValidateResource(context.Background(), &kfplugin1.ValidateResource_Request{Name: tc.name, Obj: []byte(tc.obj)})
+			if err != nil {
+				t.Fatalf("validate resource failed: %s", err.Error())
+			}
+			if diags := diag.Diagnostics(resp.GetDiagnostics()); diags.HasError() != tc.expectErr {
+				t.Errorf("want error %t, got diagnostics: %v", tc.expectErr, diags)
+			}
+		})
+	}
+}
@@ -2,14 +2,18 @@ package schema
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
-	//apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 type Provider struct {
-	//Schema               *apiext.JSONSchemaProps
+	// Schema is the optional openapi schema of the provider config, kform
+	// uses it to type-check the expressions that refer to the config
+	Schema               *spec.Schema
 	ResourceMap          map[string]*Resource
 	DataSourcesMap       map[string]*Resource
 	ListDataSourcesMap   map[string]*Resource
@@ -101,3 +105,32 @@ func (r *Provider) getResources() []string {
 	}
 	return s
 }
+
+// getSchemas returns the json encoded schemas of the provider config and of
+// the resources and data sources keyed by resource type. A resource and its
+// data sources share the resource type, the schema of the resource takes
+// precedence.
+func (r *Provider) getSchemas() ([]byte, map[string][]byte, error) {
+	var config []byte
+	if r.Schema != nil {
+		var err error
+		config, err = json.Marshal(r.Schema)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot marshal provider config schema, err: %s", err.Error())
+		}
+	}
+	schemas := map[string][]byte{}
+	for _, m := range []map[string]*Resource{r.ListDataSourcesMap, r.DataSourcesMap, r.ResourceMap} {
+		for n, res := range m {
+			if res == nil || res.Schema == nil {
+				continue
+			}
+			b, err := json.Marshal(res.Schema)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot marshal schema of %s, err: %s", n, err.Error())
+			}
+			schemas[n] = b
+		}
+	}
+	return config, schemas, nil
+}
@@ -4,23 +4,30 @@ import (
 	"context"
 
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 type Resource struct {
-	//Schema     *apiext.JSONSchemaProps
-	//SchemaFunc func() *apiext.JSONSchemaProps
-
-	CreateContext CreateContextFunc
-	UpdateContext UpdateContextFunc
-	DeleteContext DeleteContextFunc
-	ReadContext   ReadContextFunc
-	ListContext   ListContextFunc
+	// Schema is the optional openapi schema of the resource, kform uses it
+	// to type-check the expressions that refer to the resource
+	Schema *spec.Schema
+
+	// ValidateContext is the optional validation of the resource before it
+	// is created or updated
+	ValidateContext ValidateContextFunc
+	CreateContext   CreateContextFunc
+	UpdateContext   UpdateContextFunc
+	DeleteContext   DeleteContextFunc
+	ReadContext     ReadContextFunc
+	ListContext     ListContextFunc
 	//CreateWithoutTimeout CreateContextFunc
 	//ReadWithoutTimeout   ReadContextFunc
 
 	Timeouts *ResourceTimeout
 }
 
+type ValidateContextFunc func(context.Context, *ResourceObject, interface{}) diag.Diagnostics
+
 type CreateContextFunc func(context.Context, *ResourceObject, interface{}) ([]byte, diag.Diagnostics)
 
 type UpdateContextFunc func(context.Context, *ResourceObject, interface{}) ([]byte, diag.Diagnostics)
@@ -1,12 +1,22 @@
 package schema
 
-import "github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+import (
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
 
 type ResourceObject struct {
 	Scope  kfplugin1.Scope
 	DryRun bool
 	Obj    []byte // new resource obj in json format
 	OldObj []byte // old resource obj in json format
+	// OldState is the obj the provider returned for the old obj, providers
+	// keep the values they generated in it
+	OldState []byte
+	// LabelSelector and Namespace select the objects of a list
+	LabelSelector *kfplugin1.LabelSelector
+	Namespace     string
 }
 
 func (r *ResourceObject) GetScope() kfplugin1.Scope {
@@ -24,3 +34,37 @@ func (r *ResourceObject) GetObject() []byte {
 func (r *ResourceObject) GetOldObject() []byte {
 	return r.OldObj
 }
+
+// GetOldState returns the obj the provider returned for the old obj, nil when
+// the state of the old obj is unknown
+func (r *ResourceObject) GetOldState() []byte {
+	return r.OldState
+}
+
+// GetLabelSelector returns the label selector of a list, nil selects all
+// objects
+func (r *ResourceObject) GetLabelSelector() *kfplugin1.LabelSelector {
+	return r.LabelSelector
+}
+
+// GetNamespace returns the namespace of a list, empty lists all namespaces
+func (r *ResourceObject) GetNamespace() string {
+	return r.Namespace
+}
+
+// GetSelector returns the label selector of a list as a labels.Selector, all
+// objects are selected when the list has no label selector
+func (r *ResourceObject) GetSelector() (labels.Selector, error) {
+	if r.LabelSelector == nil {
+		return labels.Everything(), nil
+	}
+	ls := &metav1.LabelSelector{MatchLabels: r.LabelSelector.MatchLabels}
+	for _, req := range r.LabelSelector.MatchExpressions {
+		ls.MatchExpressions = append(ls.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      req.GetKey(),
+			Operator: metav1.LabelSelectorOperator(req.GetOperator()),
+			Values:   req.GetValues(),
+		})
+	}
+	return metav1.LabelSelectorAsSelector(ls)
+}
@@ -2,6 +2,48 @@ package schema
 
 import "time"
 
+const (
+	TimeoutCreate  = "create"
+	TimeoutRead    = "read"
+	TimeoutUpdate  = "update"
+	TimeoutDelete  = "delete"
+	TimeoutList    = "list"
+	TimeoutDefault = "default"
+)
+
+// DefaultTimeout is used when a resource does not define a timeout for
+// an operation nor a default timeout.
+const DefaultTimeout = 20 * time.Minute
+
 type ResourceTimeout struct {
-	Create, Read, Default *time.Duration
+	Create, Read, Update, Delete, List, Default *time.Duration
+}
+
+// Timeout returns the timeout for the given operation key. If the
+// operation has no timeout set the Default timeout is used and when
+// this is not set either the DefaultTimeout is returned.
+func (r *ResourceTimeout) Timeout(key string) time.Duration {
+	if r == nil {
+		return DefaultTimeout
+	}
+	var t *time.Duration
+	switch key {
+	case TimeoutCreate:
+		t = r.Create
+	case TimeoutRead:
+		t = r.Read
+	case TimeoutUpdate:
+		t = r.Update
+	case TimeoutDelete:
+		t = r.Delete
+	case TimeoutList:
+		t = r.List
+	}
+	if t == nil {
+		t = r.Default
+	}
+	if t == nil || *t <= 0 {
+		return DefaultTimeout
+	}
+	return *t
 }
@@ -0,0 +1,110 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+)
+
+// checkDiagnostics validates that every expected diagnostic is returned and
+// that no unexpected error diagnostics are returned.
+func checkDiagnostics(expected, actual diag.Diagnostics) error {
+	matched := make([]bool, len(actual))
+	for _, e := range expected {
+		found := false
+		for i, a := range actual {
+			if !matched[i] && matchDiagnostic(e, a) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("expected diagnostic %s not found, got: %v", diag.Diagnostic{Diagnostic: e}.GetDetails(), actual)
+		}
+	}
+	for i, a := range actual {
+		if !matched[i] && a.Severity == kfplugin1.Severity_ERROR {
+			return fmt.Errorf("unexpected diagnostic %s", diag.Diagnostic{Diagnostic: a}.GetDetails())
+		}
+	}
+	return nil
+}
+
+func matchDiagnostic(expected, actual *kfplugin1.Diagnostic) bool {
+	if expected.Severity != actual.Severity {
+		return false
+	}
+	if !strings.Contains(actual.Detail, expected.Detail) {
+		return false
+	}
+	if expected.Attribute != nil &&
+		diag.AttributePathToPointer(expected.Attribute) != diag.AttributePathToPointer(actual.Attribute) {
+		return false
+	}
+	return true
+}
+
+// checkObject validates that the object contains all the fields of the
+// expected object.
+func checkObject(expected any, obj []byte) error {
+	b, err := json.Marshal(expected)
+	if err != nil {
+		return fmt.Errorf("cannot marshal expected object: %s", err.Error())
+	}
+	var e, a any
+	if err := json.Unmarshal(b, &e); err != nil {
+		return fmt.Errorf("cannot unmarshal expected object: %s", err.Error())
+	}
+	if err := json.Unmarshal(obj, &a); err != nil {
+		return fmt.Errorf("cannot unmarshal object: %s", err.Error())
+	}
+	return isSubset("", e, a)
+}
+
+func isSubset(path string, expected, actual any) error {
+	switch expected := expected.(type) {
+	case map[string]any:
+		actual, ok := actual.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got: %v", getPath(path), actual)
+		}
+		for k, v := range expected {
+			av, ok := actual[k]
+			if !ok {
+				return fmt.Errorf("%s/%s: not found", path, k)
+			}
+			if err := isSubset(fmt.Sprintf("%s/%s", path, k), v, av); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		actual, ok := actual.([]any)
+		if !ok || len(actual) != len(expected) {
+			return fmt.Errorf("%s: expected %v, got: %v", getPath(path), expected, actual)
+		}
+		for i := range expected {
+			if err := isSubset(fmt.Sprintf("%s/%d", path, i), expected[i], actual[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("%s: expected %v, got: %v", getPath(path), expected, actual)
+		}
+		return nil
+	}
+}
+
+func getPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,21 @@
+// Package testing provides a harness to test a schema.Provider without
+// building a provider binary. The provider is served over an in-memory gRPC
+// connection and driven through the kfplugin1.ProviderClient, e.g.
+//
+//	kftesting.Test(t, kftesting.TestCase{
+//		ProviderName:    "resourcebackend",
+//		ProviderFactory: resourcebackend.Provider,
+//		ProviderConfig: map[string]any{
+//			"apiVersion": "resourcebackend.provider.kform.io/v1alpha1",
+//			"kind":       "ProviderConfig",
+//			"spec":       map[string]any{"kind": "mock"},
+//		},
+//		ResourceType: "resourcebackend_ipclaim",
+//		Steps: []kftesting.TestStep{
+//			{Config: claim, ExpectObject: map[string]any{"status": ...}},
+//		},
+//	})
+//
+// The kubernetes provider can be tested in the same way using the package
+// kind with a directory as backend.
+package testing
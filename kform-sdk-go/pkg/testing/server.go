@@ -0,0 +1,45 @@
+package testing
+
+import (
+	"context"
+	"net"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfserver1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+// NewProviderClient serves the provider over an in-memory gRPC connection,
+// using the same server proxy as a provider binary, and returns a client
+// connected to it. The returned function stops the server and closes the
+// connection.
+func NewProviderClient(ctx context.Context, name string, p *schema.Provider) (kfplugin1.ProviderClient, func(), error) {
+	lis := bufconn.Listen(bufSize)
+
+	s := grpc.NewServer()
+	kfplugin1.RegisterProviderServer(s, kfserver1.New(name, schema.NewGRPCProviderServer(p)))
+	go func() {
+		// Serve returns when the server is stopped
+		_ = s.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		s.Stop()
+		return nil, nil, err
+	}
+	return kfplugin1.NewProviderClient(conn), func() {
+		conn.Close()
+		s.Stop()
+	}, nil
+}
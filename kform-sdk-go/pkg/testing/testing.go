@@ -0,0 +1,245 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	gotesting "testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+)
+
+type StepKind string
+
+const (
+	// StepKindResource creates the resource in the first step and updates
+	// it in the subsequent steps.
+	StepKindResource StepKind = "resource"
+	// StepKindData reads a data source
+	StepKindData StepKind = "data"
+	// StepKindList lists a data source
+	StepKindList StepKind = "list"
+)
+
+// TestCase is a single test of a resource of a provider. The steps run in
+// order against the same provider instance. When the resource was created
+// it is destroyed after the last step and the destroy is checked.
+type TestCase struct {
+	// ProviderName is the name of the provider, e.g. kubernetes
+	ProviderName string
+	// ProviderFactory returns a new instance of the provider under test
+	ProviderFactory func() *schema.Provider
+	// ProviderConfig is the configuration of the provider, it is
+	// serialized to json
+	ProviderConfig any
+	// ResourceType is the type of the resource, e.g. kubernetes_manifest
+	ResourceType string
+	// Scope of the resource
+	Scope kfplugin1.Scope
+	// Steps of the test
+	Steps []TestStep
+	// CheckDestroy is called with the last known object after the resource
+	// is destroyed. When not set the resource should no longer be readable.
+	CheckDestroy func(ctx context.Context, client kfplugin1.ProviderClient, obj []byte) error
+}
+
+// TestStep is a single step in a test case.
+type TestStep struct {
+	// Kind of the step, the default is StepKindResource
+	Kind StepKind
+	// Config of the resource, it is serialized to json
+	Config any
+	// DryRun executes the resource step in dry run mode
+	DryRun bool
+	// LabelSelector and Namespace select the objects of a list step
+	LabelSelector *kfplugin1.LabelSelector
+	Namespace     string
+	// ExpectObject are the fields the returned object should contain
+	ExpectObject any
+	// ExpectDiagnostics are the diagnostics the step should return. The
+	// severity should match, the returned detail should contain the expected
+	// detail and when set the attribute paths should be equal.
+	ExpectDiagnostics diag.Diagnostics
+	// Check is an optional function to validate the returned object
+	Check func(obj []byte) error
+}
+
+// Test runs the test case against the provider served over an in-memory
+// gRPC connection.
+func Test(t gotesting.TB, tc TestCase) {
+	t.Helper()
+	ctx := context.Background()
+
+	if tc.ProviderFactory == nil {
+		t.Fatal("cannot run a test case without a provider factory")
+	}
+	client, closeFn, err := NewProviderClient(ctx, tc.ProviderName, tc.ProviderFactory())
+	if err != nil {
+		t.Fatalf("cannot start provider: %s", err.Error())
+	}
+	defer closeFn()
+
+	cfg, err := json.Marshal(tc.ProviderConfig)
+	if err != nil {
+		t.Fatalf("cannot marshal provider config: %s", err.Error())
+	}
+	cfgResp, err := client.Configure(ctx, &kfplugin1.Configure_Request{Config: cfg})
+	if err != nil {
+		t.Fatalf("cannot configure provider: %s", err.Error())
+	}
+	if diags := diag.Diagnostics(cfgResp.Diagnostics); diags.HasError() {
+		t.Fatalf("cannot configure provider: %s", diags.Error())
+	}
+
+	// obj is the last known object of the resource
+	var obj []byte
+	for i, step := range tc.Steps {
+		newObj, diags, err := runStep(ctx, client, tc, step, obj)
+		if err != nil {
+			t.Fatalf("step %d: %s", i, err.Error())
+		}
+		if err := checkDiagnostics(step.ExpectDiagnostics, diags); err != nil {
+			t.Errorf("step %d: %s", i, err.Error())
+			continue
+		}
+		if diags.HasError() {
+			continue
+		}
+		if step.getKind() == StepKindResource && !step.DryRun {
+			obj = newObj
+		}
+		if step.ExpectObject != nil {
+			if err := checkObject(step.ExpectObject, newObj); err != nil {
+				t.Errorf("step %d: %s", i, err.Error())
+			}
+		}
+		if step.Check != nil {
+			if err := step.Check(newObj); err != nil {
+				t.Errorf("step %d: check failed: %s", i, err.Error())
+			}
+		}
+	}
+
+	if obj == nil {
+		return
+	}
+	if err := destroy(ctx, client, tc, obj); err != nil {
+		t.Errorf("destroy: %s", err.Error())
+		return
+	}
+	checkDestroy := tc.CheckDestroy
+	if checkDestroy == nil {
+		checkDestroy = tc.checkDestroy
+	}
+	if err := checkDestroy(ctx, client, obj); err != nil {
+		t.Errorf("check destroy: %s", err.Error())
+	}
+}
+
+func (r TestStep) getKind() StepKind {
+	if r.Kind == "" {
+		return StepKindResource
+	}
+	return r.Kind
+}
+
+func runStep(ctx context.Context, client kfplugin1.ProviderClient, tc TestCase, step TestStep, obj []byte) ([]byte, diag.Diagnostics, error) {
+	b, err := json.Marshal(step.Config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot marshal config: %s", err.Error())
+	}
+	switch step.getKind() {
+	case StepKindResource:
+		// the resource is validated before it is applied, as kform does
+		validateResp, err := client.ValidateResource(ctx, &kfplugin1.ValidateResource_Request{
+			Name: tc.ResourceType,
+			Obj:  b,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if diag.Diagnostics(validateResp.Diagnostics).HasError() {
+			return nil, validateResp.Diagnostics, nil
+		}
+		if obj == nil {
+			resp, err := client.CreateResource(ctx, &kfplugin1.CreateResource_Request{
+				Name:   tc.ResourceType,
+				Scope:  tc.Scope,
+				DryRun: step.DryRun,
+				Obj:    b,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			return resp.Obj, resp.Diagnostics, nil
+		}
+		resp, err := client.UpdateResource(ctx, &kfplugin1.UpdateResource_Request{
+			Name:     tc.ResourceType,
+			Scope:    tc.Scope,
+			DryRun:   step.DryRun,
+			NewObj:   b,
+			OldObj:   obj,
+			OldState: obj,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Obj, resp.Diagnostics, nil
+	case StepKindData:
+		resp, err := client.ReadDataSource(ctx, &kfplugin1.ReadDataSource_Request{
+			Name:  tc.ResourceType,
+			Scope: tc.Scope,
+			Obj:   b,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Obj, resp.Diagnostics, nil
+	case StepKindList:
+		resp, err := client.ListDataSource(ctx, &kfplugin1.ListDataSource_Request{
+			Name:          tc.ResourceType,
+			Scope:         tc.Scope,
+			Obj:           b,
+			LabelSelector: step.LabelSelector,
+			Namespace:     step.Namespace,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Obj, resp.Diagnostics, nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected step kind, got: %s", step.Kind)
+	}
+}
+
+func destroy(ctx context.Context, client kfplugin1.ProviderClient, tc TestCase, obj []byte) error {
+	resp, err := client.DeleteResource(ctx, &kfplugin1.DeleteResource_Request{
+		Name:  tc.ResourceType,
+		Scope: tc.Scope,
+		Obj:   obj,
+	})
+	if err != nil {
+		return err
+	}
+	if diags := diag.Diagnostics(resp.Diagnostics); diags.HasError() {
+		return diags.Error()
+	}
+	return nil
+}
+
+func (r TestCase) checkDestroy(ctx context.Context, client kfplugin1.ProviderClient, obj []byte) error {
+	resp, err := client.ReadResource(ctx, &kfplugin1.ReadResource_Request{
+		Name:  r.ResourceType,
+		Scope: r.Scope,
+		Obj:   obj,
+	})
+	if err != nil {
+		return err
+	}
+	if diag.Diagnostics(resp.Diagnostics).HasError() || len(resp.Obj) == 0 {
+		return nil
+	}
+	return fmt.Errorf("resource %s still exists", r.ResourceType)
+}
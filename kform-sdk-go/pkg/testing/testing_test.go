@@ -0,0 +1,164 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	gotesting "testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+)
+
+type store struct {
+	m    sync.Mutex
+	objs map[string][]byte
+}
+
+func getName(b []byte) (string, diag.Diagnostics) {
+	obj := map[string]any{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return "", diag.FromErr(err)
+	}
+	name, ok := obj["name"].(string)
+	if !ok || name == "" {
+		return "", diag.ErrorfWithPath(diag.NewAttributePath("name"), "name is required")
+	}
+	return name, nil
+}
+
+func (r *store) apply(ctx context.Context, d *schema.ResourceObject, meta any) ([]byte, diag.Diagnostics) {
+	name, diags := getName(d.GetObject())
+	if diags.HasError() {
+		return nil, diags
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.objs[name] = d.GetObject()
+	return d.GetObject(), nil
+}
+
+func (r *store) read(ctx context.Context, d *schema.ResourceObject, meta any) ([]byte, diag.Diagnostics) {
+	name, diags := getName(d.GetObject())
+	if diags.HasError() {
+		return nil, diags
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	b, ok := r.objs[name]
+	if !ok {
+		return nil, diag.Errorf("not found: %s", name)
+	}
+	return b, nil
+}
+
+func (r *store) delete(ctx context.Context, d *schema.ResourceObject, meta any) diag.Diagnostics {
+	name, diags := getName(d.GetObject())
+	if diags.HasError() {
+		return diags
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	delete(r.objs, name)
+	return nil
+}
+
+func testProvider() *schema.Provider {
+	s := &store{objs: map[string][]byte{}}
+	res := &schema.Resource{
+		CreateContext: s.apply,
+		UpdateContext: s.apply,
+		ReadContext:   s.read,
+		DeleteContext: s.delete,
+	}
+	p := &schema.Provider{
+		ResourceMap:    map[string]*schema.Resource{"test_object": res},
+		DataSourcesMap: map[string]*schema.Resource{"test_object": res},
+	}
+	p.ConfigureContextFunc = func(ctx context.Context, d []byte) (any, diag.Diagnostics) {
+		return s, nil
+	}
+	return p
+}
+
+func TestHarness(t *gotesting.T) {
+	cases := map[string]struct {
+		steps []TestStep
+	}{
+		"CreateUpdateRead": {
+			steps: []TestStep{
+				{
+					Config:       map[string]any{"name": "a", "value": "x"},
+					ExpectObject: map[string]any{"name": "a", "value": "x"},
+				},
+				{
+					Config:       map[string]any{"name": "a", "value": "y"},
+					ExpectObject: map[string]any{"value": "y"},
+				},
+				{
+					Kind:         StepKindData,
+					Config:       map[string]any{"name": "a"},
+					ExpectObject: map[string]any{"value": "y"},
+				},
+			},
+		},
+		"Diagnostics": {
+			steps: []TestStep{
+				{
+					Config: map[string]any{"value": "x"},
+					ExpectDiagnostics: diag.Diagnostics{
+						diag.DiagErrorfWithPath(diag.NewAttributePath("name"), "name is required").Get(),
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *gotesting.T) {
+			Test(t, TestCase{
+				ProviderName:    "test",
+				ProviderFactory: testProvider,
+				ProviderConfig:  map[string]any{},
+				ResourceType:    "test_object",
+				Scope:           kfplugin1.Scope_NAMESPACE,
+				Steps:           tc.steps,
+			})
+		})
+	}
+}
+
+func TestIsSubset(t *gotesting.T) {
+	cases := map[string]struct {
+		expected  any
+		actual    any
+		expectErr bool
+	}{
+		"Equal": {
+			expected: map[string]any{"a": "b"},
+			actual:   map[string]any{"a": "b"},
+		},
+		"Subset": {
+			expected: map[string]any{"a": map[string]any{"b": []any{"c"}}},
+			actual:   map[string]any{"a": map[string]any{"b": []any{"c"}, "d": "e"}, "f": "g"},
+		},
+		"Missing": {
+			expected:  map[string]any{"a": "b"},
+			actual:    map[string]any{"c": "d"},
+			expectErr: true,
+		},
+		"Different": {
+			expected:  map[string]any{"a": []any{"b"}},
+			actual:    map[string]any{"a": []any{"c"}},
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *gotesting.T) {
+			err := isSubset("", tc.expected, tc.actual)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("want error: %t, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
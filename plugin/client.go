@@ -107,6 +107,13 @@ func (c *Client) NegotiatedVersion() int {
 	return c.negotiatedVersion
 }
 
+// Exited tells whether the underlying process has exited.
+func (c *Client) Exited() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.exited
+}
+
 // ID returns a unique ID for the running plugin. By default this is the process
 // ID (pid), but it could take other forms if RunnerFunc was provided.
 func (c *Client) ID() string {
@@ -434,6 +441,7 @@ func (c *Client) Kill() {
 
 	// If there is no runner or ID, there is nothing to kill.
 	if runner == nil || runner.ID() == "" {
+		c.detach()
 		return
 	}
 
@@ -500,6 +508,25 @@ func (c *Client) Kill() {
 	c.m.Unlock()
 }
 
+// detach closes the connection to a plugin that was reattached in test mode
+// without shutting down the plugin itself.
+func (c *Client) detach() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.config.Reattach == nil || !c.config.Reattach.Test {
+		return
+	}
+	if client, ok := c.client.(*GRPCClient); ok {
+		client.broker.Close()
+		client.Conn.Close()
+	}
+	c.client = nil
+	if c.ctxCancel != nil {
+		c.ctxCancel()
+	}
+}
+
 // Start the underlying subprocess, communicating with it to negotiate
 // a port for RPC connections, and returning the address to connect via RPC.
 //
@@ -539,11 +566,9 @@ func (c *Client) Start() (addr net.Addr, err error) {
 		}
 	}
 
-	/*
-		if c.config.Reattach != nil {
-			return c.reattach()
-		}
-	*/
+	if c.config.Reattach != nil {
+		return c.reattach()
+	}
 
 	if c.config.VersionedPlugins == nil {
 		c.config.VersionedPlugins = make(map[int]PluginSet)
@@ -750,6 +775,9 @@ func (c *Client) Start() (addr net.Addr, err error) {
 		//fmt.Println("line", line)
 		parts := strings.SplitN(line, "|", 6)
 		//fmt.Println("line", parts)
+		if len(parts) < 4 {
+			return addr, fmt.Errorf("unrecognized remote plugin message: %s", line)
+		}
 		/*
 			if len(parts) < 4 {
 				errText := fmt.Sprintf("Unrecognized remote plugin message: %s", line)
@@ -816,11 +844,12 @@ func (c *Client) Start() (addr net.Addr, err error) {
 			return nil, fmt.Errorf("unknown address type: %s", address)
 		}
 
-		// See if we have a TLS certificate from the server.
+		// See if we have a TLS certificate from the server, the server
+		// sends core|proto|network|address|cert.
 		// Checking if the length is > 50 rules out catching the unused "extra"
 		// data returned from some older implementations.
-		if len(parts) >= 6 && len(parts[5]) > 50 {
-			err := c.loadServerCert(parts[5])
+		if len(parts) >= 5 && len(parts[4]) > 50 {
+			err := c.loadServerCert(parts[4])
 			if err != nil {
 				return nil, fmt.Errorf("error parsing server cert: %s", err)
 			}
@@ -831,6 +860,61 @@ func (c *Client) Start() (addr net.Addr, err error) {
 	return
 }
 
+// reattach connects to a plugin process that is already running, e.g. a
+// provider that runs under a debugger
+func (c *Client) reattach() (net.Addr, error) {
+	reattachFunc := c.config.Reattach.ReattachFunc
+	if reattachFunc == nil {
+		reattachFunc = cmdrunner.ReattachFunc(c.config.Reattach.Pid, c.config.Reattach.Addr)
+	}
+
+	r, err := reattachFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	protoVersion := c.config.Reattach.ProtocolVersion
+	if protoVersion == 0 {
+		protoVersion = 1
+	}
+	version, plugins, err := c.checkProtoVersion(strconv.Itoa(protoVersion))
+	if err != nil {
+		return nil, err
+	}
+	c.negotiatedPlugins = plugins
+	c.negotiatedVersion = version
+
+	// Create a context for when we kill
+	c.doneCtx, c.ctxCancel = context.WithCancel(context.Background())
+
+	c.clientWaitGroup.Add(1)
+	// Goroutine to mark exit status
+	go func(r runner.AttachedRunner) {
+		defer c.clientWaitGroup.Done()
+
+		// ensure the context is cancelled when we're done
+		defer c.ctxCancel()
+
+		// Wait for the process to die
+		r.Wait(c.doneCtx)
+
+		c.logger.Debug("reattached plugin process exited")
+
+		c.m.Lock()
+		defer c.m.Unlock()
+		c.exited = true
+	}(r)
+
+	// In test mode the runner is not set, this prevents the process from
+	// being killed when the client is killed.
+	if !c.config.Reattach.Test {
+		c.runner = r
+	}
+
+	c.address = c.config.Reattach.Addr
+	return c.address, nil
+}
+
 // loadServerCert is used by AutoMTLS to read an x.509 cert returned by the
 // server, and load it as the RootCA and ClientCA for the client TLSConfig.
 func (c *Client) loadServerCert(cert string) error {
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -73,6 +74,31 @@ type ServeConfig struct {
 	// Logger is used to pass a logger into the server. If none is provided the
 	// server will create a default logger.
 	Logger *slog.Logger
+
+	// Test, if non-nil, will put plugin serving into "test mode". This is
+	// used to run the plugin in-process or under a debugger, the client
+	// connects to it with a ReattachConfig.
+	Test *ServeTestConfig
+}
+
+// ServeTestConfig configures plugin serving for test mode. See ServeConfig.Test.
+type ServeTestConfig struct {
+	// Context, if set, will force the plugin serving to end when cancelled.
+	Context context.Context
+
+	// If this channel is non-nil, we will send the ReattachConfig via
+	// this channel. This can be encoded (via JSON recommended) to the
+	// plugin client to attach to this plugin.
+	ReattachConfigCh chan<- *ReattachConfig
+
+	// CloseCh, if non-nil, will be closed when serving exits.
+	CloseCh chan<- struct{}
+
+	// SyncStdio, if true, will enable the client side "SyncStdout/Stderr"
+	// functionality to work. This defaults to false because the test mode
+	// keeps the stdout/stderr of the process, e.g. to see the logs in the
+	// debugger.
+	SyncStdio bool
 }
 
 // Serve serves the plugins given by ServeConfig.
@@ -89,9 +115,13 @@ func Serve(opts *ServeConfig) {
 	// deferred functions. In test mode, we just output the err to stderr
 	// and return.
 	defer func() {
-		if exitCode >= 0 {
+		if opts.Test == nil && exitCode >= 0 {
 			os.Exit(exitCode)
 		}
+
+		if opts.Test != nil && opts.Test.CloseCh != nil {
+			close(opts.Test.CloseCh)
+		}
 	}()
 
 	// Validate the handshake config
@@ -104,7 +134,8 @@ func Serve(opts *ServeConfig) {
 	//fmt.Printf("magic cookie key: %s\n", opts.MagicCookieKey)
 	//fmt.Printf("magic cookie value: %s\n", opts.MagicCookieValue)
 	//fmt.Printf("magic cookie env: %s\n", os.Getenv(opts.MagicCookieKey))
-	if os.Getenv(opts.MagicCookieKey) != opts.MagicCookieValue {
+	// in test mode the plugin is not started by the plugin loader
+	if opts.Test == nil && os.Getenv(opts.MagicCookieKey) != opts.MagicCookieValue {
 		fmt.Fprintf(os.Stderr,
 			`cannot execute this plugin direct, execute the plugin via the plugin loader`)
 		exitCode = 1
@@ -151,7 +182,9 @@ func Serve(opts *ServeConfig) {
 	// If the client is configured using AutoMTLS, the certificate will be here,
 	// and we need to generate our own in response.
 	if tlsConfig == nil && clientCert != "" {
-		l.Info("configuring server automatic mTLS")
+		// stdout carries the handshake, so nothing is logged at info level
+		// before it is written
+		l.Debug("configuring server automatic mTLS")
 		clientCertPool := x509.NewCertPool()
 		if !clientCertPool.AppendCertsFromPEM([]byte(clientCert)) {
 			l.Error("client cert provided but failed to parse", "cert", clientCert)
@@ -221,13 +254,26 @@ func Serve(opts *ServeConfig) {
 		"address", listener.Addr().String(),
 	)
 
-	fmt.Printf("%d|%d|%s|%s|%s\n",
-		CoreProtocolVersion,
-		protoVersion,
-		listener.Addr().Network(),
-		listener.Addr().String(),
-		serverCert)
-	os.Stdout.Sync()
+	// Output the address and service name to stdout so that the client can
+	// bring it up. In test mode, we don't do this, but send the reattach
+	// config on the channel instead.
+	if opts.Test == nil {
+		fmt.Printf("%d|%d|%s|%s|%s\n",
+			CoreProtocolVersion,
+			protoVersion,
+			listener.Addr().Network(),
+			listener.Addr().String(),
+			serverCert)
+		os.Stdout.Sync()
+	} else if ch := opts.Test.ReattachConfigCh; ch != nil {
+		// Send it
+		ch <- &ReattachConfig{
+			ProtocolVersion: protoVersion,
+			Addr:            listener.Addr(),
+			Pid:             os.Getpid(),
+			Test:            true,
+		}
+	}
 
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt)
@@ -240,13 +286,30 @@ func Serve(opts *ServeConfig) {
 		}
 	}()
 
-	os.Stdout = stdout_w
-	os.Stderr = stderr_w
+	// Set our stdout, stderr to the stdio stream that clients can retrieve
+	// using ClientConfig.SyncStdout/err. We only do this for non-test mode
+	// or if the test mode explicitly requests it.
+	if opts.Test == nil || opts.Test.SyncStdio {
+		os.Stdout = stdout_w
+		os.Stderr = stderr_w
+	}
 
 	// Accept connections and wait for completion
 	go server.Serve(listener)
 
+	ctx := context.Background()
+	if opts.Test != nil && opts.Test.Context != nil {
+		ctx = opts.Test.Context
+	}
 	select {
+	case <-ctx.Done():
+		// Cancellation, we stop the server by closing the listener and
+		// the grpc server, which kills all connections.
+		listener.Close()
+		server.Stop()
+
+		// Wait for the server itself to shut down
+		<-doneCh
 	case <-doneCh:
 		// Note that given the documentation of Serve we should probably be
 		// setting exitCode = 0 and using os.Exit here. That's how it used to
@@ -285,6 +348,12 @@ func protocolVersion(opts *ServeConfig) (int, PluginSet, error) {
 	}
 	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
 
+	// In test mode the plugin is not started by the client, so there is
+	// nothing to negotiate and the latest version is served
+	if opts.Test != nil && len(clientVersions) == 0 && len(versions) > 0 {
+		return versions[0], opts.VersionedPlugins[versions[0]], nil
+	}
+
 	// See if we have multiple versions of Plugins to choose from
 	for _, version := range versions {
 		version := version
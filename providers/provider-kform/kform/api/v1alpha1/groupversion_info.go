@@ -0,0 +1,8 @@
+// +groupName=kform.provider.kform.io
+package v1alpha1
+
+const (
+	Group      = "kform.provider.kform.io"
+	Version    = "v1alpha1"
+	APIVersion = Group + "/" + Version
+)
@@ -0,0 +1,116 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Keepers are arbitrary values that, when changed, regenerate the value of a
+// resource. The value is kept as long as the keepers do not change.
+type Keepers map[string]any
+
+// RandomStringSpec defines the desired state of a RandomString
+type RandomStringSpec struct {
+	// Length of the string
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=16
+	Length *int `json:"length,omitempty" yaml:"length,omitempty"`
+	// Upper, Lower, Numeric and Special select the characters of the string,
+	// by default the string has upper, lower and numeric characters
+	Upper   *bool `json:"upper,omitempty" yaml:"upper,omitempty"`
+	Lower   *bool `json:"lower,omitempty" yaml:"lower,omitempty"`
+	Numeric *bool `json:"numeric,omitempty" yaml:"numeric,omitempty"`
+	Special *bool `json:"special,omitempty" yaml:"special,omitempty"`
+	// Keepers regenerate the string when changed
+	Keepers Keepers `json:"keepers,omitempty" yaml:"keepers,omitempty"`
+}
+
+// RandomStringStatus defines the observed state of a RandomString
+type RandomStringStatus struct {
+	// Result is the generated string
+	Result string `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+// RandomString is a random string that is generated once
+type RandomString struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Spec   RandomStringSpec   `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Status RandomStringStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// UUIDSpec defines the desired state of a UUID
+type UUIDSpec struct {
+	// Keepers regenerate the uuid when changed
+	Keepers Keepers `json:"keepers,omitempty" yaml:"keepers,omitempty"`
+}
+
+// UUIDStatus defines the observed state of a UUID
+type UUIDStatus struct {
+	// Result is the generated uuid
+	Result string `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+// UUID is a random uuid that is generated once
+type UUID struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Spec   UUIDSpec   `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Status UUIDStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// TimeStaticSpec defines the desired state of a TimeStatic
+type TimeStaticSpec struct {
+	// RFC3339 is the time in RFC3339 format, by default the time of the
+	// creation is used
+	RFC3339 *string `json:"rfc3339,omitempty" yaml:"rfc3339,omitempty"`
+	// Keepers reset the time when changed
+	Keepers Keepers `json:"keepers,omitempty" yaml:"keepers,omitempty"`
+}
+
+// TimeStaticStatus defines the observed state of a TimeStatic
+type TimeStaticStatus struct {
+	// RFC3339 is the time in RFC3339 format
+	RFC3339 string `json:"rfc3339,omitempty" yaml:"rfc3339,omitempty"`
+	// Unix is the time in seconds since the unix epoch
+	Unix int64 `json:"unix,omitempty" yaml:"unix,omitempty"`
+}
+
+// TimeStatic is a time that is set once
+type TimeStatic struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Spec   TimeStaticSpec   `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Status TimeStaticStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// LocalFileSpec defines the desired state of a LocalFile
+type LocalFileSpec struct {
+	// Filename is the path of the file, a relative path is relative to the
+	// working directory of kform
+	// +kubebuilder:validation:Required
+	Filename string `json:"filename" yaml:"filename"`
+	// Content of the file
+	Content string `json:"content,omitempty" yaml:"content,omitempty"`
+	// FilePermission is the permission of the file in octal notation
+	// +kubebuilder:default:="0644"
+	FilePermission *string `json:"filePermission,omitempty" yaml:"filePermission,omitempty"`
+}
+
+// LocalFileStatus defines the observed state of a LocalFile
+type LocalFileStatus struct {
+	// SHA256 is the hex encoded sha256 of the content of the file
+	SHA256 string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
+}
+
+// LocalFile is a file on the local filesystem, the file is removed when
+// the resource is deleted
+type LocalFile struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Spec   LocalFileSpec   `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Status LocalFileStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
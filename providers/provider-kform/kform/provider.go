@@ -0,0 +1,73 @@
+package kform
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+)
+
+// Provider returns the built-in kform provider. The provider runs in-process
+// and generates values that are kept in the state of kform, such that the
+// values are stable across runs.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourceMap: map[string]*schema.Resource{
+			"kform_random_string": resourceKformRandomString(),
+			"kform_uuid":          resourceKformUUID(),
+			"kform_time_static":   resourceKformTimeStatic(),
+			"kform_local_file":    resourceKformLocalFile(),
+		},
+		DataSourcesMap:     map[string]*schema.Resource{},
+		ListDataSourcesMap: map[string]*schema.Resource{},
+	}
+}
+
+// getOldState unmarshals the state the provider returned in the previous run
+// in old, false when there is no old state
+func getOldState(d *schema.ResourceObject, old any) (bool, error) {
+	if len(d.GetOldState()) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(d.GetOldState(), old); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// isSameSpec returns true when the json representation of the specs is
+// equal, such that keepers of different go types compare equal. The value of
+// a resource is kept as long as its spec, including the keepers, does not
+// change.
+func isSameSpec(a, b any) (bool, error) {
+	x, err := normalize(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := normalize(b)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(x, y), nil
+}
+
+func normalize(spec any) (any, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var x any
+	if err := json.Unmarshal(b, &x); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func marshal(o any) ([]byte, diag.Diagnostics) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return b, nil
+}
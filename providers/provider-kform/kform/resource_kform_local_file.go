@@ -0,0 +1,117 @@
+package kform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+)
+
+const defaultFilePermission = "0644"
+
+func resourceKformLocalFile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKformLocalFileCreate,
+		UpdateContext: resourceKformLocalFileUpdate,
+		DeleteContext: resourceKformLocalFileDelete,
+	}
+}
+
+// resourceKformLocalFileCreate writes the content to the file, the file is
+// not written in dry run
+func resourceKformLocalFileCreate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	u := &v1alpha1.LocalFile{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if u.Spec.Filename == "" {
+		return nil, diag.Errorf("cannot write a local file without filename")
+	}
+	perm, err := getFilePermission(u.Spec)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if !d.IsDryRun() {
+		if err := os.MkdirAll(filepath.Dir(u.Spec.Filename), 0755); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if err := os.WriteFile(u.Spec.Filename, []byte(u.Spec.Content), perm); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		// the permission of an existing file is not changed by WriteFile
+		if err := os.Chmod(u.Spec.Filename, perm); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+	sum := sha256.Sum256([]byte(u.Spec.Content))
+	u.Status.SHA256 = hex.EncodeToString(sum[:])
+	return marshal(u)
+}
+
+// resourceKformLocalFileUpdate writes the file again, such that a file that
+// was changed outside of kform is restored. The old file is removed when the
+// filename changed.
+func resourceKformLocalFileUpdate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	b, diags := resourceKformLocalFileCreate(ctx, d, meta)
+	if diags.HasError() {
+		return nil, diags
+	}
+	newu := &v1alpha1.LocalFile{}
+	if err := json.Unmarshal(d.GetObject(), newu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	oldu := &v1alpha1.LocalFile{}
+	if err := json.Unmarshal(d.GetOldObject(), oldu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if !d.IsDryRun() && oldu.Spec.Filename != "" && filepath.Clean(oldu.Spec.Filename) != filepath.Clean(newu.Spec.Filename) {
+		if err := removeFile(oldu.Spec.Filename); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+	return b, diags
+}
+
+// resourceKformLocalFileDelete removes the file, removing a file that does
+// not exist succeeds
+func resourceKformLocalFileDelete(ctx context.Context, d *schema.ResourceObject, meta interface{}) diag.Diagnostics {
+	u := &v1alpha1.LocalFile{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return diag.FromErr(err)
+	}
+	if d.IsDryRun() || u.Spec.Filename == "" {
+		return nil
+	}
+	if err := removeFile(u.Spec.Filename); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func removeFile(filename string) error {
+	if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func getFilePermission(spec v1alpha1.LocalFileSpec) (os.FileMode, error) {
+	perm := defaultFilePermission
+	if spec.FilePermission != nil {
+		perm = *spec.FilePermission
+	}
+	mode, err := strconv.ParseUint(perm, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid file permission %s, expected an octal permission, e.g. %s", perm, defaultFilePermission)
+	}
+	return os.FileMode(mode), nil
+}
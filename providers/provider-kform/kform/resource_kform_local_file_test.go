@@ -0,0 +1,60 @@
+package kform
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func getLocalFile(t *testing.T, filename, content string) []byte {
+	u := &v1alpha1.LocalFile{}
+	u.APIVersion = v1alpha1.APIVersion
+	u.Kind = "LocalFile"
+	u.SetName("file")
+	u.Spec.Filename = filename
+	u.Spec.Content = content
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestResourceKformLocalFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a", "token")
+	b := filepath.Join(dir, "b", "token")
+
+	if _, diags := resourceKformLocalFileCreate(ctx, &schema.ResourceObject{Obj: getLocalFile(t, a, "x"), DryRun: true}, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	assert.NoFileExists(t, a)
+
+	if _, diags := resourceKformLocalFileCreate(ctx, &schema.ResourceObject{Obj: getLocalFile(t, a, "x")}, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	content, err := os.ReadFile(a)
+	assert.NoError(t, err)
+	assert.Equal(t, "x", string(content))
+
+	// renaming the file removes the old file
+	if _, diags := resourceKformLocalFileUpdate(ctx, &schema.ResourceObject{Obj: getLocalFile(t, b, "y"), OldObj: getLocalFile(t, a, "x")}, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	assert.NoFileExists(t, a)
+	content, err = os.ReadFile(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "y", string(content))
+
+	if diags := resourceKformLocalFileDelete(ctx, &schema.ResourceObject{Obj: getLocalFile(t, b, "y")}, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	assert.NoFileExists(t, b)
+}
@@ -0,0 +1,117 @@
+package kform
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+)
+
+const (
+	defaultRandomStringLength = 16
+
+	upperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	numericChars = "0123456789"
+	specialChars = "!@#$%&*()-_=+[]{}<>:?"
+)
+
+func resourceKformRandomString() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKformRandomStringCreate,
+		UpdateContext: resourceKformRandomStringUpdate,
+		DeleteContext: resourceKformDelete,
+	}
+}
+
+func resourceKformRandomStringCreate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	u := &v1alpha1.RandomString{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	result, err := randomString(u.Spec)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	u.Status.Result = result
+	return marshal(u)
+}
+
+// resourceKformRandomStringUpdate keeps the string of the old state when the
+// spec, including the keepers, did not change
+func resourceKformRandomStringUpdate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	u := &v1alpha1.RandomString{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	old := &v1alpha1.RandomString{}
+	ok, err := getOldState(d, old)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if ok && old.Status.Result != "" {
+		same, err := isSameSpec(old.Spec, u.Spec)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if same {
+			u.Status = old.Status
+			return marshal(u)
+		}
+	}
+	return resourceKformRandomStringCreate(ctx, d, meta)
+}
+
+// resourceKformDelete deletes a resource that only exists in the state of
+// kform, there is nothing to delete
+func resourceKformDelete(ctx context.Context, d *schema.ResourceObject, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func randomString(spec v1alpha1.RandomStringSpec) (string, error) {
+	length := defaultRandomStringLength
+	if spec.Length != nil {
+		length = *spec.Length
+	}
+	if length < 1 {
+		return "", fmt.Errorf("invalid length %d, expected a length larger than 0", length)
+	}
+	chars := ""
+	if isEnabled(spec.Upper, true) {
+		chars += upperChars
+	}
+	if isEnabled(spec.Lower, true) {
+		chars += lowerChars
+	}
+	if isEnabled(spec.Numeric, true) {
+		chars += numericChars
+	}
+	if isEnabled(spec.Special, false) {
+		chars += specialChars
+	}
+	if chars == "" {
+		return "", fmt.Errorf("cannot generate a string without characters, enable upper, lower, numeric or special")
+	}
+	b := make([]byte, length)
+	max := big.NewInt(int64(len(chars)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = chars[n.Int64()]
+	}
+	return string(b), nil
+}
+
+func isEnabled(b *bool, dflt bool) bool {
+	if b == nil {
+		return dflt
+	}
+	return *b
+}
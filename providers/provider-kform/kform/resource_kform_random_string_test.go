@@ -0,0 +1,86 @@
+package kform
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func getRandomString(t *testing.T, length int, keepers v1alpha1.Keepers) []byte {
+	u := &v1alpha1.RandomString{}
+	u.APIVersion = v1alpha1.APIVersion
+	u.Kind = "RandomString"
+	u.SetName("token")
+	u.Spec.Length = &length
+	u.Spec.Keepers = keepers
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestResourceKformRandomStringUpdate(t *testing.T) {
+	cases := map[string]struct {
+		oldObj      []byte
+		newObj      []byte
+		noOldState  bool
+		expectedNew bool
+	}{
+		"Keep": {
+			oldObj: getRandomString(t, 16, v1alpha1.Keepers{"cluster": "a"}),
+			newObj: getRandomString(t, 16, v1alpha1.Keepers{"cluster": "a"}),
+		},
+		"KeepersChanged": {
+			oldObj:      getRandomString(t, 16, v1alpha1.Keepers{"cluster": "a"}),
+			newObj:      getRandomString(t, 16, v1alpha1.Keepers{"cluster": "b"}),
+			expectedNew: true,
+		},
+		"LengthChanged": {
+			oldObj:      getRandomString(t, 16, nil),
+			newObj:      getRandomString(t, 8, nil),
+			expectedNew: true,
+		},
+		"NoOldState": {
+			oldObj:      getRandomString(t, 16, nil),
+			newObj:      getRandomString(t, 16, nil),
+			noOldState:  true,
+			expectedNew: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			state, diags := resourceKformRandomStringCreate(ctx, &schema.ResourceObject{Obj: tc.oldObj}, nil)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			old := &v1alpha1.RandomString{}
+			if err := json.Unmarshal(state, old); err != nil {
+				t.Fatal(err)
+			}
+			if tc.noOldState {
+				state = nil
+			}
+			b, diags := resourceKformRandomStringUpdate(ctx, &schema.ResourceObject{Obj: tc.newObj, OldObj: tc.oldObj, OldState: state}, nil)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			u := &v1alpha1.RandomString{}
+			if err := json.Unmarshal(b, u); err != nil {
+				t.Fatal(err)
+			}
+			assert.Len(t, u.Status.Result, *u.Spec.Length)
+			if tc.expectedNew {
+				assert.NotEqual(t, old.Status.Result, u.Status.Result)
+			} else {
+				assert.Equal(t, old.Status.Result, u.Status.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package kform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+)
+
+func resourceKformTimeStatic() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKformTimeStaticCreate,
+		UpdateContext: resourceKformTimeStaticUpdate,
+		DeleteContext: resourceKformDelete,
+	}
+}
+
+func resourceKformTimeStaticCreate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	u := &v1alpha1.TimeStatic{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	t := time.Now().UTC()
+	if u.Spec.RFC3339 != nil {
+		var err error
+		t, err = time.Parse(time.RFC3339, *u.Spec.RFC3339)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("invalid rfc3339 time %s, err: %s", *u.Spec.RFC3339, err.Error()))
+		}
+	}
+	u.Status.RFC3339 = t.Format(time.RFC3339)
+	u.Status.Unix = t.Unix()
+	return marshal(u)
+}
+
+// resourceKformTimeStaticUpdate keeps the time of the old state when the
+// spec, including the keepers, did not change
+func resourceKformTimeStaticUpdate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	u := &v1alpha1.TimeStatic{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	old := &v1alpha1.TimeStatic{}
+	ok, err := getOldState(d, old)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if ok && old.Status.RFC3339 != "" {
+		same, err := isSameSpec(old.Spec, u.Spec)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if same {
+			u.Status = old.Status
+			return marshal(u)
+		}
+	}
+	return resourceKformTimeStaticCreate(ctx, d, meta)
+}
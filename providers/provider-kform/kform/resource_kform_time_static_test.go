@@ -0,0 +1,138 @@
+package kform
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func getTimeStatic(t *testing.T, rfc3339 *string, keepers v1alpha1.Keepers) []byte {
+	u := &v1alpha1.TimeStatic{}
+	u.APIVersion = v1alpha1.APIVersion
+	u.Kind = "TimeStatic"
+	u.SetName("created")
+	u.Spec.RFC3339 = rfc3339
+	u.Spec.Keepers = keepers
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestResourceKformTimeStaticCreate(t *testing.T) {
+	cases := map[string]struct {
+		obj         []byte
+		expectedErr bool
+		expected    string
+		expectedNow bool
+	}{
+		"Now": {
+			obj:         getTimeStatic(t, nil, nil),
+			expectedNow: true,
+		},
+		"RFC3339": {
+			obj:      getTimeStatic(t, pointer.String("2023-10-01T12:00:00+02:00"), nil),
+			expected: "2023-10-01T12:00:00+02:00",
+		},
+		"InvalidRFC3339": {
+			obj:         getTimeStatic(t, pointer.String("2023-10-01"), nil),
+			expectedErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now().UTC().Truncate(time.Second)
+			b, diags := resourceKformTimeStaticCreate(context.Background(), &schema.ResourceObject{Obj: tc.obj}, nil)
+			if tc.expectedErr {
+				assert.True(t, diags.HasError())
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			u := &v1alpha1.TimeStatic{}
+			if err := json.Unmarshal(b, u); err != nil {
+				t.Fatal(err)
+			}
+			got, err := time.Parse(time.RFC3339, u.Status.RFC3339)
+			if err != nil {
+				t.Fatalf("invalid rfc3339 status %s: %s", u.Status.RFC3339, err.Error())
+			}
+			assert.Equal(t, got.Unix(), u.Status.Unix)
+			if tc.expectedNow {
+				assert.False(t, got.Before(start), "want time after %s, got: %s", start, got)
+				assert.False(t, got.After(time.Now()), "want time before now, got: %s", got)
+			} else {
+				assert.Equal(t, tc.expected, u.Status.RFC3339)
+			}
+		})
+	}
+}
+
+func TestResourceKformTimeStaticUpdate(t *testing.T) {
+	// the old state has a time in the past, such that a reset time differs
+	oldObj := getTimeStatic(t, nil, v1alpha1.Keepers{"cluster": "a"})
+	old := &v1alpha1.TimeStatic{}
+	if err := json.Unmarshal(oldObj, old); err != nil {
+		t.Fatal(err)
+	}
+	old.Status.RFC3339 = "2020-01-01T00:00:00Z"
+	old.Status.Unix = 1577836800
+	oldState, err := json.Marshal(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		newObj      []byte
+		noOldState  bool
+		expectedNew bool
+	}{
+		"Keep": {
+			newObj: getTimeStatic(t, nil, v1alpha1.Keepers{"cluster": "a"}),
+		},
+		"KeepersChanged": {
+			newObj:      getTimeStatic(t, nil, v1alpha1.Keepers{"cluster": "b"}),
+			expectedNew: true,
+		},
+		"RFC3339Changed": {
+			newObj:      getTimeStatic(t, pointer.String("2023-10-01T12:00:00Z"), v1alpha1.Keepers{"cluster": "a"}),
+			expectedNew: true,
+		},
+		"NoOldState": {
+			newObj:      getTimeStatic(t, nil, v1alpha1.Keepers{"cluster": "a"}),
+			noOldState:  true,
+			expectedNew: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := oldState
+			if tc.noOldState {
+				state = nil
+			}
+			b, diags := resourceKformTimeStaticUpdate(context.Background(), &schema.ResourceObject{Obj: tc.newObj, OldObj: oldObj, OldState: state}, nil)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			u := &v1alpha1.TimeStatic{}
+			if err := json.Unmarshal(b, u); err != nil {
+				t.Fatal(err)
+			}
+			if tc.expectedNew {
+				assert.NotEqual(t, old.Status, u.Status)
+			} else {
+				assert.Equal(t, old.Status, u.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package kform
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+)
+
+func resourceKformUUID() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKformUUIDCreate,
+		UpdateContext: resourceKformUUIDUpdate,
+		DeleteContext: resourceKformDelete,
+	}
+}
+
+func resourceKformUUIDCreate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	u := &v1alpha1.UUID{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	u.Status.Result = id.String()
+	return marshal(u)
+}
+
+// resourceKformUUIDUpdate keeps the uuid of the old state when the keepers
+// did not change
+func resourceKformUUIDUpdate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	u := &v1alpha1.UUID{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	old := &v1alpha1.UUID{}
+	ok, err := getOldState(d, old)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if ok && old.Status.Result != "" {
+		same, err := isSameSpec(old.Spec, u.Spec)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if same {
+			u.Status = old.Status
+			return marshal(u)
+		}
+	}
+	return resourceKformUUIDCreate(ctx, d, meta)
+}
@@ -0,0 +1,82 @@
+package kform
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func getUUID(t *testing.T, keepers v1alpha1.Keepers) []byte {
+	u := &v1alpha1.UUID{}
+	u.APIVersion = v1alpha1.APIVersion
+	u.Kind = "UUID"
+	u.SetName("id")
+	u.Spec.Keepers = keepers
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestResourceKformUUIDUpdate(t *testing.T) {
+	cases := map[string]struct {
+		oldObj      []byte
+		newObj      []byte
+		noOldState  bool
+		expectedNew bool
+	}{
+		"Keep": {
+			oldObj: getUUID(t, v1alpha1.Keepers{"cluster": "a"}),
+			newObj: getUUID(t, v1alpha1.Keepers{"cluster": "a"}),
+		},
+		"KeepersChanged": {
+			oldObj:      getUUID(t, v1alpha1.Keepers{"cluster": "a"}),
+			newObj:      getUUID(t, v1alpha1.Keepers{"cluster": "b"}),
+			expectedNew: true,
+		},
+		"NoOldState": {
+			oldObj:      getUUID(t, nil),
+			newObj:      getUUID(t, nil),
+			noOldState:  true,
+			expectedNew: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			state, diags := resourceKformUUIDCreate(ctx, &schema.ResourceObject{Obj: tc.oldObj}, nil)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			old := &v1alpha1.UUID{}
+			if err := json.Unmarshal(state, old); err != nil {
+				t.Fatal(err)
+			}
+			if tc.noOldState {
+				state = nil
+			}
+			b, diags := resourceKformUUIDUpdate(ctx, &schema.ResourceObject{Obj: tc.newObj, OldObj: tc.oldObj, OldState: state}, nil)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			u := &v1alpha1.UUID{}
+			if err := json.Unmarshal(b, u); err != nil {
+				t.Fatal(err)
+			}
+			_, err := uuid.Parse(u.Status.Result)
+			assert.NoError(t, err)
+			if tc.expectedNew {
+				assert.NotEqual(t, old.Status.Result, u.Status.Result)
+			} else {
+				assert.Equal(t, old.Status.Result, u.Status.Result)
+			}
+		})
+	}
+}
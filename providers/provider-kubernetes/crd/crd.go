@@ -0,0 +1,10 @@
+// Package crd holds the generated custom resource definitions of the
+// provider.
+package crd
+
+import _ "embed"
+
+// ProviderConfig is the custom resource definition of the provider config
+//
+//go:embed kubernetes.provider.kform.io_providerconfigs.yaml
+var ProviderConfig []byte
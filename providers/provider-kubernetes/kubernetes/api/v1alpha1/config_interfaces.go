@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -17,6 +19,48 @@ func (r *ProviderConfigSpec) IsKindValid() bool {
 
 var ExpectedProviderKinds = []string{string(ProviderKindPackage), string(ProviderKindAPI)}
 
+// DefaultFieldManager is the field manager used for server-side apply when no
+// field manager is configured
+const DefaultFieldManager = "kform"
+
+// GetFieldManager returns the field manager used for server-side apply
+func (r *ProviderConfigSpec) GetFieldManager() string {
+	if r.FieldManager == nil || *r.FieldManager == "" {
+		return DefaultFieldManager
+	}
+	return *r.FieldManager
+}
+
+var ExpectedDeletePropagations = []string{
+	string(metav1.DeletePropagationBackground),
+	string(metav1.DeletePropagationForeground),
+	string(metav1.DeletePropagationOrphan),
+}
+
+// IsDeletePropagationValid returns true when the policy is a known
+// propagation policy
+func IsDeletePropagationValid(policy metav1.DeletionPropagation) bool {
+	switch policy {
+	case metav1.DeletePropagationBackground, metav1.DeletePropagationForeground, metav1.DeletePropagationOrphan:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetDeletePropagation returns the propagation policy used to delete objects,
+// an error is returned when the configured policy is unknown
+func (r *ProviderConfigSpec) GetDeletePropagation() (metav1.DeletionPropagation, error) {
+	if r.DeletePropagation == nil || *r.DeletePropagation == "" {
+		return metav1.DeletePropagationBackground, nil
+	}
+	policy := metav1.DeletionPropagation(*r.DeletePropagation)
+	if !IsDeletePropagationValid(policy) {
+		return "", fmt.Errorf("invalid deletePropagation, got: %s, expected: %v", policy, ExpectedDeletePropagations)
+	}
+	return policy, nil
+}
+
 // BuildProviderConfig returns a ProviderConfig from a meta Object and
 // an ProviderConfig Spec
 func BuildProviderConfig(meta metav1.ObjectMeta, spec ProviderConfigSpec) *ProviderConfig {
@@ -29,3 +73,12 @@ func BuildProviderConfig(meta metav1.ObjectMeta, spec ProviderConfigSpec) *Provi
 		Spec:       spec,
 	}
 }
+
+// GetPathTemplate returns the template of the file path new objects of a
+// package are written to, empty when the default layout is used
+func (r *ProviderConfigSpec) GetPathTemplate() string {
+	if r.PathTemplate == nil {
+		return ""
+	}
+	return *r.PathTemplate
+}
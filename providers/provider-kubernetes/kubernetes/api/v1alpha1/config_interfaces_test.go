@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDeletePropagation(t *testing.T) {
+	cases := map[string]struct {
+		policy    *string
+		want      metav1.DeletionPropagation
+		expectErr bool
+	}{
+		"Default": {
+			want: metav1.DeletePropagationBackground,
+		},
+		"Foreground": {
+			policy: ptr("Foreground"),
+			want:   metav1.DeletePropagationForeground,
+		},
+		"Invalid": {
+			policy:    ptr("Later"),
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec := &ProviderConfigSpec{DeletePropagation: tc.policy}
+			got, err := spec.GetDeletePropagation()
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
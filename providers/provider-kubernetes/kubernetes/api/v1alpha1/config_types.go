@@ -15,6 +15,12 @@ type ProviderConfigSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MaxLength=64
 	Directory *string `json:"directory,omitempty" yaml:"directory,omitempty"`
+	// PathTemplate is the go template of the file path, relative to the
+	// directory, an object that is not in the package yet is written to.
+	// The template gets the Group, Version, Kind, Namespace and Name of the
+	// object.
+	// +kubebuilder:validation:MaxLength=256
+	PathTemplate *string `json:"pathTemplate,omitempty" yaml:"pathTemplate,omitempty"`
 	// The hostname (in form of URI) of Kubernetes master.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MaxLength=64
@@ -89,6 +95,17 @@ type ProviderConfigSpec struct {
 	// Use the  local kubeconfig
 	UseConfigFile *bool `json:"useConfigFile,omitempty" yaml:"useConfigFile,omitempty"`
 
+	// FieldManager is the name of the field manager used for server-side apply
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:default="kform"
+	FieldManager *string `json:"fieldManager,omitempty" yaml:"fieldManager,omitempty"`
+
+	// DeletePropagation is the propagation policy used to delete objects,
+	// the manifest can override it with an annotation
+	// +kubebuilder:validation:Enum=Background;Foreground;Orphan
+	// +kubebuilder:default="Background"
+	DeletePropagation *string `json:"deletePropagation,omitempty" yaml:"deletePropagation,omitempty"`
+
 	// Exec executes a command to get the authentication context
 	//Exec *ExecContext `json:"exec,omitempty" yaml:"exec,omitempty"`
 }
@@ -3,7 +3,7 @@ package client
 import (
 	"context"
 
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -11,6 +11,21 @@ type Client interface {
 	client.Reader
 	client.Writer
 	Applicator
+	// RESTMapper resolves the scope and the versions of a kind
+	RESTMapper() meta.RESTMapper
+}
+
+// An OfflineClient does not talk to an api server, e.g. the package client
+// writes the objects to a directory. The objects are never reconciled by a
+// controller.
+type OfflineClient interface {
+	Offline() bool
+}
+
+// IsOffline returns true when the client does not talk to an api server
+func IsOffline(c any) bool {
+	o, ok := c.(OfflineClient)
+	return ok && o.Offline()
 }
 
 // An ApplyFn is a function that satisfies the Applicator interface.
@@ -26,6 +41,38 @@ type Applicator interface {
 	Apply(context.Context, client.Object, ...ApplyOption) error
 }
 
-// An ApplyOption is called before patching the current object to match the
-// desired object. ApplyOptions are not called if no current object exists.
-type ApplyOption func(ctx context.Context, current, desired runtime.Object) error
+// ApplyOptions are the options of a server-side apply
+type ApplyOptions struct {
+	// FieldManager overrides the field manager of the applicator
+	FieldManager string
+	// ForceConflicts takes the ownership of the fields that are owned by
+	// other field managers
+	ForceConflicts bool
+}
+
+// An ApplyOption configures the server-side apply of an object.
+type ApplyOption func(*ApplyOptions)
+
+// WithFieldManager overrides the field manager of the applicator
+func WithFieldManager(fieldManager string) ApplyOption {
+	return func(o *ApplyOptions) {
+		o.FieldManager = fieldManager
+	}
+}
+
+// WithForceConflicts takes the ownership of conflicting fields instead of
+// failing the apply
+func WithForceConflicts(force bool) ApplyOption {
+	return func(o *ApplyOptions) {
+		o.ForceConflicts = force
+	}
+}
+
+// GetApplyOptions returns the apply options with the options applied
+func GetApplyOptions(fieldManager string, ao ...ApplyOption) *ApplyOptions {
+	o := &ApplyOptions{FieldManager: fieldManager}
+	for _, fn := range ao {
+		fn(o)
+	}
+	return o
+}
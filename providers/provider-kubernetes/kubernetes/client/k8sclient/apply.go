@@ -2,67 +2,56 @@ package k8sclient
 
 import (
 	"context"
-	"encoding/json"
 
 	provclient "github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
 	"github.com/pkg/errors"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// An APIPatchingApplicator applies changes to an object by either creating or
-// patching it in a Kubernetes API server.
-type APIPatchingApplicator struct {
+// An APIApplicator applies changes to an object using server-side apply in a
+// Kubernetes API server. Fields owned by other field managers are not
+// changed, unless the ownership is forced.
+type APIApplicator struct {
 	client.Client
+	fieldManager      string
+	deletePropagation metav1.DeletionPropagation
 }
 
-// NewAPIPatchingApplicator returns an Applicator that applies changes to an
-// object by either creating or patching it in a Kubernetes API server.
-func NewAPIPatchingApplicator(c client.Client) APIPatchingApplicator {
-	return APIPatchingApplicator{c}
+// NewAPIApplicator returns an Applicator that applies changes to an object
+// using server-side apply with the given field manager.
+func NewAPIApplicator(c client.Client, fieldManager string) APIApplicator {
+	return APIApplicator{Client: c, fieldManager: fieldManager}
 }
 
-// Apply changes to the supplied object. The object will be created if it does
-// not exist, or patched if it does. If the object does exist, it will only be
-// patched if the passed object has the same or an empty resource version.
-func (a APIPatchingApplicator) Apply(ctx context.Context, o client.Object, ao ...provclient.ApplyOption) error {
-	//if o.GetNamespace() == "" {
-	//	o.SetNamespace("default")
-	//}
+// Apply changes to the supplied object. The object is created if it does not
+// exist, otherwise the fields of the object are merged with the fields owned
+// by other field managers. The apply fails with a conflict when a field is
+// owned by another field manager, unless the conflicts are forced.
+func (a APIApplicator) Apply(ctx context.Context, o client.Object, ao ...provclient.ApplyOption) error {
+	opts := provclient.GetApplyOptions(a.fieldManager, ao...)
 
-	m, ok := o.(metav1.Object)
-	if !ok {
-		return errors.New("cannot access object metadata")
+	if o.GetName() == "" && o.GetGenerateName() != "" {
+		return errors.Wrap(a.Create(ctx, o, client.FieldOwner(opts.FieldManager)), "cannot create object")
 	}
 
-	if m.GetName() == "" && m.GetGenerateName() != "" {
-		return errors.Wrap(a.Create(ctx, o), "cannot create object")
-	}
-
-	desired := o.DeepCopyObject()
+	// server-side apply expresses the intent of the field manager, the
+	// resource version and managed fields are owned by the api server
+	o.SetResourceVersion("")
+	o.SetManagedFields(nil)
 
-	err := a.Get(ctx, types.NamespacedName{Name: m.GetName(), Namespace: m.GetNamespace()}, o)
-	if kerrors.IsNotFound(err) {
-		// TODO: Apply ApplyOptions here too?
-		return errors.Wrap(a.Create(ctx, o), "cannot create object")
-	}
-	if err != nil {
-		return errors.Wrap(err, "cannot get object")
+	patchOpts := []client.PatchOption{client.FieldOwner(opts.FieldManager)}
+	if opts.ForceConflicts {
+		patchOpts = append(patchOpts, client.ForceOwnership)
 	}
+	return errors.Wrap(a.Patch(ctx, o, client.Apply, patchOpts...), "cannot apply object")
+}
 
-	for _, fn := range ao {
-		if err := fn(ctx, o, desired); err != nil {
-			return err
-		}
+// Delete deletes the object with the propagation policy of the applicator,
+// unless the options override it.
+func (a APIApplicator) Delete(ctx context.Context, o client.Object, opts ...client.DeleteOption) error {
+	if a.deletePropagation != "" {
+		opts = append([]client.DeleteOption{client.PropagationPolicy(a.deletePropagation)}, opts...)
 	}
-
-	// TODO: Allow callers to override the kind of patch used.
-	return errors.Wrap(a.Patch(ctx, o, &patch{desired.(client.Object)}), "cannot patch object")
+	return a.Client.Delete(ctx, o, opts...)
 }
-
-type patch struct{ from client.Object }
-
-func (p *patch) Type() types.PatchType                { return types.MergePatchType }
-func (p *patch) Data(_ client.Object) ([]byte, error) { return json.Marshal(p.from) }
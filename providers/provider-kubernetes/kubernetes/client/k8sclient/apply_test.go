@@ -0,0 +1,67 @@
+package k8sclient
+
+import (
+	"context"
+	"testing"
+
+	provclient "github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestAPIApplicator(t *testing.T) {
+	cases := map[string]struct {
+		opts                 []provclient.ApplyOption
+		expectedFieldManager string
+		expectedForce        bool
+	}{
+		"Default": {
+			expectedFieldManager: "kform",
+		},
+		"FieldManager": {
+			opts:                 []provclient.ApplyOption{provclient.WithFieldManager("edge01")},
+			expectedFieldManager: "edge01",
+		},
+		"ForceConflicts": {
+			opts:                 []provclient.ApplyOption{provclient.WithForceConflicts(true)},
+			expectedFieldManager: "kform",
+			expectedForce:        true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patchType types.PatchType
+			patchOpts := &client.PatchOptions{}
+			// the fake client does not implement server-side apply, the
+			// interceptor stands in for the api server
+			c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					patchType = patch.Type()
+					patchOpts.ApplyOptions(opts)
+					return nil
+				},
+			}).Build()
+
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion("v1")
+			u.SetKind("ConfigMap")
+			u.SetName("a")
+			u.SetNamespace("default")
+			u.SetResourceVersion("1")
+
+			a := NewAPIApplicator(c, "kform")
+			if err := a.Apply(context.Background(), u, tc.opts...); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			assert.Equal(t, types.ApplyPatchType, patchType)
+			assert.Equal(t, tc.expectedFieldManager, patchOpts.FieldManager)
+			assert.Equal(t, tc.expectedForce, patchOpts.Force != nil && *patchOpts.Force)
+			assert.Equal(t, "", u.GetResourceVersion())
+		})
+	}
+}
@@ -2,8 +2,12 @@ package k8sclient
 
 import (
 	provclient "github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -11,15 +15,30 @@ type Config struct {
 	RESTCOnfig        *rest.Config
 	IgnoreAnnotations []string
 	IgnoreLabels      []string
+	// FieldManager is the field manager used for server-side apply
+	FieldManager string
+	// DeletePropagation is the default propagation policy of a delete
+	DeletePropagation metav1.DeletionPropagation
 }
 
 func New(cfg Config) (provclient.Client, error) {
+	// the discovery of the api resources is cached, the cache is refreshed
+	// when a kind is not found, e.g. after a CRD got installed
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg.RESTCOnfig)
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+
 	c, err := client.New(cfg.RESTCOnfig, client.Options{
 		Scheme: runtime.NewScheme(),
+		Mapper: mapper,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return NewAPIPatchingApplicator(c), nil
+	a := NewAPIApplicator(c, cfg.FieldManager)
+	a.deletePropagation = cfg.DeletePropagation
+	return a, nil
 }
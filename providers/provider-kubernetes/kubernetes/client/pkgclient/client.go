@@ -5,54 +5,103 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
 
 	provclient "github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
 	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client/pkgclient/pkgutil"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/yaml"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 type Config struct {
-	Dir               string
+	Dir string
+	// PathTemplate is the go template of the file path, relative to Dir, an
+	// object that is not in the package yet is written to. The default
+	// layout writes every object to its own file.
+	PathTemplate      string
 	IgnoreAnnotations []string
 	IgnoreLabels      []string
 }
 
+// New returns a client of the package in the directory. The objects that are
+// created, updated or deleted are written back to the directory, only the
+// files of these objects are written such that the other files keep their
+// comments and ordering.
 func New(cfg Config) (provclient.Client, error) {
+	pathTemplate, err := newPathTemplate(cfg.PathTemplate)
+	if err != nil {
+		return nil, err
+	}
+	r := &pkgclient{
+		cfg:          cfg,
+		pathTemplate: pathTemplate,
+		resources:    map[v1.ObjectReference]client.Object{},
+		paths:        map[v1.ObjectReference]string{},
+		files:        map[string][]*yaml.RNode{},
+		// the kinds of the package are resolved from the CRDs in the package
+		mapper: newRESTMapper(),
+	}
+	// a package that does not exist yet is created by the first write
+	if _, err := os.Stat(cfg.Dir); errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
 	pb, err := pkgutil.GetPackage(cfg.Dir, "*.yaml")
 	if err != nil {
 		return nil, err
 	}
-
-	res := map[v1.ObjectReference]client.Object{}
 	for _, n := range pb.Nodes {
-		u := unstructured.Unstructured{}
-		if err := yaml.Unmarshal([]byte(n.MustString()), &u); err != nil {
+		path, _, err := kioutil.GetFileAnnotations(n)
+		if err != nil {
+			return nil, err
+		}
+		u, err := getObject(n)
+		if err != nil {
 			return nil, err
 		}
-		//fmt.Println(u.GetKind())
-		res[v1.ObjectReference{
-			APIVersion: u.GetAPIVersion(),
-			Kind:       u.GetKind(),
-			Name:       u.GetName(),
-			Namespace:  u.GetNamespace(),
-		}] = &u
-	}
-
-	return &pkgclient{
-		cfg:       cfg,
-		resources: res,
-	}, nil
+		if isCRD(u) {
+			if err := r.mapper.addCRD(u); err != nil {
+				return nil, err
+			}
+		}
+		ref := getRef(n)
+		r.resources[ref] = u
+		r.paths[ref] = path
+		r.files[path] = append(r.files[path], n)
+	}
+	return r, nil
 }
 
 type pkgclient struct {
-	cfg       Config
+	cfg          Config
+	pathTemplate *template.Template
+
+	m sync.RWMutex
+	// resources are the objects of the package
 	resources map[v1.ObjectReference]client.Object
+	// paths are the files of the objects and files are the documents of the
+	// files, in the order of the file
+	paths  map[v1.ObjectReference]string
+	files  map[string][]*yaml.RNode
+	mapper *restMapper
 }
 
 func (r *pkgclient) Get(ctx context.Context, key client.ObjectKey, o client.Object, opts ...client.GetOption) error {
+	if o == nil {
+		return fmt.Errorf("cannot get %s into a nil object", key.String())
+	}
+	r.m.RLock()
+	defer r.m.RUnlock()
 
 	objRef := v1.ObjectReference{
 		APIVersion: o.GetObjectKind().GroupVersionKind().GroupVersion().Identifier(),
@@ -62,10 +111,7 @@ func (r *pkgclient) Get(ctx context.Context, key client.ObjectKey, o client.Obje
 	}
 	u, ok := r.resources[objRef]
 	if !ok {
-		return fmt.Errorf("resource not found: %v", objRef.String())
-	}
-	if o == nil {
-		return fmt.Errorf("resource not found: %v", objRef.String())
+		return kerrors.NewNotFound(o.GetObjectKind().GroupVersionKind().GroupVersion().WithResource(objRef.Kind).GroupResource(), key.Name)
 	}
 	b, err := json.Marshal(u)
 	if err != nil {
@@ -75,19 +121,39 @@ func (r *pkgclient) Get(ctx context.Context, key client.ObjectKey, o client.Obje
 }
 
 func (r *pkgclient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
 	objref := v1.ObjectReference{
 		APIVersion: list.GetObjectKind().GroupVersionKind().GroupVersion().Identifier(),
-		Kind:       list.GetObjectKind().GroupVersionKind().Kind,
+		Kind:       strings.TrimSuffix(list.GetObjectKind().GroupVersionKind().Kind, "List"),
 	}
 	ul := unstructured.UnstructuredList{}
 	ul.SetAPIVersion(list.GetObjectKind().GroupVersionKind().GroupVersion().Identifier())
 	ul.SetKind(list.GetObjectKind().GroupVersionKind().Kind)
 	for ref, u := range r.resources {
-		if ref.APIVersion == objref.APIVersion && ref.Kind == objref.Kind {
-			ul.Items = append(ul.Items, *u.(*unstructured.Unstructured))
+		if ref.APIVersion != objref.APIVersion || ref.Kind != objref.Kind {
+			continue
+		}
+		if listOpts.Namespace != "" && ref.Namespace != listOpts.Namespace {
+			continue
 		}
+		if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(u.GetLabels())) {
+			continue
+		}
+		ul.Items = append(ul.Items, *u.(*unstructured.Unstructured))
 	}
-	b, err := json.Marshal(ul)
+	// the items are sorted such that the list is deterministic
+	sort.Slice(ul.Items, func(i, j int) bool {
+		if ul.Items[i].GetNamespace() != ul.Items[j].GetNamespace() {
+			return ul.Items[i].GetNamespace() < ul.Items[j].GetNamespace()
+		}
+		return ul.Items[i].GetName() < ul.Items[j].GetName()
+	})
+	b, err := json.Marshal(&ul)
 	if err != nil {
 		return err
 	}
@@ -101,8 +167,24 @@ func (r *pkgclient) Create(ctx context.Context, o client.Object, ao ...client.Cr
 		Namespace:  o.GetNamespace(),
 		Name:       o.GetName(),
 	}
-	r.resources[objRef] = o
-	return nil
+	u, err := toUnstructured(o)
+	if err != nil {
+		return err
+	}
+	// a CRD applied to the package makes its kind known
+	if isCRD(u) {
+		if err := r.mapper.addCRD(u); err != nil {
+			return err
+		}
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	path, err := r.setNode(objRef, u)
+	if err != nil {
+		return err
+	}
+	r.resources[objRef] = u
+	return r.writeFile(path)
 }
 
 func (r *pkgclient) Delete(ctx context.Context, o client.Object, ao ...client.DeleteOption) error {
@@ -112,8 +194,14 @@ func (r *pkgclient) Delete(ctx context.Context, o client.Object, ao ...client.De
 		Namespace:  o.GetNamespace(),
 		Name:       o.GetName(),
 	}
+	r.m.Lock()
+	defer r.m.Unlock()
 	delete(r.resources, objRef)
-	return nil
+	path, ok := r.deleteNode(objRef)
+	if !ok {
+		return nil
+	}
+	return r.writeFile(path)
 }
 
 func (r *pkgclient) Update(ctx context.Context, o client.Object, ao ...client.UpdateOption) error {
@@ -131,3 +219,25 @@ func (r *pkgclient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...
 func (r *pkgclient) Apply(ctx context.Context, o client.Object, ao ...provclient.ApplyOption) error {
 	return r.Create(ctx, o)
 }
+
+// Offline returns true, the package client writes the objects to a directory
+func (r *pkgclient) Offline() bool {
+	return true
+}
+
+// RESTMapper returns the mapper of the built-in kinds and the kinds of the
+// CRDs of the package
+func (r *pkgclient) RESTMapper() meta.RESTMapper {
+	return r.mapper
+}
+
+func toUnstructured(o client.Object) (*unstructured.Unstructured, error) {
+	if u, ok := o.(*unstructured.Unstructured); ok {
+		return u.DeepCopy(), nil
+	}
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: m}, nil
+}
@@ -0,0 +1,246 @@
+package pkgclient
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testApp = `# the app of the package
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: default
+data:
+  # the mode of the app
+  mode: dev
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-extra
+  namespace: default
+data:
+  a: b
+  replicas: 3
+`
+
+var testUntouched = `# untouched
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: default # the namespace
+`
+
+func getConfigMap(name string, data map[string]any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{"data": data}}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetName(name)
+	u.SetNamespace("default")
+	return u
+}
+
+func TestWrite(t *testing.T) {
+	cases := map[string]struct {
+		pathTemplate  string
+		apply         []*unstructured.Unstructured
+		delete        []*unstructured.Unstructured
+		expectedFiles map[string]string
+	}{
+		"Create": {
+			apply: []*unstructured.Unstructured{getConfigMap("new", map[string]any{"x": "y"})},
+			expectedFiles: map[string]string{
+				"app.yaml":       testApp,
+				"untouched.yaml": testUntouched,
+				"configmap_default_new.yaml": `apiVersion: v1
+data:
+  x: "y"
+kind: ConfigMap
+metadata:
+  name: new
+  namespace: default
+`,
+			},
+		},
+		"CreatePathTemplate": {
+			pathTemplate: "{{ .Namespace }}/{{ .Name }}.yaml",
+			apply:        []*unstructured.Unstructured{getConfigMap("new", map[string]any{"x": "y"})},
+			expectedFiles: map[string]string{
+				"app.yaml":       testApp,
+				"untouched.yaml": testUntouched,
+				"default/new.yaml": `apiVersion: v1
+data:
+  x: "y"
+kind: ConfigMap
+metadata:
+  name: new
+  namespace: default
+`,
+			},
+		},
+		"Update": {
+			apply: []*unstructured.Unstructured{getConfigMap("app", map[string]any{"mode": "prod"})},
+			expectedFiles: map[string]string{
+				"app.yaml": `# the app of the package
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: default
+data:
+  # the mode of the app
+  mode: prod
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-extra
+  namespace: default
+data:
+  a: b
+  replicas: 3
+`,
+				"untouched.yaml": testUntouched,
+			},
+		},
+		"Delete": {
+			delete: []*unstructured.Unstructured{getConfigMap("app-extra", nil)},
+			expectedFiles: map[string]string{
+				"app.yaml": `# the app of the package
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: default
+data:
+  # the mode of the app
+  mode: dev
+`,
+				"untouched.yaml": testUntouched,
+			},
+		},
+		"DeleteFile": {
+			apply:  []*unstructured.Unstructured{getConfigMap("new", map[string]any{"x": "y"})},
+			delete: []*unstructured.Unstructured{getConfigMap("new", nil)},
+			expectedFiles: map[string]string{
+				"app.yaml":       testApp,
+				"untouched.yaml": testUntouched,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(testApp), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "untouched.yaml"), []byte(testUntouched), 0644); err != nil {
+				t.Fatal(err)
+			}
+			c, err := New(Config{Dir: dir, PathTemplate: tc.pathTemplate})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, u := range tc.apply {
+				if err := c.Apply(ctx, u); err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+			}
+			for _, u := range tc.delete {
+				if err := c.Delete(ctx, u); err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+			}
+
+			files := map[string]string{}
+			if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				b, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(dir, path)
+				if err != nil {
+					return err
+				}
+				files[rel] = string(b)
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.expectedFiles, files)
+
+			if len(tc.delete) != 0 {
+				return
+			}
+			// a new client reads the objects that were written
+			c, err = New(Config{Dir: dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, u := range tc.apply {
+				got := &unstructured.Unstructured{}
+				got.SetGroupVersionKind(u.GroupVersionKind())
+				if err := c.Get(ctx, client.ObjectKeyFromObject(u), got); err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				assert.Equal(t, u.Object, got.Object)
+			}
+		})
+	}
+}
+
+func TestNewDirNotExist(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	c, err := New(Config{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Apply(context.Background(), getConfigMap("a", map[string]any{"x": "y"})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "configmap_default_a.yaml")); err != nil {
+		t.Errorf("want file, got: %s", err.Error())
+	}
+}
+
+func TestGetDeepCopy(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(testApp), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(Config{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "app-extra"}, u); err != nil {
+		t.Fatal(err)
+	}
+	// numbers are decoded as json numbers such that the object can be copied
+	assert.Equal(t, int64(3), u.DeepCopy().Object["data"].(map[string]any)["replicas"])
+	assert.Empty(t, u.GetAnnotations())
+}
+
+func TestGetNil(t *testing.T) {
+	c, err := New(Config{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "app"}, nil); err == nil {
+		t.Error("want error for a nil object")
+	}
+}
@@ -0,0 +1,212 @@
+package pkgclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var crdGVK = apiextv1.SchemeGroupVersion.WithKind("CustomResourceDefinition")
+
+// builtinScopes are the scopes of the built-in kinds
+var builtinScopes = sync.OnceValue(getBuiltinScopes)
+
+// getBuiltinScopes returns the scopes of the built-in kinds, derived from the
+// generated clientset: the client of a namespaced resource takes the
+// namespace, e.g. Pods(namespace), the client of a cluster scoped resource
+// does not, e.g. Namespaces(). The kind of a resource is the kind of the
+// object its client creates or gets.
+func getBuiltinScopes() map[schema.GroupKind]meta.RESTScope {
+	scopes := map[schema.GroupKind]meta.RESTScope{}
+	clientset := reflect.TypeOf((*kubernetes.Interface)(nil)).Elem()
+	for i := 0; i < clientset.NumMethod(); i++ {
+		groupClient := clientset.Method(i).Type
+		if groupClient.NumIn() != 0 || groupClient.NumOut() != 1 || groupClient.Out(0).Kind() != reflect.Interface {
+			continue
+		}
+		for j := 0; j < groupClient.Out(0).NumMethod(); j++ {
+			resourceClient := groupClient.Out(0).Method(j).Type
+			if resourceClient.NumOut() != 1 || resourceClient.Out(0).Kind() != reflect.Interface {
+				continue
+			}
+			obj := getClientObject(resourceClient.Out(0))
+			if obj == nil {
+				continue
+			}
+			gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+			if err != nil {
+				continue
+			}
+			scope := meta.RESTScopeNamespace
+			if resourceClient.NumIn() == 0 {
+				scope = meta.RESTScopeRoot
+			}
+			for _, gvk := range gvks {
+				scopes[gvk.GroupKind()] = scope
+			}
+		}
+	}
+	return scopes
+}
+
+// getClientObject returns a new object of the type the resource client
+// creates or gets, nil when the client has no such method
+func getClientObject(resourceClient reflect.Type) runtime.Object {
+	for _, name := range []string{"Create", "Get"} {
+		m, ok := resourceClient.MethodByName(name)
+		if !ok || m.Type.NumOut() != 2 || m.Type.Out(0).Kind() != reflect.Pointer {
+			continue
+		}
+		if obj, ok := reflect.New(m.Type.Out(0).Elem()).Interface().(runtime.Object); ok {
+			return obj
+		}
+	}
+	return nil
+}
+
+// restMapper resolves the kinds of a package, there is no api server to
+// discover the kinds from. The mapper knows the built-in kinds and the kinds
+// of the CRDs of the package, also the CRDs that are applied to the package.
+type restMapper struct {
+	m      sync.RWMutex
+	crds   map[string]*apiextv1.CustomResourceDefinition
+	mapper meta.RESTMapper
+}
+
+func newRESTMapper() *restMapper {
+	r := &restMapper{crds: map[string]*apiextv1.CustomResourceDefinition{}}
+	r.mapper = r.build()
+	return r
+}
+
+// isCRD returns true when the object is a CustomResourceDefinition
+func isCRD(u *unstructured.Unstructured) bool {
+	return u.GroupVersionKind() == crdGVK
+}
+
+// addCRD adds the kind of the CRD to the mapper
+func (r *restMapper) addCRD(u *unstructured.Unstructured) error {
+	b, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	crd := &apiextv1.CustomResourceDefinition{}
+	if err := json.Unmarshal(b, crd); err != nil {
+		return fmt.Errorf("invalid CustomResourceDefinition %s, err: %s", u.GetName(), err.Error())
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.crds[crd.Name] = crd
+	r.mapper = r.build()
+	return nil
+}
+
+// build returns a mapper with the built-in kinds and the kinds of the CRDs,
+// the preferred version of a CRD is its storage version. No lock is needed
+// since this is called from the constructor or with the lock held.
+func (r *restMapper) build() meta.RESTMapper {
+	gvs := []schema.GroupVersion{}
+	crdVersions := map[string][]apiextv1.CustomResourceDefinitionVersion{}
+	for name, crd := range r.crds {
+		versions := []apiextv1.CustomResourceDefinitionVersion{}
+		for _, v := range crd.Spec.Versions {
+			if v.Storage {
+				versions = append([]apiextv1.CustomResourceDefinitionVersion{v}, versions...)
+			} else if v.Served {
+				versions = append(versions, v)
+			}
+		}
+		for _, v := range versions {
+			gvs = append(gvs, schema.GroupVersion{Group: crd.Spec.Group, Version: v.Name})
+		}
+		crdVersions[name] = versions
+	}
+	gvs = append(gvs, scheme.Scheme.PrioritizedVersionsAllGroups()...)
+	gvs = append(gvs, apiextv1.SchemeGroupVersion)
+
+	mapper := meta.NewDefaultRESTMapper(gvs)
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if gvk.Version == "__internal" {
+			continue
+		}
+		mapper.Add(gvk, getScope(gvk.GroupKind()))
+	}
+	mapper.Add(crdGVK, meta.RESTScopeRoot)
+
+	for name, crd := range r.crds {
+		scope := meta.RESTScopeNamespace
+		if crd.Spec.Scope == apiextv1.ClusterScoped {
+			scope = meta.RESTScopeRoot
+		}
+		for _, v := range crdVersions[name] {
+			gv := schema.GroupVersion{Group: crd.Spec.Group, Version: v.Name}
+			mapper.AddSpecific(
+				gv.WithKind(crd.Spec.Names.Kind),
+				gv.WithResource(crd.Spec.Names.Plural),
+				gv.WithResource(crd.Spec.Names.Singular),
+				scope,
+			)
+		}
+	}
+	return mapper
+}
+
+// getScope returns the scope of a built-in kind, a kind without a client is
+// namespaced
+func getScope(gk schema.GroupKind) meta.RESTScope {
+	if scope, ok := builtinScopes()[gk]; ok {
+		return scope
+	}
+	return meta.RESTScopeNamespace
+}
+
+func (r *restMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.mapper.KindFor(resource)
+}
+
+func (r *restMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.mapper.KindsFor(resource)
+}
+
+func (r *restMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.mapper.ResourceFor(input)
+}
+
+func (r *restMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.mapper.ResourcesFor(input)
+}
+
+func (r *restMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.mapper.RESTMapping(gk, versions...)
+}
+
+func (r *restMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.mapper.RESTMappings(gk, versions...)
+}
+
+func (r *restMapper) ResourceSingularizer(resource string) (string, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.mapper.ResourceSingularizer(resource)
+}
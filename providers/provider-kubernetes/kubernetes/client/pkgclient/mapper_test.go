@@ -0,0 +1,55 @@
+package pkgclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetScope(t *testing.T) {
+	cases := map[string]struct {
+		gk   schema.GroupKind
+		want meta.RESTScopeName
+	}{
+		"Pod": {
+			gk:   schema.GroupKind{Kind: "Pod"},
+			want: meta.RESTScopeNameNamespace,
+		},
+		"Namespace": {
+			gk:   schema.GroupKind{Kind: "Namespace"},
+			want: meta.RESTScopeNameRoot,
+		},
+		"Deployment": {
+			gk:   schema.GroupKind{Group: "apps", Kind: "Deployment"},
+			want: meta.RESTScopeNameNamespace,
+		},
+		"ClusterRole": {
+			gk:   schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+			want: meta.RESTScopeNameRoot,
+		},
+		// a review is only created
+		"TokenReview": {
+			gk:   schema.GroupKind{Group: "authentication.k8s.io", Kind: "TokenReview"},
+			want: meta.RESTScopeNameRoot,
+		},
+		"LocalSubjectAccessReview": {
+			gk:   schema.GroupKind{Group: "authorization.k8s.io", Kind: "LocalSubjectAccessReview"},
+			want: meta.RESTScopeNameNamespace,
+		},
+		"ValidatingAdmissionPolicy": {
+			gk:   schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"},
+			want: meta.RESTScopeNameRoot,
+		},
+		"Unknown": {
+			gk:   schema.GroupKind{Group: "example.com", Kind: "Cluster"},
+			want: meta.RESTScopeNameNamespace,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, getScope(tc.gk).Name())
+		})
+	}
+}
@@ -62,6 +62,7 @@ func GetPackage(src string, m ...string) (*kio.PackageBuffer, error) {
 						kioutil.PathAnnotation: relPath,
 					},
 					DisableUnwrapping: true,
+					PreserveSeqIndent: true,
 				})
 			}
 		}
@@ -0,0 +1,200 @@
+package pkgclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/comments"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/order"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// DefaultPathTemplate is the layout of the objects that are added to a
+// package, every object is written to its own file
+const DefaultPathTemplate = `{{ lower .Kind }}{{ with .Namespace }}_{{ . }}{{ end }}_{{ .Name }}.yaml`
+
+// readerAnnotations are set by the kio reader, they are removed from the
+// objects the client returns and from the files the client writes
+var readerAnnotations = []string{
+	kioutil.PathAnnotation,
+	kioutil.IndexAnnotation,
+	kioutil.LegacyPathAnnotation,
+	kioutil.LegacyIndexAnnotation,
+	kioutil.SeqIndentAnnotation,
+	kioutil.IdAnnotation,
+	kioutil.LegacyIdAnnotation,
+}
+
+// pathTemplateData is the data of the path template of an object
+type pathTemplateData struct {
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func newPathTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultPathTemplate
+	}
+	t, err := template.New("path").Funcs(template.FuncMap{
+		"lower": strings.ToLower,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path template %q, err: %s", text, err.Error())
+	}
+	return t, nil
+}
+
+// getPath returns the file path of an object that is not in the package yet,
+// the path is relative to the directory of the package
+func (r *pkgclient) getPath(ref v1.ObjectReference) (string, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := r.pathTemplate.Execute(&buf, pathTemplateData{
+		Group:     gv.Group,
+		Version:   gv.Version,
+		Kind:      ref.Kind,
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+	}); err != nil {
+		return "", fmt.Errorf("cannot render path for %s, err: %s", ref.String(), err.Error())
+	}
+	path := filepath.Clean(buf.String())
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf("invalid path %q for %s, the path must be within the package", path, ref.String())
+	}
+	return path, nil
+}
+
+// setNode adds or replaces the document of an object in the file of the
+// object. A replaced document keeps its position in the file and the field
+// order and comments of the fields it still has. An added document is
+// appended to the file of the path template.
+func (r *pkgclient) setNode(ref v1.ObjectReference, u *unstructured.Unstructured) (string, error) {
+	node, err := yaml.FromMap(u.Object)
+	if err != nil {
+		return "", err
+	}
+	if path, ok := r.paths[ref]; ok {
+		for i, old := range r.files[path] {
+			if getRef(old) != ref {
+				continue
+			}
+			if err := order.SyncOrder(old, node); err != nil {
+				return "", err
+			}
+			if err := comments.CopyComments(old, node); err != nil {
+				return "", err
+			}
+			if err := copySeqIndent(old, node); err != nil {
+				return "", err
+			}
+			r.files[path][i] = node
+			return path, nil
+		}
+	}
+	path, err := r.getPath(ref)
+	if err != nil {
+		return "", err
+	}
+	r.files[path] = append(r.files[path], node)
+	r.paths[ref] = path
+	return path, nil
+}
+
+// deleteNode removes the document of an object from its file
+func (r *pkgclient) deleteNode(ref v1.ObjectReference) (string, bool) {
+	path, ok := r.paths[ref]
+	if !ok {
+		return "", false
+	}
+	delete(r.paths, ref)
+	nodes := []*yaml.RNode{}
+	for _, n := range r.files[path] {
+		if getRef(n) != ref {
+			nodes = append(nodes, n)
+		}
+	}
+	r.files[path] = nodes
+	return path, true
+}
+
+// writeFile writes the documents of a file to the directory of the package,
+// a file without documents is removed
+func (r *pkgclient) writeFile(path string) error {
+	fileName := filepath.Join(r.cfg.Dir, path)
+	nodes := r.files[path]
+	if len(nodes) == 0 {
+		delete(r.files, path)
+		if err := os.Remove(fileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := (kio.ByteWriter{
+		Writer:           &buf,
+		ClearAnnotations: readerAnnotations,
+	}).Write(nodes); err != nil {
+		return fmt.Errorf("cannot write %s, err: %s", path, err.Error())
+	}
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, buf.Bytes(), 0644)
+}
+
+// getObject returns the object of a document without the reader annotations
+func getObject(n *yaml.RNode) (*unstructured.Unstructured, error) {
+	n = n.Copy()
+	for _, a := range readerAnnotations {
+		if _, err := n.Pipe(yaml.ClearAnnotation(a)); err != nil {
+			return nil, err
+		}
+	}
+	if err := yaml.ClearEmptyAnnotations(n); err != nil {
+		return nil, err
+	}
+	b, err := n.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func getRef(n *yaml.RNode) v1.ObjectReference {
+	return v1.ObjectReference{
+		APIVersion: n.GetApiVersion(),
+		Kind:       n.GetKind(),
+		Namespace:  n.GetNamespace(),
+		Name:       n.GetName(),
+	}
+}
+
+// copySeqIndent keeps the sequence indentation of a replaced document
+func copySeqIndent(src, dst *yaml.RNode) error {
+	seqIndent, ok := src.GetAnnotations()[kioutil.SeqIndentAnnotation]
+	if !ok {
+		return nil
+	}
+	return dst.PipeE(yaml.SetAnnotation(kioutil.SeqIndentAnnotation, seqIndent))
+}
@@ -5,12 +5,10 @@ import (
 	"encoding/json"
 	"time"
 
-	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
 	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func dataSourceKubernetesManifest() *schema.Resource {
@@ -25,20 +23,18 @@ func dataSourceKubernetesManifest() *schema.Resource {
 }
 
 func dataSourceKubernetesManifestRead(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
-	client := meta.(client.Client)
+	c := meta.(client.Client)
 
 	u := &unstructured.Unstructured{}
 	if err := json.Unmarshal(d.GetObject(), u); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	nsn := types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}
-	if d.GetScope() != kfplugin1.Scope_CLUSTER {
-		nsn = types.NamespacedName{Name: u.GetName()}
+	scope, diags := resolveManifest(c, u)
+	if diags.HasError() {
+		return nil, diags
 	}
-	newu := &unstructured.Unstructured{}
-	newu.SetAPIVersion(u.GetAPIVersion())
-	newu.SetKind(u.GetKind())
-	if err := client.Get(ctx, nsn, newu); err != nil {
+	newu, err := getObject(ctx, c, scope, u)
+	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 	b, err := json.Marshal(newu)
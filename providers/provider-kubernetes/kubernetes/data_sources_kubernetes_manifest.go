@@ -10,6 +10,7 @@ import (
 	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
 	"github.com/henderiw/logger/log"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func dataSourcesKubernetesManifest() *schema.Resource {
@@ -35,14 +36,27 @@ func dataSourcesKubernetesManifestList(ctx context.Context, d *schema.ResourceOb
 		return nil, diag.FromErr(err)
 	}
 
+	if _, diags := getRESTMapping(client, getListItemGVK(ul.GroupVersionKind())); diags.HasError() {
+		return nil, diags
+	}
+
+	selector, err := d.GetSelector()
+	if err != nil {
+		return nil, diag.Errorf("invalid label selector, err: %s", err.Error())
+	}
+	opts := []ctrlclient.ListOption{ctrlclient.MatchingLabelsSelector{Selector: selector}}
+	if d.GetNamespace() != "" {
+		opts = append(opts, ctrlclient.InNamespace(d.GetNamespace()))
+	}
+
 	newul := unstructured.UnstructuredList{}
 	newul.SetAPIVersion(ul.GetAPIVersion())
 	newul.SetKind(ul.GetKind())
-	if err := client.List(ctx, &newul); err != nil {
+	if err := client.List(ctx, &newul, opts...); err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	b, err := json.Marshal(newul)
+	b, err := json.Marshal(&newul)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -0,0 +1,118 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client/pkgclient"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var testConfigMaps = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  namespace: default
+  labels:
+    app: nginx
+    tier: frontend
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+  namespace: default
+  labels:
+    app: redis
+    tier: backend
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: c
+  namespace: other
+  labels:
+    app: nginx
+`
+
+func TestDataSourcesKubernetesManifestList(t *testing.T) {
+	in := "In"
+	exists := "Exists"
+	key := "tier"
+
+	cases := map[string]struct {
+		labelSelector *kfplugin1.LabelSelector
+		namespace     string
+		expectedNames []string
+	}{
+		"All": {
+			expectedNames: []string{"a", "b", "c"},
+		},
+		"Namespace": {
+			namespace:     "default",
+			expectedNames: []string{"a", "b"},
+		},
+		"MatchLabels": {
+			labelSelector: &kfplugin1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+			expectedNames: []string{"a", "c"},
+		},
+		"MatchLabelsNamespace": {
+			labelSelector: &kfplugin1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+			namespace:     "other",
+			expectedNames: []string{"c"},
+		},
+		"MatchExpressionsIn": {
+			labelSelector: &kfplugin1.LabelSelector{MatchExpressions: []*kfplugin1.LabelSelectorRequirement{
+				{Key: &key, Operator: &in, Values: []string{"backend"}},
+			}},
+			expectedNames: []string{"b"},
+		},
+		"MatchExpressionsExists": {
+			labelSelector: &kfplugin1.LabelSelector{MatchExpressions: []*kfplugin1.LabelSelectorRequirement{
+				{Key: &key, Operator: &exists},
+			}},
+			expectedNames: []string{"a", "b"},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "configmaps.yaml"), []byte(testConfigMaps), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := pkgclient.New(pkgclient.Config{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(map[string]any{"apiVersion": "v1", "kind": "ConfigMapList"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, diags := dataSourcesKubernetesManifestList(context.Background(), &schema.ResourceObject{
+				Obj:           b,
+				LabelSelector: tc.labelSelector,
+				Namespace:     tc.namespace,
+			}, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			ul := unstructured.UnstructuredList{}
+			if err := json.Unmarshal(b, &ul); err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, u := range ul.Items {
+				names = append(names, u.GetName())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
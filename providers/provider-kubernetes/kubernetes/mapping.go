@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"strings"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resolveManifest validates the kind of the manifest with the RESTMapper of
+// the client and returns the scope of the kind. The namespace of a cluster
+// scoped object is removed, such that the object is identified by its name.
+// The manifest is validated before any object is read or changed, an unknown
+// kind fails the operation with a diagnostic on the kind of the manifest.
+func resolveManifest(c client.Client, u *unstructured.Unstructured) (kfplugin1.Scope, diag.Diagnostics) {
+	mapping, diags := getRESTMapping(c, u.GroupVersionKind())
+	if diags.HasError() {
+		return kfplugin1.Scope_INVALID, diags
+	}
+	scope := getScope(mapping)
+	if scope == kfplugin1.Scope_CLUSTER {
+		u.SetNamespace("")
+	}
+	return scope, nil
+}
+
+// getRESTMapping returns the mapping of the kind in the version of the gvk.
+// When the kind is not served in the version the diagnostic reports the
+// preferred version of the kind.
+func getRESTMapping(c client.Client, gvk schema.GroupVersionKind) (*meta.RESTMapping, diag.Diagnostics) {
+	if gvk.Kind == "" {
+		return nil, diag.Diagnostics{diag.DiagErrorfWithPath(diag.NewAttributePath("kind"), "kind is required").Get()}
+	}
+	mapper := c.RESTMapper()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err == nil {
+		return mapping, nil
+	}
+	if !meta.IsNoMatchError(err) {
+		return nil, diag.FromErr(err)
+	}
+	preferred, err := mapper.RESTMapping(gvk.GroupKind())
+	if err != nil {
+		return nil, diag.Diagnostics{diag.DiagErrorfWithPath(diag.NewAttributePath("kind"),
+			"unknown kind %s in apiVersion %s", gvk.Kind, gvk.GroupVersion().Identifier(),
+		).WithSummary("unknown kind").Get()}
+	}
+	return nil, diag.Diagnostics{diag.DiagErrorfWithPath(diag.NewAttributePath("apiVersion"),
+		"kind %s is not served in apiVersion %s, the preferred apiVersion is %s",
+		gvk.Kind, gvk.GroupVersion().Identifier(), preferred.GroupVersionKind.GroupVersion().Identifier(),
+	).WithSummary("unknown kind").Get()}
+}
+
+func getScope(mapping *meta.RESTMapping) kfplugin1.Scope {
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return kfplugin1.Scope_CLUSTER
+	}
+	return kfplugin1.Scope_NAMESPACE
+}
+
+// getListItemGVK returns the gvk of the items of a list, e.g. ConfigMapList
+// lists ConfigMap objects
+func getListItemGVK(gvk schema.GroupVersionKind) schema.GroupVersionKind {
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	return gvk
+}
@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client/pkgclient"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var testCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: clusters.example.com
+spec:
+  group: example.com
+  names:
+    kind: Cluster
+    plural: clusters
+    singular: cluster
+  scope: Cluster
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: false
+  - name: v1beta1
+    served: true
+    storage: true
+`
+
+func TestResolveManifest(t *testing.T) {
+	cases := map[string]struct {
+		apiVersion      string
+		kind            string
+		namespace       string
+		expectedScope   kfplugin1.Scope
+		expectedPointer string
+	}{
+		"Namespaced": {
+			apiVersion:    "apps/v1",
+			kind:          "Deployment",
+			namespace:     "default",
+			expectedScope: kfplugin1.Scope_NAMESPACE,
+		},
+		"Cluster": {
+			apiVersion:    "v1",
+			kind:          "Namespace",
+			namespace:     "default",
+			expectedScope: kfplugin1.Scope_CLUSTER,
+		},
+		"CRD": {
+			apiVersion:    "example.com/v1beta1",
+			kind:          "Cluster",
+			namespace:     "default",
+			expectedScope: kfplugin1.Scope_CLUSTER,
+		},
+		"CRDVersionNotServed": {
+			apiVersion:      "example.com/v1",
+			kind:            "Cluster",
+			expectedPointer: "/apiVersion",
+		},
+		"UnknownKind": {
+			apiVersion:      "example.com/v1beta1",
+			kind:            "Network",
+			expectedPointer: "/kind",
+		},
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "crd.yaml"), []byte(testCRD), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := pkgclient.New(pkgclient.Config{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion(tc.apiVersion)
+			u.SetKind(tc.kind)
+			u.SetName("a")
+			u.SetNamespace(tc.namespace)
+
+			scope, diags := resolveManifest(c, u)
+			if tc.expectedPointer != "" {
+				if !diags.HasError() {
+					t.Fatalf("want error on %s, got none", tc.expectedPointer)
+				}
+				assert.Equal(t, tc.expectedPointer, diag.AttributePathToPointer(diags[0].Attribute))
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			assert.Equal(t, tc.expectedScope, scope)
+			if scope == kfplugin1.Scope_CLUSTER {
+				assert.Equal(t, "", u.GetNamespace())
+			}
+		})
+	}
+}
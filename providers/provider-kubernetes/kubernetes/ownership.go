@@ -0,0 +1,125 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// ManagedByLabel marks the objects managed by kform
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "kform"
+	// ModuleAnnotation is the kform module of the resource managing the object
+	ModuleAnnotation = "kform.io/module"
+	// ResourceAddressAnnotation is the address of the resource managing the
+	// object, e.g. example.kubernetes_manifest.cm[0]
+	ResourceAddressAnnotation = "kform.io/resource-address"
+	// RunIDAnnotation is the id of the kform run that last changed the
+	// manifest of the object
+	RunIDAnnotation = "kform.io/run-id"
+)
+
+// adoptKey is the key in the manifest that allows kform to adopt an existing
+// object that is not managed by kform, the key is removed from the manifest
+// before the object is applied.
+const adoptKey = "adopt"
+
+// setOwner stamps the owner on the object, nothing is stamped when kform did
+// not pass an owner
+func setOwner(u *unstructured.Unstructured, owner kfplugin.Owner) {
+	if owner.Address == "" {
+		return
+	}
+	labels := u.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ManagedByLabel] = ManagedByValue
+	u.SetLabels(labels)
+
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ModuleAnnotation] = owner.Module
+	annotations[ResourceAddressAnnotation] = owner.Address
+	if owner.RunID != "" {
+		annotations[RunIDAnnotation] = owner.RunID
+	}
+	u.SetAnnotations(annotations)
+}
+
+// checkOwner returns an error when the existing object is not owned by the
+// owner, kform does not delete objects owned by someone else and only adopts
+// an object that is not managed by kform when adopt is set. The check is
+// skipped when kform did not pass an owner.
+func checkOwner(existing *unstructured.Unstructured, owner kfplugin.Owner, adopt bool) error {
+	if owner.Address == "" {
+		return nil
+	}
+	address := existing.GetAnnotations()[ResourceAddressAnnotation]
+	if existing.GetLabels()[ManagedByLabel] != ManagedByValue || address == "" {
+		if adopt {
+			return nil
+		}
+		return fmt.Errorf("%s %s exists and is not managed by kform, refusing to adopt it; set %s to true in the manifest to adopt it",
+			existing.GetKind(), getObjectName(existing), adoptKey)
+	}
+	if address != owner.Address {
+		return fmt.Errorf("%s %s is owned by %s, refusing to change it from %s",
+			existing.GetKind(), getObjectName(existing), address, owner.Address)
+	}
+	return nil
+}
+
+// getAdopt returns true when the manifest allows to adopt an existing object
+// and removes the adopt key from the manifest
+func getAdopt(u *unstructured.Unstructured) (bool, error) {
+	v, ok := u.Object[adoptKey]
+	if !ok {
+		return false, nil
+	}
+	delete(u.Object, adoptKey)
+	adopt, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("invalid %s, expected a bool, got: %v", adoptKey, v)
+	}
+	return adopt, nil
+}
+
+// getRunID returns the run id stamped on the object, the run id of an
+// existing object is kept when its manifest did not change since the previous
+// run, such that an unchanged object is not modified by every run
+func getRunID(existing *unstructured.Unstructured, owner kfplugin.Owner, obj, oldObj []byte) string {
+	runID, ok := existing.GetAnnotations()[RunIDAnnotation]
+	if !ok || !isSameManifest(obj, oldObj) {
+		return owner.RunID
+	}
+	return runID
+}
+
+// isSameManifest returns true when both json manifests are equal
+func isSameManifest(a, b []byte) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	var av, bv any
+	if err := json.Unmarshal(a, &av); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &bv); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+func getObjectName(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return u.GetName()
+	}
+	return fmt.Sprintf("%s/%s", u.GetNamespace(), u.GetName())
+}
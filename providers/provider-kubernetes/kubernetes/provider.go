@@ -11,6 +11,7 @@ import (
 
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/crd"
 	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/api/v1alpha1"
 	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client/k8sclient"
 	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client/pkgclient"
@@ -22,11 +23,12 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 func Provider() *schema.Provider {
 	p := &schema.Provider{
-		//Schema:         provSchema,
+		Schema: providerConfigSchema(),
 		ResourceMap: map[string]*schema.Resource{
 			"kubernetes_manifest": resourceKubernetesManifest(),
 		},
@@ -60,6 +62,17 @@ func (k kubeClientsets) MainClientset() (*kubernetes.Clientset, error) {
 }
 */
 
+// providerConfigSchema returns the schema of the provider config from the
+// generated custom resource definition
+func providerConfigSchema() *spec.Schema {
+	s, err := schema.NewSchemaFromCRD(crd.ProviderConfig)
+	if err != nil {
+		slog.Error("invalid provider config schema", "err", err)
+		return nil
+	}
+	return s
+}
+
 func providerConfigure(ctx context.Context, d []byte, version string) (any, diag.Diagnostics) {
 	/*
 		b, err := yaml.Marshal(d)
@@ -75,6 +88,10 @@ func providerConfigure(ctx context.Context, d []byte, version string) (any, diag
 	if !providerConfig.Spec.IsKindValid() {
 		return nil, diag.Errorf("invalid provider kind, got: %s, expected: %v", providerConfig.Kind, v1alpha1.ExpectedProviderKinds)
 	}
+	deletePropagation, err := providerConfig.Spec.GetDeletePropagation()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
 
 	if providerConfig.Spec.Kind == v1alpha1.ProviderKindPackage {
 		dir := "./out"
@@ -84,6 +101,7 @@ func providerConfigure(ctx context.Context, d []byte, version string) (any, diag
 
 		c, err := pkgclient.New(pkgclient.Config{
 			Dir:               dir,
+			PathTemplate:      providerConfig.Spec.GetPathTemplate(),
 			IgnoreAnnotations: []string{},
 			IgnoreLabels:      []string{},
 		})
@@ -108,6 +126,8 @@ func providerConfigure(ctx context.Context, d []byte, version string) (any, diag
 		RESTCOnfig:        cfg,
 		IgnoreAnnotations: []string{},
 		IgnoreLabels:      []string{},
+		FieldManager:      providerConfig.Spec.GetFieldManager(),
+		DeletePropagation: deletePropagation,
 	})
 	if err != nil {
 		return nil, diag.FromErr(err)
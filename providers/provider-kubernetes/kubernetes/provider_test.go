@@ -0,0 +1,15 @@
+package kubernetes
+
+import (
+	"testing"
+)
+
+func TestProviderConfigSchema(t *testing.T) {
+	s := Provider().Schema
+	if s == nil {
+		t.Fatal("want provider config schema, got nil")
+	}
+	if _, ok := s.Properties["spec"]; !ok {
+		t.Errorf("want spec in provider config schema, got: %v", s.Properties)
+	}
+}
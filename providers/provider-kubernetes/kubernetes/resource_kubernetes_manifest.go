@@ -3,69 +3,194 @@ package kubernetes
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/api/v1alpha1"
 	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// forceConflictsKey is the key in the manifest that forces the server-side
+// apply when fields are owned by other field managers, the key is removed
+// from the manifest before the object is applied.
+const forceConflictsKey = "forceConflicts"
+
+// DeletePropagationAnnotation overrides the propagation policy of the
+// provider config when the object is deleted, e.g. Foreground. The
+// annotation is not applied to the object.
+const DeletePropagationAnnotation = "kubernetes.provider.kform.io/delete-propagation"
+
 func resourceKubernetesManifest() *schema.Resource {
 	defaultTimout := 5 * time.Minute
 	return &schema.Resource{
-		CreateContext: resourceKubernetesManifestCreate,
-		ReadContext:   resourceKubernetesManifestRead,
+		ValidateContext: resourceKubernetesManifestValidate,
+		CreateContext:   resourceKubernetesManifestCreate,
+		UpdateContext:   resourceKubernetesManifestUpdate,
+		DeleteContext:   resourceKubernetesManifestDelete,
+		ReadContext:     resourceKubernetesManifestRead,
 		Timeouts: &schema.ResourceTimeout{
 			Create:  &defaultTimout,
+			Update:  &defaultTimout,
+			Delete:  &defaultTimout,
 			Read:    &defaultTimout,
 			Default: &defaultTimout,
 		},
 	}
 }
 
+// resourceKubernetesManifestValidate resolves the kind of the manifest through
+// the RESTMapper of the client and validates the keys that configure the
+// apply, before any object is read or changed
+func resourceKubernetesManifestValidate(ctx context.Context, d *schema.ResourceObject, meta interface{}) diag.Diagnostics {
+	c := meta.(client.Client)
+
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, diags := resolveManifest(c, u); diags.HasError() {
+		return diags
+	}
+	if _, err := getWaitFor(u); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := getApplyOptions(u); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := getAdopt(u); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := getDeleteOptions(u); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
 func resourceKubernetesManifestCreate(ctx context.Context, newObj *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
-	// TODO distinguish between offline and api
-	client := meta.(client.Client)
+	return applyManifest(ctx, newObj.GetObject(), nil, meta)
+}
+
+func resourceKubernetesManifestUpdate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	c := meta.(client.Client)
+
+	b, diags := applyManifest(ctx, d.GetObject(), d.GetOldObject(), meta)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if len(d.GetOldObject()) == 0 {
+		return b, diags
+	}
+	// the old object is deleted when the new manifest identifies another
+	// object, e.g. the object was renamed
+	oldu := &unstructured.Unstructured{}
+	if err := json.Unmarshal(d.GetOldObject(), oldu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	newu := &unstructured.Unstructured{}
+	if err := json.Unmarshal(b, newu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	oldScope, diags := resolveManifest(c, oldu)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if isSameObject(oldu, newu) {
+		return b, diags
+	}
+	opts, err := getDeleteOptions(oldu)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if diags := deleteObject(ctx, c, oldScope, oldu, opts...); diags.HasError() {
+		return nil, diags
+	}
+	return b, diags
+}
+
+func resourceKubernetesManifestDelete(ctx context.Context, d *schema.ResourceObject, meta interface{}) diag.Diagnostics {
+	c := meta.(client.Client)
 
 	u := &unstructured.Unstructured{}
-	if err := json.Unmarshal(newObj.GetObject(), u); err != nil {
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return diag.FromErr(err)
+	}
+	scope, diags := resolveManifest(c, u)
+	if diags.HasError() {
+		return diags
+	}
+	opts, err := getDeleteOptions(u)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return deleteObject(ctx, c, scope, u, opts...)
+}
+
+// applyManifest applies the manifest and waits for the object to be settled
+// when the manifest has a waitFor section. The owner passed by kform is
+// stamped on the object, an existing object owned by someone else is not
+// adopted. The old manifest is the manifest of the previous run, nil when the
+// object is created.
+func applyManifest(ctx context.Context, obj, oldObj []byte, meta interface{}) ([]byte, diag.Diagnostics) {
+	c := meta.(client.Client)
+
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(obj, u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	scope, diags := resolveManifest(c, u)
+	if diags.HasError() {
+		return nil, diags
+	}
+	waiter, err := getWaitFor(u)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if _, err := getDeleteOptions(u); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	applyOpts, err := getApplyOptions(u)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	adopt, err := getAdopt(u)
+	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	// TODO
-	/*
-		stateConf := &resource.StateChangeConf{
-			Target:  expandPodTargetState(d.Get("target_state").([]interface{})),
-			Pending: []string{string(corev1.PodPending)},
-			Timeout: d.Timeout(schema.TimeoutCreate),
-			Refresh: func() (interface{}, string, error) {
-				out, err := conn.CoreV1().Pods(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
-				if err != nil {
-					log.Printf("[ERROR] Received error: %#v", err)
-					return out, "Error", err
-				}
-
-				statusPhase := fmt.Sprintf("%v", out.Status.Phase)
-				log.Printf("[DEBUG] Pods %s status received: %#v", out.Name, statusPhase)
-				return out, statusPhase, nil
-			},
-		}
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			lastWarnings, wErr := getLastWarningsForObject(ctx, conn, out.ObjectMeta, "Pod", 3)
-			if wErr != nil {
-				return diag.FromErr(wErr)
+	owner := kfplugin.GetOwner(ctx)
+	if u.GetName() != "" {
+		existing, err := getObject(ctx, c, scope, u)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, diag.FromErr(err)
+		}
+		if err == nil {
+			if err := checkOwner(existing, owner, adopt); err != nil {
+				return nil, diag.FromErr(err)
 			}
-			return diag.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+			owner.RunID = getRunID(existing, owner, obj, oldObj)
 		}
-		log.Printf("[INFO] Pod %s created", out.Name)
-	*/
+	}
+	setOwner(u, owner)
 
-	if err := client.Apply(ctx, u); err != nil {
-		return nil, diag.FromErr(err)
+	if err := c.Apply(ctx, u, applyOpts...); err != nil {
+		return nil, applyDiagnostics(u, err)
+	}
+	// an offline client renders the objects, no controller settles them
+	if waiter != nil && !client.IsOffline(c) {
+		if diags := waiter.wait(ctx, c, scope, u); diags.HasError() {
+			return nil, diags
+		}
 	}
 
 	b, err := json.Marshal(u)
@@ -73,22 +198,52 @@ func resourceKubernetesManifestCreate(ctx context.Context, newObj *schema.Resour
 		return nil, diag.FromErr(err)
 	}
 
-	return resourceKubernetesManifestRead(ctx, &schema.ResourceObject{Scope: newObj.GetScope(), Obj: b}, meta)
+	return resourceKubernetesManifestRead(ctx, &schema.ResourceObject{Obj: b}, meta)
+}
+
+// deleteObject deletes the object and waits until it is gone, such that the
+// finalizers of the object finished. An object owned by someone else is not
+// deleted.
+func deleteObject(ctx context.Context, c client.Client, scope kfplugin1.Scope, u *unstructured.Unstructured, opts ...ctrlclient.DeleteOption) diag.Diagnostics {
+	existing, err := getObject(ctx, c, scope, u)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if err := checkOwner(existing, kfplugin.GetOwner(ctx), false); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.Delete(ctx, existing, opts...); err != nil && !kerrors.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if client.IsOffline(c) {
+		return nil
+	}
+	return waitForDeletion(ctx, c, scope, existing)
+}
+
+// isSameObject returns true when both manifests identify the same object
+func isSameObject(a, b *unstructured.Unstructured) bool {
+	return a.GroupVersionKind().GroupKind() == b.GroupVersionKind().GroupKind() &&
+		a.GetNamespace() == b.GetNamespace() &&
+		a.GetName() == b.GetName()
 }
 
 func resourceKubernetesManifestRead(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
-	client := meta.(client.Client)
+	c := meta.(client.Client)
 
 	u := &unstructured.Unstructured{}
 	if err := json.Unmarshal(d.GetObject(), u); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	nsn := types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}
-	if d.GetScope() == kfplugin1.Scope_CLUSTER {
-		nsn = types.NamespacedName{Name: u.GetName()}
+	scope, diags := resolveManifest(c, u)
+	if diags.HasError() {
+		return nil, diags
 	}
-	newu := &unstructured.Unstructured{}
-	if err := client.Get(ctx, nsn, newu); err != nil {
+	newu, err := getObject(ctx, c, scope, u)
+	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 	b, err := json.Marshal(newu)
@@ -98,4 +253,227 @@ func resourceKubernetesManifestRead(ctx context.Context, d *schema.ResourceObjec
 	return b, nil
 }
 
+// getApplyOptions returns the apply options of the manifest and removes the
+// forceConflicts key from the manifest
+func getApplyOptions(u *unstructured.Unstructured) ([]client.ApplyOption, error) {
+	v, ok := u.Object[forceConflictsKey]
+	if !ok {
+		return nil, nil
+	}
+	delete(u.Object, forceConflictsKey)
+	force, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s, expected a bool, got: %v", forceConflictsKey, v)
+	}
+	return []client.ApplyOption{client.WithForceConflicts(force)}, nil
+}
+
+// getDeleteOptions returns the delete options of the manifest and removes the
+// annotations that configure the delete from the manifest
+func getDeleteOptions(u *unstructured.Unstructured) ([]ctrlclient.DeleteOption, error) {
+	annotations := u.GetAnnotations()
+	v, ok := annotations[DeletePropagationAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	delete(annotations, DeletePropagationAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	u.SetAnnotations(annotations)
+	policy := metav1.DeletionPropagation(v)
+	if !v1alpha1.IsDeletePropagationValid(policy) {
+		return nil, fmt.Errorf("invalid %s annotation, got: %s, expected: %v", DeletePropagationAnnotation, v, v1alpha1.ExpectedDeletePropagations)
+	}
+	return []ctrlclient.DeleteOption{ctrlclient.PropagationPolicy(policy)}, nil
+}
+
+// applyDiagnostics returns the diagnostics of a failed apply of the manifest,
+// a conflict returns a diagnostic per field that is owned by another field
+// manager
+func applyDiagnostics(u *unstructured.Unstructured, err error) diag.Diagnostics {
+	var statusErr kerrors.APIStatus
+	if !kerrors.IsConflict(err) || !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return diag.FromErr(err)
+	}
+	diags := diag.Diagnostics{}
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		diags = append(diags, diag.DiagErrorfWithPath(
+			diag.NewAttributePath(getFieldPathSteps(u.Object, cause.Field)...),
+			"%s; the field is owned by another field manager, set %s to true in the manifest to take ownership",
+			cause.Message, forceConflictsKey,
+		).WithSummary("server-side apply conflict").Get())
+	}
+	if len(diags) == 0 {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getFieldPathSteps returns the steps of a managed fields path in the object,
+// e.g. .spec.containers[name="nginx"].image returns spec, containers, 0, image
+// when nginx is the first container. The key and value selectors of a list
+// are resolved to the index of the matching entry in the object and a field
+// name is matched against the keys of the object, as a name can contain a dot.
+// The steps are returned up to the first element that cannot be resolved.
+func getFieldPathSteps(obj map[string]any, field string) []string {
+	steps := []string{}
+	var cur any = obj
+	for field != "" {
+		switch field[0] {
+		case '.':
+			name := getFieldName(cur, field[1:])
+			if name == "" {
+				return steps
+			}
+			steps = append(steps, name)
+			field = field[1+len(name):]
+			m, _ := cur.(map[string]any)
+			cur = m[name]
+		case '[':
+			end := getSelectorEnd(field)
+			if end < 0 {
+				return steps
+			}
+			l, _ := cur.([]any)
+			idx, ok := getListIndex(l, field[1:end])
+			if !ok {
+				return steps
+			}
+			steps = append(steps, strconv.Itoa(idx))
+			field = field[end+1:]
+			cur = l[idx]
+		default:
+			return steps
+		}
+	}
+	return steps
+}
+
+// getFieldName returns the field name at the start of the path, the longest
+// key of the object that is followed by the next element is preferred over
+// the name up to the next dot.
+func getFieldName(cur any, path string) string {
+	isEnd := func(i int) bool {
+		return i == len(path) || path[i] == '.' || path[i] == '['
+	}
+	name := ""
+	m, _ := cur.(map[string]any)
+	for k := range m {
+		if len(k) > len(name) && strings.HasPrefix(path, k) && isEnd(len(k)) {
+			name = k
+		}
+	}
+	if name != "" {
+		return name
+	}
+	i := strings.IndexAny(path, ".[")
+	if i < 0 {
+		return path
+	}
+	return path[:i]
+}
+
+// getSelectorEnd returns the index of the bracket that closes the selector at
+// the start of the path, brackets in quoted values are skipped
+func getSelectorEnd(path string) int {
+	quoted := false
+	for i := 1; i < len(path); i++ {
+		switch {
+		case quoted && path[i] == '\\':
+			i++
+		case path[i] == '"':
+			quoted = !quoted
+		case !quoted && path[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
 
+// getListIndex returns the index of the list entry that matches the selector,
+// the selector is an index, e.g. 0, a value, e.g. ="a", or a list of keys with
+// their values, e.g. containerPort=80,protocol="TCP"
+func getListIndex(l []any, selector string) (int, bool) {
+	if idx, err := strconv.Atoi(selector); err == nil {
+		return idx, idx >= 0 && idx < len(l)
+	}
+	if strings.HasPrefix(selector, "=") {
+		var v any
+		if err := json.Unmarshal([]byte(selector[1:]), &v); err != nil {
+			return 0, false
+		}
+		for i, item := range l {
+			if isEqualJSON(item, v) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	keys := map[string]any{}
+	for _, kv := range splitSelector(selector) {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return 0, false
+		}
+		var value any
+		if err := json.Unmarshal([]byte(v), &value); err != nil {
+			return 0, false
+		}
+		keys[k] = value
+	}
+	for i, item := range l {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		match := true
+		for k, v := range keys {
+			if !isEqualJSON(m[k], v) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// splitSelector splits the keys of a selector on the commas that are not in
+// a quoted value
+func splitSelector(selector string) []string {
+	parts := []string{}
+	quoted := false
+	start := 0
+	for i := 0; i < len(selector); i++ {
+		switch {
+		case quoted && selector[i] == '\\':
+			i++
+		case selector[i] == '"':
+			quoted = !quoted
+		case !quoted && selector[i] == ',':
+			parts = append(parts, selector[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+// isEqualJSON compares the values by their json encoding, such that the
+// numbers of the object and the selector compare equal
+func isEqualJSON(a, b any) bool {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ab) == string(bb)
+}
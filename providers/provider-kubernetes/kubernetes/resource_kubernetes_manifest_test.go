@@ -0,0 +1,479 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	provclient "github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client/k8sclient"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var testDeployment = `{
+	"apiVersion": "apps/v1",
+	"kind": "Deployment",
+	"metadata": {
+		"name": "nginx",
+		"annotations": {"app.kubernetes.io/name": "nginx", "a~b": "c"}
+	},
+	"spec": {
+		"replicas": 1,
+		"template": {
+			"spec": {
+				"containers": [
+					{"name": "sidecar", "image": "busybox"},
+					{
+						"name": "nginx",
+						"image": "nginx:1.14.2",
+						"ports": [
+							{"containerPort": 80, "protocol": "UDP"},
+							{"containerPort": 80, "protocol": "TCP"}
+						],
+						"args": ["a", "b"]
+					}
+				]
+			}
+		}
+	}
+}`
+
+func TestApplyDiagnostics(t *testing.T) {
+	cases := map[string]struct {
+		err              error
+		expectedDetails  []string
+		expectedPointers []string
+	}{
+		"Conflict": {
+			err: kerrors.NewApplyConflict([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kube-controller-manager" using apps/v1`,
+					Field:   ".spec.replicas",
+				},
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl" using apps/v1`,
+					Field:   `.spec.template.spec.containers[name="nginx"].image`,
+				},
+			}, "Apply failed with 2 conflicts"),
+			expectedDetails: []string{
+				`conflict with "kube-controller-manager" using apps/v1; the field is owned by another field manager, set forceConflicts to true in the manifest to take ownership`,
+				`conflict with "kubectl" using apps/v1; the field is owned by another field manager, set forceConflicts to true in the manifest to take ownership`,
+			},
+			expectedPointers: []string{
+				"/spec/replicas",
+				"/spec/template/spec/containers/1/image",
+			},
+		},
+		"OtherError": {
+			err:              fmt.Errorf("cannot apply object: connection refused"),
+			expectedDetails:  []string{"cannot apply object: connection refused"},
+			expectedPointers: []string{""},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(testDeployment), u); err != nil {
+				t.Fatal(err)
+			}
+			diags := applyDiagnostics(u, tc.err)
+			details := []string{}
+			pointers := []string{}
+			for _, d := range diags {
+				details = append(details, d.Detail)
+				pointers = append(pointers, diag.AttributePathToPointer(d.Attribute))
+			}
+			assert.Equal(t, tc.expectedDetails, details)
+			assert.Equal(t, tc.expectedPointers, pointers)
+			assert.True(t, diags.HasError())
+		})
+	}
+}
+
+func TestGetFieldPathSteps(t *testing.T) {
+	cases := map[string]struct {
+		field   string
+		pointer string
+	}{
+		"Field": {
+			field:   ".spec.replicas",
+			pointer: "/spec/replicas",
+		},
+		"KeySelector": {
+			field:   `.spec.template.spec.containers[name="nginx"].image`,
+			pointer: "/spec/template/spec/containers/1/image",
+		},
+		"MultiKeySelector": {
+			field:   `.spec.template.spec.containers[name="nginx"].ports[containerPort=80,protocol="TCP"].protocol`,
+			pointer: "/spec/template/spec/containers/1/ports/1/protocol",
+		},
+		"ValueSelector": {
+			field:   `.spec.template.spec.containers[name="nginx"].args[="b"]`,
+			pointer: "/spec/template/spec/containers/1/args/1",
+		},
+		"IndexSelector": {
+			field:   ".spec.template.spec.containers[0].image",
+			pointer: "/spec/template/spec/containers/0/image",
+		},
+		"Slash": {
+			field:   ".metadata.annotations.app.kubernetes.io/name",
+			pointer: "/metadata/annotations/app.kubernetes.io~1name",
+		},
+		"Tilde": {
+			field:   ".metadata.annotations.a~b",
+			pointer: "/metadata/annotations/a~0b",
+		},
+		// the path stops at the selector that does not match an entry
+		"UnknownEntry": {
+			field:   `.spec.template.spec.containers[name="unknown"].image`,
+			pointer: "/spec/template/spec/containers",
+		},
+		"UnknownField": {
+			field:   ".spec.strategy.type",
+			pointer: "/spec/strategy/type",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := map[string]any{}
+			if err := json.Unmarshal([]byte(testDeployment), &obj); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.pointer, diag.AttributePathToPointer(diag.NewAttributePath(getFieldPathSteps(obj, tc.field)...)))
+		})
+	}
+}
+
+func TestGetApplyOptions(t *testing.T) {
+	cases := map[string]struct {
+		forceConflicts any
+		wantForce      bool
+		expectErr      bool
+	}{
+		"NotSet": {},
+		"Force": {
+			forceConflicts: true,
+			wantForce:      true,
+		},
+		"NoForce": {
+			forceConflicts: false,
+		},
+		"Invalid": {
+			forceConflicts: "yes",
+			expectErr:      true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := getConfigMap("a", "")
+			if tc.forceConflicts != nil {
+				u.Object[forceConflictsKey] = tc.forceConflicts
+			}
+			opts, err := getApplyOptions(u)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantForce, provclient.GetApplyOptions("kform", opts...).ForceConflicts)
+			if _, ok := u.Object[forceConflictsKey]; ok {
+				t.Errorf("want %s removed from the manifest", forceConflictsKey)
+			}
+		})
+	}
+}
+
+// getManifestClient returns a client with the objects, the fake client does
+// not implement server-side apply, the interceptor stores the applied object
+func getManifestClient(objs ...client.Object) provclient.Client {
+	c := fake.NewClientBuilder().WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if err := c.Create(ctx, obj); err != nil {
+				if !kerrors.IsAlreadyExists(err) {
+					return err
+				}
+				return c.Update(ctx, obj)
+			}
+			return nil
+		},
+	}).Build()
+	return k8sclient.NewAPIApplicator(c, "kform")
+}
+
+func getConfigMap(name, address string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetName(name)
+	u.SetNamespace("default")
+	if address != "" {
+		u.SetLabels(map[string]string{ManagedByLabel: ManagedByValue})
+		u.SetAnnotations(map[string]string{ResourceAddressAnnotation: address})
+	}
+	return u
+}
+
+func getOwnerContext(address string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		kfplugin.ModuleKey, "example",
+		kfplugin.ResourceAddressKey, address,
+		kfplugin.RunIDKey, "run1",
+	))
+}
+
+func TestResourceKubernetesManifestValidate(t *testing.T) {
+	cases := map[string]struct {
+		apiVersion      string
+		kind            string
+		key             string
+		value           any
+		expectErr       bool
+		expectedPointer string
+	}{
+		"Valid": {
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+		},
+		"UnknownKind": {
+			apiVersion:      "v1",
+			kind:            "ConfigMapp",
+			expectErr:       true,
+			expectedPointer: "/kind",
+		},
+		"InvalidForceConflicts": {
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			key:        forceConflictsKey,
+			value:      "yes",
+			expectErr:  true,
+		},
+		"InvalidAdopt": {
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			key:        adoptKey,
+			value:      "yes",
+			expectErr:  true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := getConfigMap("a", "")
+			u.SetAPIVersion(tc.apiVersion)
+			u.SetKind(tc.kind)
+			if tc.key != "" {
+				u.Object[tc.key] = tc.value
+			}
+			b, err := json.Marshal(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diags := resourceKubernetesManifestValidate(context.Background(), &schema.ResourceObject{Obj: b}, getManifestClient())
+			if tc.expectErr != diags.HasError() {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, diags.Error())
+			}
+			if tc.expectedPointer != "" {
+				assert.Equal(t, tc.expectedPointer, diag.AttributePathToPointer(diags[0].Attribute))
+			}
+		})
+	}
+}
+
+func TestResourceKubernetesManifestCreate(t *testing.T) {
+	cases := map[string]struct {
+		existing  *unstructured.Unstructured
+		adopt     bool
+		expectErr bool
+	}{
+		"New": {},
+		"Owned": {
+			existing: getConfigMap("a", "example.kubernetes_manifest.a"),
+		},
+		"NotManaged": {
+			existing:  getConfigMap("a", ""),
+			expectErr: true,
+		},
+		"Adopt": {
+			existing: getConfigMap("a", ""),
+			adopt:    true,
+		},
+		"OwnedByOther": {
+			existing:  getConfigMap("a", "example.kubernetes_manifest.b"),
+			expectErr: true,
+		},
+		// only objects that are not managed by kform are adopted
+		"AdoptOwnedByOther": {
+			existing:  getConfigMap("a", "example.kubernetes_manifest.b"),
+			adopt:     true,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := []client.Object{}
+			if tc.existing != nil {
+				objs = append(objs, tc.existing)
+			}
+			c := getManifestClient(objs...)
+
+			m := getConfigMap("a", "")
+			if tc.adopt {
+				m.Object[adoptKey] = true
+			}
+			b, err := json.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := getOwnerContext("example.kubernetes_manifest.a")
+			b, diags := resourceKubernetesManifestCreate(ctx, &schema.ResourceObject{Obj: b}, c)
+			if tc.expectErr != diags.HasError() {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, diags.Error())
+			}
+			if diags.HasError() {
+				return
+			}
+			u := &unstructured.Unstructured{}
+			if err := json.Unmarshal(b, u); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, ManagedByValue, u.GetLabels()[ManagedByLabel])
+			assert.Equal(t, "example", u.GetAnnotations()[ModuleAnnotation])
+			assert.Equal(t, "example.kubernetes_manifest.a", u.GetAnnotations()[ResourceAddressAnnotation])
+			assert.Equal(t, "run1", u.GetAnnotations()[RunIDAnnotation])
+		})
+	}
+}
+
+func TestResourceKubernetesManifestUpdate(t *testing.T) {
+	address := "example.kubernetes_manifest.a"
+	c := getManifestClient(getConfigMap("a", address))
+
+	oldObj, _ := json.Marshal(getConfigMap("a", ""))
+	newObj, _ := json.Marshal(getConfigMap("b", ""))
+	_, diags := resourceKubernetesManifestUpdate(getOwnerContext(address), &schema.ResourceObject{Obj: newObj, OldObj: oldObj}, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	// the renamed object replaces the old object
+	if _, err := getObject(context.Background(), c, kfplugin1.Scope_NAMESPACE, getConfigMap("a", "")); !kerrors.IsNotFound(err) {
+		t.Errorf("want old object deleted, got: %v", err)
+	}
+	if _, err := getObject(context.Background(), c, kfplugin1.Scope_NAMESPACE, getConfigMap("b", "")); err != nil {
+		t.Errorf("want new object, got: %v", err)
+	}
+}
+
+func TestResourceKubernetesManifestUpdateRunID(t *testing.T) {
+	address := "example.kubernetes_manifest.a"
+	cases := map[string]struct {
+		data      map[string]any
+		wantRunID string
+	}{
+		// the object is not changed when the manifest did not change
+		"Unchanged": {
+			wantRunID: "run0",
+		},
+		"Changed": {
+			data:      map[string]any{"a": "b"},
+			wantRunID: "run1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			existing := getConfigMap("a", address)
+			existing.SetAnnotations(map[string]string{ResourceAddressAnnotation: address, RunIDAnnotation: "run0"})
+			c := getManifestClient(existing)
+
+			oldObj, _ := json.Marshal(getConfigMap("a", ""))
+			m := getConfigMap("a", "")
+			if tc.data != nil {
+				m.Object["data"] = tc.data
+			}
+			newObj, _ := json.Marshal(m)
+			b, diags := resourceKubernetesManifestUpdate(getOwnerContext(address), &schema.ResourceObject{Obj: newObj, OldObj: oldObj}, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			u := &unstructured.Unstructured{}
+			if err := json.Unmarshal(b, u); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantRunID, u.GetAnnotations()[RunIDAnnotation])
+		})
+	}
+}
+
+func TestResourceKubernetesManifestDelete(t *testing.T) {
+	waitForPollInterval = 10 * time.Millisecond
+
+	cases := map[string]struct {
+		existing    *unstructured.Unstructured
+		annotations map[string]string
+		expectErr   bool
+	}{
+		"Owned": {
+			existing: getConfigMap("a", "example.kubernetes_manifest.a"),
+		},
+		"NotFound": {},
+		"OwnedByOther": {
+			existing:  getConfigMap("a", "example.kubernetes_manifest.b"),
+			expectErr: true,
+		},
+		"Propagation": {
+			existing:    getConfigMap("a", "example.kubernetes_manifest.a"),
+			annotations: map[string]string{DeletePropagationAnnotation: "Foreground"},
+		},
+		"InvalidPropagation": {
+			existing:    getConfigMap("a", "example.kubernetes_manifest.a"),
+			annotations: map[string]string{DeletePropagationAnnotation: "Later"},
+			expectErr:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := []client.Object{}
+			if tc.existing != nil {
+				objs = append(objs, tc.existing)
+			}
+			c := getManifestClient(objs...)
+
+			u := getConfigMap("a", "")
+			u.SetAnnotations(tc.annotations)
+			b, err := json.Marshal(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithTimeout(getOwnerContext("example.kubernetes_manifest.a"), time.Second)
+			defer cancel()
+			diags := resourceKubernetesManifestDelete(ctx, &schema.ResourceObject{Obj: b}, c)
+			if tc.expectErr != diags.HasError() {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, diags.Error())
+			}
+			if diags.HasError() {
+				return
+			}
+			if _, err := getObject(context.Background(), c, kfplugin1.Scope_NAMESPACE, u); !kerrors.IsNotFound(err) {
+				t.Errorf("want object deleted, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client"
+	"github.com/henderiw/logger/log"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+// waitForKey is the key of the waitFor section in the manifest, the section
+// is not applied to the object.
+const waitForKey = "waitFor"
+
+// waitForPollInterval is the interval in which the object is read while
+// waiting for the object to be settled
+var waitForPollInterval = 2 * time.Second
+
+// WaitFor defines when an applied manifest is settled, all the checks must
+// succeed. The wait is bounded by the create timeout of the resource.
+type WaitFor struct {
+	// Status is the kstatus the object must have, e.g. Current
+	Status string `json:"status,omitempty"`
+	// Conditions are the conditions the object must have, e.g. Ready=True
+	Conditions []WaitForCondition `json:"conditions,omitempty"`
+	// Fields maps a JSON path in the object to the value it must have,
+	// e.g. .status.phase: Running
+	Fields map[string]string `json:"fields,omitempty"`
+	// Expression is a CEL expression on the object that must be true,
+	// e.g. object.status.readyReplicas == object.spec.replicas
+	Expression string `json:"expression,omitempty"`
+}
+
+type WaitForCondition struct {
+	Type   string `json:"type"`
+	Status string `json:"status,omitempty"`
+}
+
+// getWaitFor returns the waitFor section of the manifest and removes it from
+// the manifest. Nil is returned when the manifest has no waitFor section.
+func getWaitFor(u *unstructured.Unstructured) (*waiter, error) {
+	v, ok := u.Object[waitForKey]
+	if !ok {
+		return nil, nil
+	}
+	delete(u.Object, waitForKey)
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	waitFor := WaitFor{}
+	if err := json.Unmarshal(b, &waitFor); err != nil {
+		return nil, fmt.Errorf("invalid %s, err: %s", waitForKey, err.Error())
+	}
+	return newWaiter(waitFor)
+}
+
+// waiter evaluates the checks of the waitFor section on an object
+type waiter struct {
+	waitFor    WaitFor
+	fields     map[string]*jsonpath.JSONPath
+	expression cel.Program
+}
+
+func newWaiter(waitFor WaitFor) (*waiter, error) {
+	w := &waiter{
+		waitFor: waitFor,
+		fields:  map[string]*jsonpath.JSONPath{},
+	}
+	if waitFor.Status != "" {
+		if !isKnownStatus(waitFor.Status) {
+			return nil, fmt.Errorf("invalid %s status %q, expected one of %v", waitForKey, waitFor.Status, status.Statuses)
+		}
+	}
+	for _, c := range waitFor.Conditions {
+		if c.Type == "" {
+			return nil, fmt.Errorf("invalid %s condition, type is required", waitForKey)
+		}
+	}
+	for path := range waitFor.Fields {
+		jp := jsonpath.New(path)
+		if err := jp.Parse(getJSONPathTemplate(path)); err != nil {
+			return nil, fmt.Errorf("invalid %s field %s, err: %s", waitForKey, path, err.Error())
+		}
+		w.fields[path] = jp
+	}
+	if waitFor.Expression != "" {
+		env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+		if err != nil {
+			return nil, err
+		}
+		ast, iss := env.Compile(waitFor.Expression)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("invalid %s expression %q, err: %s", waitForKey, waitFor.Expression, iss.Err().Error())
+		}
+		prg, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s expression %q, err: %s", waitForKey, waitFor.Expression, err.Error())
+		}
+		w.expression = prg
+	}
+	return w, nil
+}
+
+func isKnownStatus(s string) bool {
+	for _, known := range status.Statuses {
+		if known.String() == s {
+			return true
+		}
+	}
+	return false
+}
+
+// getJSONPathTemplate returns the JSON path template of a path, e.g.
+// .status.phase becomes {.status.phase}
+func getJSONPathTemplate(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+	return fmt.Sprintf("{%s}", path)
+}
+
+// settled returns true when all the checks succeed on the object, otherwise
+// the reason of the first failing check is returned.
+func (r *waiter) settled(u *unstructured.Unstructured) (bool, string, error) {
+	if r.waitFor.Status != "" {
+		res, err := status.Compute(u)
+		if err != nil {
+			return false, "", err
+		}
+		if res.Status.String() != r.waitFor.Status {
+			return false, fmt.Sprintf("status is %s, waiting for %s: %s", res.Status, r.waitFor.Status, res.Message), nil
+		}
+	}
+	for _, c := range r.waitFor.Conditions {
+		expected := c.Status
+		if expected == "" {
+			expected = "True"
+		}
+		got := getConditionStatus(u, c.Type)
+		if !strings.EqualFold(got, expected) {
+			return false, fmt.Sprintf("condition %s is %q, waiting for %q", c.Type, got, expected), nil
+		}
+	}
+	paths := make([]string, 0, len(r.fields))
+	for path := range r.fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		results, err := r.fields[path].FindResults(u.Object)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			return false, fmt.Sprintf("field %s not found", path), nil
+		}
+		got := fmt.Sprintf("%v", results[0][0].Interface())
+		if got != r.waitFor.Fields[path] {
+			return false, fmt.Sprintf("field %s is %q, waiting for %q", path, got, r.waitFor.Fields[path]), nil
+		}
+	}
+	if r.expression != nil {
+		out, _, err := r.expression.Eval(map[string]any{"object": u.Object})
+		if err != nil {
+			// fields referenced by the expression might not be set yet
+			return false, fmt.Sprintf("expression %q: %s", r.waitFor.Expression, err.Error()), nil
+		}
+		ok, isBool := out.Value().(bool)
+		if !isBool {
+			return false, "", fmt.Errorf("expression %q must return a bool, got: %s", r.waitFor.Expression, out.Type())
+		}
+		if !ok {
+			return false, fmt.Sprintf("expression %q is false", r.waitFor.Expression), nil
+		}
+	}
+	return true, "", nil
+}
+
+func getConditionStatus(u *unstructured.Unstructured, conditionType string) string {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType {
+			s, _ := condition["status"].(string)
+			return s
+		}
+	}
+	return ""
+}
+
+// wait reads the object until it is settled, the wait is bounded by the
+// context of the operation.
+func (r *waiter) wait(ctx context.Context, c client.Client, scope kfplugin1.Scope, u *unstructured.Unstructured) diag.Diagnostics {
+	log := log.FromContext(ctx).With("apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "name", u.GetName())
+	ticker := time.NewTicker(waitForPollInterval)
+	defer ticker.Stop()
+
+	reason := ""
+	for {
+		newu, err := getObject(ctx, c, scope, u)
+		if err != nil {
+			reason = err.Error()
+		} else {
+			ok, msg, err := r.settled(newu)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if ok {
+				log.Debug("object settled")
+				return nil
+			}
+			reason = msg
+		}
+		log.Debug("waiting for object", "reason", reason)
+
+		select {
+		case <-ctx.Done():
+			return diag.Errorf("%s/%s %s not settled: %s", u.GetAPIVersion(), u.GetKind(), u.GetName(), reason)
+		case <-ticker.C:
+		}
+	}
+}
+
+// getObject reads the object identified by the apiVersion, kind, namespace
+// and name of the manifest
+func getObject(ctx context.Context, c client.Client, scope kfplugin1.Scope, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	nsn := types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}
+	if scope == kfplugin1.Scope_CLUSTER {
+		nsn = types.NamespacedName{Name: u.GetName()}
+	}
+	newu := &unstructured.Unstructured{}
+	newu.SetAPIVersion(u.GetAPIVersion())
+	newu.SetKind(u.GetKind())
+	if err := c.Get(ctx, nsn, newu); err != nil {
+		return nil, err
+	}
+	return newu, nil
+}
+
+// waitForDeletion reads the object until it is gone, e.g. until the
+// finalizers of the object finished. The wait is bounded by the context of
+// the operation.
+func waitForDeletion(ctx context.Context, c client.Client, scope kfplugin1.Scope, u *unstructured.Unstructured) diag.Diagnostics {
+	log := log.FromContext(ctx).With("apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "name", u.GetName())
+	ticker := time.NewTicker(waitForPollInterval)
+	defer ticker.Stop()
+
+	reason := ""
+	for {
+		newu, err := getObject(ctx, c, scope, u)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				log.Debug("object deleted")
+				return nil
+			}
+			reason = err.Error()
+		} else {
+			reason = fmt.Sprintf("finalizers %v", newu.GetFinalizers())
+		}
+		log.Debug("waiting for object deletion", "reason", reason)
+
+		select {
+		case <-ctx.Done():
+			return diag.Errorf("%s/%s %s not deleted: %s", u.GetAPIVersion(), u.GetKind(), u.GetName(), reason)
+		case <-ticker.C:
+		}
+	}
+}
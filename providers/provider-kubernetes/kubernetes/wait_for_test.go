@@ -0,0 +1,190 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/providers/provider-kubernetes/kubernetes/client/k8sclient"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getDeployment(ready bool) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":       "nginx",
+			"namespace":  "default",
+			"generation": int64(1),
+		},
+		"spec": map[string]any{
+			"replicas": int64(2),
+		},
+		"status": map[string]any{
+			"observedGeneration": int64(1),
+			"replicas":           int64(2),
+			"updatedReplicas":    int64(2),
+			"readyReplicas":      int64(1),
+			"availableReplicas":  int64(1),
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": "False"},
+			},
+		},
+	}}
+	if ready {
+		u.Object["status"] = map[string]any{
+			"observedGeneration": int64(1),
+			"replicas":           int64(2),
+			"updatedReplicas":    int64(2),
+			"readyReplicas":      int64(2),
+			"availableReplicas":  int64(2),
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": "True"},
+			},
+		}
+	}
+	return u
+}
+
+func TestWaiterSettled(t *testing.T) {
+	cases := map[string]struct {
+		waitFor   WaitFor
+		ready     bool
+		expected  bool
+		expectErr bool
+	}{
+		"StatusCurrent": {
+			waitFor:  WaitFor{Status: "Current"},
+			ready:    true,
+			expected: true,
+		},
+		"StatusInProgress": {
+			waitFor:  WaitFor{Status: "Current"},
+			expected: false,
+		},
+		"Condition": {
+			waitFor:  WaitFor{Conditions: []WaitForCondition{{Type: "Available"}}},
+			ready:    true,
+			expected: true,
+		},
+		"ConditionFalse": {
+			waitFor:  WaitFor{Conditions: []WaitForCondition{{Type: "Available", Status: "True"}}},
+			expected: false,
+		},
+		"Field": {
+			waitFor:  WaitFor{Fields: map[string]string{".status.readyReplicas": "2"}},
+			ready:    true,
+			expected: true,
+		},
+		"FieldNotSet": {
+			waitFor:  WaitFor{Fields: map[string]string{"status.unknown": "2"}},
+			ready:    true,
+			expected: false,
+		},
+		"Expression": {
+			waitFor:  WaitFor{Expression: "object.status.readyReplicas == object.spec.replicas"},
+			ready:    true,
+			expected: true,
+		},
+		"ExpressionFalse": {
+			waitFor:  WaitFor{Expression: "object.status.readyReplicas == object.spec.replicas"},
+			expected: false,
+		},
+		"ExpressionNotBool": {
+			waitFor:   WaitFor{Expression: "object.status.readyReplicas"},
+			ready:     true,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w, err := newWaiter(tc.waitFor)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			ok, reason, err := w.settled(getDeployment(tc.ready))
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, err)
+			}
+			if ok != tc.expected {
+				t.Errorf("want settled %t, got: %t, reason: %s", tc.expected, ok, reason)
+			}
+		})
+	}
+}
+
+func TestGetWaitFor(t *testing.T) {
+	cases := map[string]struct {
+		waitFor   any
+		expectErr bool
+	}{
+		"Valid": {
+			waitFor: map[string]any{
+				"status":     "Current",
+				"conditions": []any{map[string]any{"type": "Ready"}},
+				"fields":     map[string]any{".status.phase": "Running"},
+				"expression": "object.status.phase == 'Running'",
+			},
+		},
+		"InvalidStatus": {
+			waitFor:   map[string]any{"status": "Ready"},
+			expectErr: true,
+		},
+		"InvalidExpression": {
+			waitFor:   map[string]any{"expression": "object.status.phase =="},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := getDeployment(false)
+			u.Object[waitForKey] = tc.waitFor
+			_, err := getWaitFor(u)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, err)
+			}
+			if _, ok := u.Object[waitForKey]; ok {
+				t.Errorf("want %s removed from the manifest", waitForKey)
+			}
+		})
+	}
+}
+
+func TestWaiterWait(t *testing.T) {
+	waitForPollInterval = 10 * time.Millisecond
+
+	cases := map[string]struct {
+		ready     bool
+		expectErr bool
+	}{
+		"Settled": {
+			ready: true,
+		},
+		"Timeout": {
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := getDeployment(tc.ready)
+			c := k8sclient.NewAPIApplicator(fake.NewClientBuilder().WithObjects(u.DeepCopy()).Build(), "kform")
+
+			w, err := newWaiter(WaitFor{Status: "Current"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			diags := w.wait(ctx, c, kfplugin1.Scope_NAMESPACE, u)
+			if tc.expectErr != diags.HasError() {
+				t.Errorf("want error %t, got: %v", tc.expectErr, diags.Error())
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log/slog"
 
 	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1"
@@ -13,6 +15,9 @@ import (
 const providerName = "registry.fkorm.io/kform/resourcebackend"
 
 func main() {
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "start the provider in debug mode, e.g. to run it under a debugger")
+	flag.Parse()
 
 	log := log.NewLogger(&log.HandlerOptions{Name: "provider-resourcebackend-logger", AddSource: false})
 	slog.SetDefault(log)
@@ -24,6 +29,12 @@ func main() {
 	opts := []kfserver1.ServeOpt{
 		kfserver1.WithGoPluginLogger(log),
 	}
+	if debug {
+		if err := kfserver1.Debug(context.Background(), providerName, grpcProviderFunc, opts...); err != nil {
+			slog.Error("kform debug failed", "err", err)
+		}
+		return
+	}
 	if err := kfserver1.Serve(
 		providerName,
 		grpcProviderFunc,
@@ -10,12 +10,38 @@ func (r *ProviderConfigSpec) IsKindValid() bool {
 		return true
 	case ProviderKindAPI:
 		return true
+	case ProviderKindLocal:
+		return true
 	default:
 		return false
 	}
 }
 
-var ExpectedProviderKinds = []string{string(ProviderKindMock), string(ProviderKindAPI)}
+var ExpectedProviderKinds = []string{string(ProviderKindMock), string(ProviderKindAPI), string(ProviderKindLocal)}
+
+// GetFile returns the file the local kind persists the claims to
+func (r *ProviderConfigSpec) GetFile() string {
+	if r.File == nil {
+		return ""
+	}
+	return *r.File
+}
+
+// GetMinID returns the first vlan of the index
+func (r *VLANIndexPool) GetMinID() uint16 {
+	if r.MinID == nil {
+		return 1
+	}
+	return *r.MinID
+}
+
+// GetMaxID returns the last vlan of the index
+func (r *VLANIndexPool) GetMaxID() uint16 {
+	if r.MaxID == nil {
+		return 4094
+	}
+	return *r.MaxID
+}
 
 // BuildProviderConfig returns a ProviderConfig from a meta Object and
 // an ProviderConfig Spec
@@ -11,15 +11,68 @@ type ProviderKind string
 const (
 	ProviderKindMock ProviderKind = "mock"
 	ProviderKindAPI  ProviderKind = "api"
+	// ProviderKindLocal claims the resources in-process from the pools of the
+	// provider config and persists the claims to a file
+	ProviderKindLocal ProviderKind = "local"
 )
 
 type ProviderConfigSpec struct {
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum:=api;mock
+	// +kubebuilder:validation:Enum:=api;mock;local
 	// +kubebuilder:default:=api
 	Kind ProviderKind `json:"kind" yaml:"kind"`
 
 	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+
+	// File is the file the local kind persists the claims to. A relative path
+	// is relative to the state directory of kform, by default the claims are
+	// persisted to resourcebackend-<name>.json in the state directory, with
+	// name the name of the provider config.
+	// +kubebuilder:validation:MaxLength=256
+	File *string `json:"file,omitempty" yaml:"file,omitempty"`
+	// NetworkInstances are the pools of prefixes the local kind claims ip
+	// prefixes and addresses from
+	NetworkInstances []NetworkInstancePool `json:"networkInstances,omitempty" yaml:"networkInstances,omitempty"`
+	// VLANIndexes are the pools of vlans the local kind claims vlans from
+	VLANIndexes []VLANIndexPool `json:"vlanIndexes,omitempty" yaml:"vlanIndexes,omitempty"`
+}
+
+// NetworkInstancePool is a network instance of the local kind
+type NetworkInstancePool struct {
+	// Namespace of the network instance, an empty namespace matches the
+	// network instance of a claim in any namespace
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// +kubebuilder:validation:Required
+	Name string `json:"name" yaml:"name"`
+	// Prefixes are claimed from in order
+	Prefixes []PoolPrefix `json:"prefixes,omitempty" yaml:"prefixes,omitempty"`
+}
+
+// PoolPrefix is a prefix of a network instance
+type PoolPrefix struct {
+	// +kubebuilder:validation:Required
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// Labels are matched by the selector of a claim
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// VLANIndexPool is a vlan index of the local kind
+type VLANIndexPool struct {
+	// Namespace of the vlan index, an empty namespace matches the vlan index
+	// of a claim in any namespace
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// +kubebuilder:validation:Required
+	Name string `json:"name" yaml:"name"`
+	// MinID is the first vlan of the index
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	// +kubebuilder:default=1
+	MinID *uint16 `json:"minID,omitempty" yaml:"minID,omitempty"`
+	// MaxID is the last vlan of the index
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	// +kubebuilder:default=4094
+	MaxID *uint16 `json:"maxID,omitempty" yaml:"maxID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
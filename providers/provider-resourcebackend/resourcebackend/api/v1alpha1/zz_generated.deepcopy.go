@@ -24,12 +24,56 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInstancePool) DeepCopyInto(out *NetworkInstancePool) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]PoolPrefix, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInstancePool.
+func (in *NetworkInstancePool) DeepCopy() *NetworkInstancePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInstancePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolPrefix) DeepCopyInto(out *PoolPrefix) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolPrefix.
+func (in *PoolPrefix) DeepCopy() *PoolPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfig.
@@ -49,3 +93,62 @@ func (in *ProviderConfig) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkInstances != nil {
+		in, out := &in.NetworkInstances, &out.NetworkInstances
+		*out = make([]NetworkInstancePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VLANIndexes != nil {
+		in, out := &in.VLANIndexes, &out.VLANIndexes
+		*out = make([]VLANIndexPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
+func (in *ProviderConfigSpec) DeepCopy() *ProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLANIndexPool) DeepCopyInto(out *VLANIndexPool) {
+	*out = *in
+	if in.MinID != nil {
+		in, out := &in.MinID, &out.MinID
+		*out = new(uint16)
+		**out = **in
+	}
+	if in.MaxID != nil {
+		in, out := &in.MaxID, &out.MaxID
+		*out = new(uint16)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLANIndexPool.
+func (in *VLANIndexPool) DeepCopy() *VLANIndexPool {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANIndexPool)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,187 @@
+package resourcebackend
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
+	"github.com/nokia/k8s-ipam/pkg/proxy/beclient"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Client is the client of the resource backend.
+type Client interface {
+	beclient.Client
+	// ListClaims returns the claims of the kind, sorted by namespace and
+	// name, that are in the namespace and match the selector. An empty
+	// namespace selects all namespaces. The claims are listed from a
+	// backend that can list its claims, e.g. the local backend. The remote
+	// backend has no api to list claims, for that backend the list is
+	// process-local: it only has the claims this provider instance claimed
+	// or read, claims of previous runs or of other clients are not listed.
+	ListClaims(ctx context.Context, gk schema.GroupKind, namespace string, selector labels.Selector) ([]client.Object, error)
+}
+
+// claimLister is implemented by a backend that can list its claims
+type claimLister interface {
+	ListClaims(ctx context.Context, gk schema.GroupKind) ([]client.Object, error)
+}
+
+func newClient(be beclient.Client) Client {
+	return &claimClient{
+		Client: be,
+		claims: map[claimKey]client.Object{},
+	}
+}
+
+// claimKey identifies a claim, the name of a claim is unique within the
+// network instance or VLAN index it claims from
+type claimKey struct {
+	gk    schema.GroupKind
+	nsn   types.NamespacedName
+	index types.NamespacedName
+}
+
+func getClaimKey(cr client.Object) claimKey {
+	key := claimKey{
+		gk:  cr.GetObjectKind().GroupVersionKind().GroupKind(),
+		nsn: types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()},
+	}
+	switch claim := cr.(type) {
+	case *ipamv1alpha1.IPClaim:
+		key.index = types.NamespacedName{Namespace: claim.Spec.NetworkInstance.Namespace, Name: claim.Spec.NetworkInstance.Name}
+	case *vlanv1alpha1.VLANClaim:
+		key.index = types.NamespacedName{Namespace: claim.Spec.VLANIndex.Namespace, Name: claim.Spec.VLANIndex.Name}
+	}
+	return key
+}
+
+type claimClient struct {
+	beclient.Client
+
+	m      sync.RWMutex
+	claims map[claimKey]client.Object
+}
+
+// GetClaim returns the claimed resource
+func (r *claimClient) GetClaim(ctx context.Context, cr client.Object, d any) (client.Object, error) {
+	o, err := r.Client.GetClaim(ctx, cr, d)
+	if err != nil {
+		return nil, err
+	}
+	r.add(getClaimKey(cr), o)
+	return o, nil
+}
+
+// Claim claims a resource
+func (r *claimClient) Claim(ctx context.Context, cr client.Object, d any) (client.Object, error) {
+	o, err := r.Client.Claim(ctx, cr, d)
+	if err != nil {
+		return nil, err
+	}
+	r.add(getClaimKey(cr), o)
+	return o, nil
+}
+
+// DeleteClaim releases the claimed resource
+func (r *claimClient) DeleteClaim(ctx context.Context, cr client.Object, d any) error {
+	if err := r.Client.DeleteClaim(ctx, cr, d); err != nil {
+		return err
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	delete(r.claims, getClaimKey(cr))
+	return nil
+}
+
+func (r *claimClient) ListClaims(ctx context.Context, gk schema.GroupKind, namespace string, selector labels.Selector) ([]client.Object, error) {
+	claims, err := r.getClaims(ctx, gk)
+	if err != nil {
+		return nil, err
+	}
+	keys := []claimKey{}
+	for key, o := range claims {
+		if namespace != "" && key.nsn.Namespace != namespace {
+			continue
+		}
+		if selector != nil && !selector.Matches(labels.Set(o.GetLabels())) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].nsn != keys[j].nsn {
+			return keys[i].nsn.String() < keys[j].nsn.String()
+		}
+		return keys[i].index.String() < keys[j].index.String()
+	})
+	l := make([]client.Object, 0, len(keys))
+	for _, key := range keys {
+		l = append(l, claims[key].DeepCopyObject().(client.Object))
+	}
+	return l, nil
+}
+
+// getClaims returns the claims of the kind from the backend when the backend
+// can list its claims, otherwise the claims this client claimed or read
+func (r *claimClient) getClaims(ctx context.Context, gk schema.GroupKind) (map[claimKey]client.Object, error) {
+	claims := map[claimKey]client.Object{}
+	if lister, ok := r.Client.(claimLister); ok {
+		l, err := lister.ListClaims(ctx, gk)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range l {
+			claims[getClaimKey(o)] = o
+		}
+		return claims, nil
+	}
+	r.m.RLock()
+	defer r.m.RUnlock()
+	for key, o := range r.claims {
+		if key.gk == gk {
+			claims[key] = o
+		}
+	}
+	return claims, nil
+}
+
+func (r *claimClient) add(key claimKey, o client.Object) {
+	if o == nil {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.claims[key] = o.DeepCopyObject().(client.Object)
+}
+
+// mockBackend is the mock of the resource backend, the mock keeps no claims
+// so releasing a claim always succeeds
+type mockBackend struct {
+	beclient.Client
+}
+
+func newMockBackend() beclient.Client {
+	return &mockBackend{Client: beclient.NewMock()}
+}
+
+// DeleteClaim releases the claimed resource
+func (r *mockBackend) DeleteClaim(ctx context.Context, cr client.Object, d any) error {
+	return nil
+}
+
+// marshal returns the json of a claim or a list of claims
+func marshal(o any) ([]byte, diag.Diagnostics) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return b, nil
+}
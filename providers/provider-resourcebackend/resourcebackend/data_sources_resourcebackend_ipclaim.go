@@ -0,0 +1,47 @@
+package resourcebackend
+
+import (
+	"context"
+	"time"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+)
+
+func dataSourcesResourceBackendIPClaim() *schema.Resource {
+	defaultTimout := 5 * time.Minute
+	return &schema.Resource{
+		ListContext: dataSourcesResourceBackendIPClaimList,
+		Timeouts: &schema.ResourceTimeout{
+			Read:    &defaultTimout,
+			Default: &defaultTimout,
+		},
+	}
+}
+
+// dataSourcesResourceBackendIPClaimList lists the IPClaims that are in the
+// namespace and match the label selector of the list
+func dataSourcesResourceBackendIPClaimList(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	client := meta.(Client)
+
+	selector, err := d.GetSelector()
+	if err != nil {
+		return nil, diag.Errorf("invalid label selector, err: %s", err.Error())
+	}
+
+	l := &ipamv1alpha1.IPClaimList{}
+	l.SetGroupVersionKind(ipamv1alpha1.GroupVersion.WithKind(ipamv1alpha1.IPClaimKind + "List"))
+	claims, err := client.ListClaims(ctx, ipamv1alpha1.IPClaimGroupVersionKind.GroupKind(), d.GetNamespace(), selector)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for _, o := range claims {
+		claim, ok := o.(*ipamv1alpha1.IPClaim)
+		if !ok {
+			return nil, diag.Errorf("unexpected claim %s, expected: %s", o.GetName(), ipamv1alpha1.IPClaimKind)
+		}
+		l.Items = append(l.Items, *claim)
+	}
+	return marshal(l)
+}
@@ -0,0 +1,88 @@
+package resourcebackend
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourcesResourceBackendClaimList(t *testing.T) {
+	cases := map[string]struct {
+		labelSelector *kfplugin1.LabelSelector
+		namespace     string
+		expectedNames []string
+	}{
+		"All": {
+			expectedNames: []string{"a", "b", "c"},
+		},
+		"MatchLabels": {
+			labelSelector: &kfplugin1.LabelSelector{MatchLabels: map[string]string{"app": "x"}},
+			expectedNames: []string{"a", "c"},
+		},
+		"Namespace": {
+			namespace:     "other",
+			expectedNames: []string{},
+		},
+	}
+
+	// the claims are listed from the backend, also the claims of a previous
+	// run that are persisted to the file of the backend
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "claims.json")
+	c := newClient(newLocalBackend(t, file))
+	for _, name := range []string{"c", "a", "b"} {
+		claimLabels := map[string]string{"app": "x"}
+		if name == "b" {
+			claimLabels = map[string]string{"app": "y"}
+		}
+		if _, diags := resourceResourceBackendIPClaimCreate(ctx, &schema.ResourceObject{Obj: getIPClaim(t, name, "vpc1", claimLabels)}, c); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags.Error())
+		}
+		if _, diags := resourceResourceBackendVLANClaimCreate(ctx, &schema.ResourceObject{Obj: getVLANClaim(t, name, "vpc1", nil, claimLabels)}, c); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags.Error())
+		}
+	}
+
+	c = newClient(newLocalBackend(t, file))
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := &schema.ResourceObject{LabelSelector: tc.labelSelector, Namespace: tc.namespace}
+
+			b, diags := dataSourcesResourceBackendIPClaimList(ctx, d, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			ipClaims := &ipamv1alpha1.IPClaimList{}
+			if err := json.Unmarshal(b, ipClaims); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, "IPClaimList", ipClaims.Kind)
+			names := []string{}
+			for _, claim := range ipClaims.Items {
+				names = append(names, claim.GetName())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+
+			b, diags = dataSourcesResourceBackendVLANClaimList(ctx, d, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			vlanClaims := &vlanv1alpha1.VLANClaimList{}
+			if err := json.Unmarshal(b, vlanClaims); err != nil {
+				t.Fatal(err)
+			}
+			names = []string{}
+			for _, claim := range vlanClaims.Items {
+				names = append(names, claim.GetName())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package resourcebackend
+
+import (
+	"context"
+	"time"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
+)
+
+func dataSourcesResourceBackendVLANClaim() *schema.Resource {
+	defaultTimout := 5 * time.Minute
+	return &schema.Resource{
+		ListContext: dataSourcesResourceBackendVLANClaimList,
+		Timeouts: &schema.ResourceTimeout{
+			Read:    &defaultTimout,
+			Default: &defaultTimout,
+		},
+	}
+}
+
+// dataSourcesResourceBackendVLANClaimList lists the VLANClaims that are in the
+// namespace and match the label selector of the list
+func dataSourcesResourceBackendVLANClaimList(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	client := meta.(Client)
+
+	selector, err := d.GetSelector()
+	if err != nil {
+		return nil, diag.Errorf("invalid label selector, err: %s", err.Error())
+	}
+
+	l := &vlanv1alpha1.VLANClaimList{}
+	l.SetGroupVersionKind(vlanv1alpha1.GroupVersion.WithKind(vlanv1alpha1.VLANClaimKind + "List"))
+	claims, err := client.ListClaims(ctx, vlanv1alpha1.VLANClaimGroupVersionKind.GroupKind(), d.GetNamespace(), selector)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for _, o := range claims {
+		claim, ok := o.(*vlanv1alpha1.VLANClaim)
+		if !ok {
+			return nil, diag.Errorf("unexpected claim %s, expected: %s", o.GetName(), vlanv1alpha1.VLANClaimKind)
+		}
+		l.Items = append(l.Items, *claim)
+	}
+	return marshal(l)
+}
@@ -0,0 +1,423 @@
+package local
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+	"github.com/nokia/k8s-ipam/pkg/iputil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type networkInstance struct {
+	// key is the namespace and name of the network instance in the config
+	key      string
+	name     string
+	prefixes []poolPrefix
+}
+
+type poolPrefix struct {
+	prefix netip.Prefix
+	labels map[string]string
+}
+
+// ipAllocation is the prefix or address of an ip claim
+type ipAllocation struct {
+	NetworkInstance string                  `json:"networkInstance"`
+	Claim           string                  `json:"claim"`
+	Kind            ipamv1alpha1.PrefixKind `json:"kind"`
+	CreatePrefix    bool                    `json:"createPrefix,omitempty"`
+	// Prefix is the claimed prefix, a single address for an address claim
+	Prefix netip.Prefix `json:"prefix"`
+	// Parent is the claimed prefix the address is claimed from, the parent is
+	// not set when the address is claimed from a pool prefix
+	Parent *netip.Prefix `json:"parent,omitempty"`
+	// FromLabels are the labels of the pool prefix or the parent the claim is
+	// claimed from
+	FromLabels map[string]string `json:"fromLabels,omitempty"`
+	// Labels are the user defined labels of the claim, the address claims
+	// select the prefix they claim from by these labels
+	Labels map[string]string `json:"labels,omitempty"`
+	// ClaimLabels are the labels of the claim object, a list of the claims
+	// selects the claims by these labels
+	ClaimLabels map[string]string `json:"claimLabels,omitempty"`
+	// StatusPrefix and Gateway are the status of the claim
+	StatusPrefix string `json:"statusPrefix"`
+	Gateway      string `json:"gateway,omitempty"`
+}
+
+func (r *ipAllocation) getKey() string {
+	return r.NetworkInstance + "/" + r.Claim
+}
+
+func (r *ipAllocation) getClaim(claim *ipamv1alpha1.IPClaim) *ipamv1alpha1.IPClaim {
+	claim = claim.DeepCopy()
+	prefix := r.StatusPrefix
+	claim.Status.Prefix = &prefix
+	claim.Status.Gateway = nil
+	if r.Gateway != "" {
+		gateway := r.Gateway
+		claim.Status.Gateway = &gateway
+	}
+	return claim
+}
+
+func (r *local) getNetworkInstance(ref corev1.ObjectReference) *networkInstance {
+	for _, ni := range r.networkInstances {
+		if isIndex(ni.key, ref) {
+			return ni
+		}
+	}
+	return nil
+}
+
+// getIPKey returns the key of an ip claim, the claim has no key when its
+// network instance is not in the config
+func (r *local) getIPKey(claim *ipamv1alpha1.IPClaim) (string, bool) {
+	ni := r.getNetworkInstance(claim.Spec.NetworkInstance)
+	if ni == nil {
+		return "", false
+	}
+	return ni.key + "/" + getNSN(claim), true
+}
+
+// claimIP returns the allocation of the claim when the allocation still
+// satisfies the claim, otherwise a new prefix or address is claimed
+func (r *local) claimIP(claim *ipamv1alpha1.IPClaim) (*ipamv1alpha1.IPClaim, error) {
+	ni := r.getNetworkInstance(claim.Spec.NetworkInstance)
+	if ni == nil {
+		return nil, fmt.Errorf("network instance %s not found", claim.Spec.NetworkInstance.Name)
+	}
+	selector, err := claim.GetLabelSelector()
+	if err != nil {
+		return nil, err
+	}
+	key := ni.key + "/" + getNSN(claim)
+	old, ok := r.ipClaims[key]
+	if ok && r.isSatisfied(ni, old, claim, selector) {
+		a := *old
+		a.Labels = claim.GetUserDefinedLabels()
+		a.ClaimLabels = claim.GetLabels()
+		r.ipClaims[key] = &a
+		if err := r.save(); err != nil {
+			r.ipClaims[key] = old
+			return nil, err
+		}
+		return a.getClaim(claim), nil
+	}
+
+	delete(r.ipClaims, key)
+	a, err := r.allocateIP(ni, claim, selector)
+	if err == nil {
+		r.ipClaims[key] = a
+		err = r.save()
+	}
+	if err != nil {
+		delete(r.ipClaims, key)
+		if old != nil {
+			r.ipClaims[key] = old
+		}
+		return nil, err
+	}
+	return a.getClaim(claim), nil
+}
+
+// isSatisfied returns true when the allocation is still within the pools of
+// the network instance and still matches the spec of the claim
+func (r *local) isSatisfied(ni *networkInstance, a *ipAllocation, claim *ipamv1alpha1.IPClaim, selector labels.Selector) bool {
+	if a.Kind != claim.Spec.Kind || a.CreatePrefix != isCreatePrefix(claim) {
+		return false
+	}
+	if claim.Spec.Prefix != nil {
+		p, err := netip.ParsePrefix(*claim.Spec.Prefix)
+		if err != nil || p.String() != a.StatusPrefix {
+			return false
+		}
+	} else {
+		if !isAddressFamily(claim, a.Prefix) || !selector.Matches(labels.Set(a.FromLabels)) {
+			return false
+		}
+		if isPrefixClaim(claim) && claim.Spec.PrefixLength != nil && int(*claim.Spec.PrefixLength) != a.Prefix.Bits() {
+			return false
+		}
+	}
+	if a.Parent != nil {
+		for _, parent := range r.getParents(ni, a.Kind) {
+			if parent.Prefix == *a.Parent {
+				return true
+			}
+		}
+		return false
+	}
+	for _, pp := range ni.prefixes {
+		if pp.prefix.Bits() <= a.Prefix.Bits() && pp.prefix.Contains(a.Prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// allocateIP claims the prefix or address of the claim. A prefix is claimed
+// from the pool prefixes. An address is claimed from the prefixes that are
+// claimed with createPrefix for the same kind and that match the selector of
+// the claim, or from the pool prefixes when there is no such prefix.
+func (r *local) allocateIP(ni *networkInstance, claim *ipamv1alpha1.IPClaim, selector labels.Selector) (*ipAllocation, error) {
+	a := &ipAllocation{
+		NetworkInstance: ni.key,
+		Claim:           getNSN(claim),
+		Kind:            claim.Spec.Kind,
+		CreatePrefix:    isCreatePrefix(claim),
+		Labels:          claim.GetUserDefinedLabels(),
+		ClaimLabels:     claim.GetLabels(),
+	}
+	if claim.Spec.Prefix != nil {
+		if err := r.allocateStaticIP(ni, a, claim); err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+
+	if isPrefixClaim(claim) {
+		if claim.Spec.PrefixLength == nil {
+			return nil, fmt.Errorf("cannot claim a prefix of kind %s without prefixLength", claim.Spec.Kind)
+		}
+		bits := int(*claim.Spec.PrefixLength)
+		for _, pp := range ni.prefixes {
+			if !isAddressFamily(claim, pp.prefix) || !selector.Matches(labels.Set(pp.labels)) ||
+				pp.prefix.Bits() > bits || bits > pp.prefix.Addr().BitLen() {
+				continue
+			}
+			if p, ok := findFree(pp.prefix, bits, r.getUsed(a.NetworkInstance, nil)); ok {
+				a.Prefix = p
+				a.FromLabels = pp.labels
+				a.StatusPrefix = p.String()
+				return a, nil
+			}
+		}
+		return nil, fmt.Errorf("no free prefix with length %d in network instance %s", bits, ni.name)
+	}
+
+	for _, parent := range r.getParents(ni, claim.Spec.Kind) {
+		if !isAddressFamily(claim, parent.Prefix) || !selector.Matches(labels.Set(parent.Labels)) {
+			continue
+		}
+		used := append(r.getUsed(a.NetworkInstance, &parent.Prefix), getReserved(a.Kind, parent.Prefix)...)
+		if p, ok := findFree(parent.Prefix, parent.Prefix.Addr().BitLen(), used); ok {
+			parentPrefix := parent.Prefix
+			a.Parent = &parentPrefix
+			a.FromLabels = parent.Labels
+			setAddress(a, p, parent.Prefix)
+			return a, nil
+		}
+	}
+	for _, pp := range ni.prefixes {
+		if !isAddressFamily(claim, pp.prefix) || !selector.Matches(labels.Set(pp.labels)) {
+			continue
+		}
+		used := append(r.getUsed(a.NetworkInstance, nil), getReserved(a.Kind, pp.prefix)...)
+		if p, ok := findFree(pp.prefix, pp.prefix.Addr().BitLen(), used); ok {
+			a.FromLabels = pp.labels
+			setAddress(a, p, pp.prefix)
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("no free address in network instance %s", ni.name)
+}
+
+// allocateStaticIP claims the prefix or address the claim requests
+func (r *local) allocateStaticIP(ni *networkInstance, a *ipAllocation, claim *ipamv1alpha1.IPClaim) error {
+	p, err := netip.ParsePrefix(*claim.Spec.Prefix)
+	if err != nil {
+		return fmt.Errorf("invalid prefix %s, err: %s", *claim.Spec.Prefix, err.Error())
+	}
+	a.StatusPrefix = p.String()
+	if isPrefixClaim(claim) {
+		if p != p.Masked() {
+			return fmt.Errorf("invalid prefix %s, expected: %s", p.String(), p.Masked().String())
+		}
+		a.Prefix = p
+	} else {
+		a.Prefix = netip.PrefixFrom(p.Addr(), p.Addr().BitLen())
+		if a.Kind == ipamv1alpha1.PrefixKindNetwork {
+			a.Gateway = getGateway(p.Masked())
+		}
+		for _, parent := range r.getParents(ni, a.Kind) {
+			if !parent.Prefix.Contains(p.Addr()) {
+				continue
+			}
+			parentPrefix := parent.Prefix
+			a.Parent = &parentPrefix
+			a.FromLabels = parent.Labels
+			used := append(r.getUsed(a.NetworkInstance, &parent.Prefix), getReserved(a.Kind, parent.Prefix)...)
+			if isOverlapping(a.Prefix, used) {
+				return fmt.Errorf("prefix %s is not available in network instance %s", p.String(), ni.name)
+			}
+			return nil
+		}
+	}
+	for _, pp := range ni.prefixes {
+		if pp.prefix.Bits() > a.Prefix.Bits() || !pp.prefix.Contains(a.Prefix.Addr()) {
+			continue
+		}
+		a.FromLabels = pp.labels
+		if isOverlapping(a.Prefix, r.getUsed(a.NetworkInstance, nil)) {
+			return fmt.Errorf("prefix %s is not available in network instance %s", p.String(), ni.name)
+		}
+		return nil
+	}
+	return fmt.Errorf("prefix %s is not within the prefixes of network instance %s", p.String(), ni.name)
+}
+
+// getParents returns the prefixes that are claimed with createPrefix for the
+// kind, sorted by prefix
+func (r *local) getParents(ni *networkInstance, kind ipamv1alpha1.PrefixKind) []*ipAllocation {
+	parents := []*ipAllocation{}
+	for _, a := range r.ipClaims {
+		if a.NetworkInstance == ni.key && a.CreatePrefix && a.Kind == kind && a.Parent == nil {
+			parents = append(parents, a)
+		}
+	}
+	sort.Slice(parents, func(i, j int) bool {
+		return comparePrefix(parents[i].Prefix, parents[j].Prefix) < 0
+	})
+	return parents
+}
+
+// getUsed returns the claimed prefixes in the network instance, with parent
+// only the addresses claimed from the parent
+func (r *local) getUsed(networkInstance string, parent *netip.Prefix) []netip.Prefix {
+	used := []netip.Prefix{}
+	for _, a := range r.ipClaims {
+		if a.NetworkInstance != networkInstance {
+			continue
+		}
+		if parent != nil && (a.Parent == nil || *a.Parent != *parent) {
+			continue
+		}
+		used = append(used, a.Prefix)
+	}
+	return used
+}
+
+// setAddress sets the address claimed from prefix p, an address of a network
+// gets the length and the gateway of the network
+func setAddress(a *ipAllocation, addr, p netip.Prefix) {
+	a.Prefix = addr
+	if a.Kind != ipamv1alpha1.PrefixKindNetwork {
+		a.StatusPrefix = addr.String()
+		return
+	}
+	a.StatusPrefix = netip.PrefixFrom(addr.Addr(), p.Bits()).String()
+	a.Gateway = getGateway(p)
+}
+
+// getGateway returns the first address of a network, a point-to-point
+// network has no gateway
+func getGateway(p netip.Prefix) string {
+	if p.Bits() >= p.Addr().BitLen()-1 {
+		return ""
+	}
+	return p.Addr().Next().String()
+}
+
+// getReserved returns the addresses of a network that are not claimed: the
+// network address, the gateway and the ipv4 broadcast address
+func getReserved(kind ipamv1alpha1.PrefixKind, p netip.Prefix) []netip.Prefix {
+	if kind != ipamv1alpha1.PrefixKindNetwork || p.Bits() >= p.Addr().BitLen()-1 {
+		return nil
+	}
+	bits := p.Addr().BitLen()
+	reserved := []netip.Prefix{
+		netip.PrefixFrom(p.Addr(), bits),
+		netip.PrefixFrom(p.Addr().Next(), bits),
+	}
+	if p.Addr().Is4() {
+		reserved = append(reserved, netip.PrefixFrom(lastAddr(p), bits))
+	}
+	return reserved
+}
+
+// findFree returns the lowest prefix with length bits in p that does not
+// overlap with the used prefixes
+func findFree(p netip.Prefix, bits int, used []netip.Prefix) (netip.Prefix, bool) {
+	c := netip.PrefixFrom(p.Addr(), bits)
+	for p.Contains(c.Addr()) {
+		blocker, ok := getOverlapping(c, used)
+		if !ok {
+			return c, true
+		}
+		// prefixes either nest or do not overlap, the next candidate follows
+		// the larger of both
+		end := lastAddr(c)
+		if blocker.Bits() < c.Bits() {
+			end = lastAddr(blocker)
+		}
+		next := end.Next()
+		if !next.IsValid() {
+			break
+		}
+		c = netip.PrefixFrom(next, bits)
+	}
+	return netip.Prefix{}, false
+}
+
+func getOverlapping(p netip.Prefix, used []netip.Prefix) (netip.Prefix, bool) {
+	for _, u := range used {
+		if u.Overlaps(p) {
+			return u, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+func isOverlapping(p netip.Prefix, used []netip.Prefix) bool {
+	_, ok := getOverlapping(p, used)
+	return ok
+}
+
+// lastAddr returns the last address of prefix p
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Masked().Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - uint(i%8))
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+func comparePrefix(a, b netip.Prefix) int {
+	if c := a.Addr().Compare(b.Addr()); c != 0 {
+		return c
+	}
+	return a.Bits() - b.Bits()
+}
+
+// isPrefixClaim returns true when the claim claims a prefix iso an address
+func isPrefixClaim(claim *ipamv1alpha1.IPClaim) bool {
+	switch claim.Spec.Kind {
+	case ipamv1alpha1.PrefixKindPool, ipamv1alpha1.PrefixKindAggregate:
+		return true
+	default:
+		return isCreatePrefix(claim)
+	}
+}
+
+func isCreatePrefix(claim *ipamv1alpha1.IPClaim) bool {
+	return claim.Spec.CreatePrefix != nil && *claim.Spec.CreatePrefix
+}
+
+func isAddressFamily(claim *ipamv1alpha1.IPClaim, p netip.Prefix) bool {
+	if claim.Spec.AddressFamily == nil {
+		return true
+	}
+	switch *claim.Spec.AddressFamily {
+	case iputil.AddressFamilyIpv4:
+		return p.Addr().Is4()
+	case iputil.AddressFamilyIpv6:
+		return p.Addr().Is6()
+	default:
+		return true
+	}
+}
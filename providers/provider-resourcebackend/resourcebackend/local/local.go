@@ -0,0 +1,303 @@
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/resourcebackend/api/v1alpha1"
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
+	"github.com/nokia/k8s-ipam/pkg/proxy/beclient"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Config is the config of the local backend
+type Config struct {
+	// File is the file the claims are persisted to, the claims are not
+	// persisted when the file is empty
+	File string
+	// NetworkInstances are the pools of prefixes ip claims claim from
+	NetworkInstances []v1alpha1.NetworkInstancePool
+	// VLANIndexes are the pools of vlans vlan claims claim from
+	VLANIndexes []v1alpha1.VLANIndexPool
+}
+
+// New returns a backend that claims the resources in-process from the pools
+// of the config. A claim gets the lowest free resource of the pools, in the
+// order of the config, such that the same config always gets the same
+// resources. The claims are read from and persisted to the file of the
+// config, a claim that is claimed again keeps its resource.
+func New(cfg Config) (beclient.Client, error) {
+	r := &local{
+		file:       cfg.File,
+		ipClaims:   map[string]*ipAllocation{},
+		vlanClaims: map[string]*vlanAllocation{},
+	}
+	for _, pool := range cfg.NetworkInstances {
+		ni := &networkInstance{key: getIndexKey(pool.Namespace, pool.Name), name: pool.Name}
+		for _, pp := range pool.Prefixes {
+			p, err := netip.ParsePrefix(pp.Prefix)
+			if err != nil {
+				return nil, fmt.Errorf("invalid prefix %s in network instance %s, err: %s", pp.Prefix, pool.Name, err.Error())
+			}
+			if p != p.Masked() {
+				return nil, fmt.Errorf("invalid prefix %s in network instance %s, expected: %s", pp.Prefix, pool.Name, p.Masked().String())
+			}
+			ni.prefixes = append(ni.prefixes, poolPrefix{prefix: p, labels: pp.Labels})
+		}
+		r.networkInstances = append(r.networkInstances, ni)
+	}
+	for _, pool := range cfg.VLANIndexes {
+		if pool.GetMinID() > pool.GetMaxID() {
+			return nil, fmt.Errorf("invalid vlan index %s, minID %d is larger than maxID %d", pool.Name, pool.GetMinID(), pool.GetMaxID())
+		}
+		r.vlanIndexes = append(r.vlanIndexes, &vlanIndex{
+			key:   getIndexKey(pool.Namespace, pool.Name),
+			name:  pool.Name,
+			minID: pool.GetMinID(),
+			maxID: pool.GetMaxID(),
+		})
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+type local struct {
+	m                sync.Mutex
+	file             string
+	networkInstances []*networkInstance
+	vlanIndexes      []*vlanIndex
+	// ipClaims holds the ip claims keyed by network instance and claim
+	ipClaims map[string]*ipAllocation
+	// vlanClaims holds the vlan claims keyed by vlan index and claim
+	vlanClaims map[string]*vlanAllocation
+}
+
+// claims is the content of the file the claims are persisted to
+type claims struct {
+	IPClaims   []*ipAllocation   `json:"ipClaims,omitempty"`
+	VLANClaims []*vlanAllocation `json:"vlanClaims,omitempty"`
+}
+
+func (r *local) CreateIndex(ctx context.Context, cr client.Object) error {
+	return fmt.Errorf("unsupported, the indexes of the local backend are configured in the provider config")
+}
+
+// Delete deletes the cache instance in the backend
+func (r *local) DeleteIndex(ctx context.Context, cr client.Object) error {
+	return fmt.Errorf("unsupported, the indexes of the local backend are configured in the provider config")
+}
+
+// Get returns the claimed resource
+func (r *local) GetClaim(ctx context.Context, cr client.Object, d any) (client.Object, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	switch claim := cr.(type) {
+	case *ipamv1alpha1.IPClaim:
+		key, _ := r.getIPKey(claim)
+		a, ok := r.ipClaims[key]
+		if !ok {
+			return nil, fmt.Errorf("claim %s not found in network instance %s", getNSN(claim), claim.Spec.NetworkInstance.Name)
+		}
+		return a.getClaim(claim), nil
+	case *vlanv1alpha1.VLANClaim:
+		key, _ := r.getVLANKey(claim)
+		a, ok := r.vlanClaims[key]
+		if !ok {
+			return nil, fmt.Errorf("claim %s not found in vlan index %s", getNSN(claim), claim.Spec.VLANIndex.Name)
+		}
+		return a.getClaim(claim), nil
+	default:
+		return nil, fmt.Errorf("unsupported claim, got: %v", reflect.TypeOf(cr))
+	}
+}
+
+// Claim claims a resource
+func (r *local) Claim(ctx context.Context, cr client.Object, d any) (client.Object, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	switch claim := cr.(type) {
+	case *ipamv1alpha1.IPClaim:
+		return r.claimIP(claim)
+	case *vlanv1alpha1.VLANClaim:
+		return r.claimVLAN(claim)
+	default:
+		return nil, fmt.Errorf("unsupported claim, got: %v", reflect.TypeOf(cr))
+	}
+}
+
+// DeleteClaim deletes the claim, deleting a claim that does not exist
+// succeeds
+func (r *local) DeleteClaim(ctx context.Context, cr client.Object, d any) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	switch claim := cr.(type) {
+	case *ipamv1alpha1.IPClaim:
+		key, _ := r.getIPKey(claim)
+		old, ok := r.ipClaims[key]
+		if !ok {
+			return nil
+		}
+		delete(r.ipClaims, key)
+		if err := r.save(); err != nil {
+			r.ipClaims[key] = old
+			return err
+		}
+		return nil
+	case *vlanv1alpha1.VLANClaim:
+		key, _ := r.getVLANKey(claim)
+		old, ok := r.vlanClaims[key]
+		if !ok {
+			return nil
+		}
+		delete(r.vlanClaims, key)
+		if err := r.save(); err != nil {
+			r.vlanClaims[key] = old
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported claim, got: %v", reflect.TypeOf(cr))
+	}
+}
+
+// ListClaims returns the claims of the kind the backend holds, also the
+// claims of previous runs that are persisted to the file. A claim has the
+// name, namespace and labels of the claim object, its index and its status.
+func (r *local) ListClaims(ctx context.Context, gk schema.GroupKind) ([]client.Object, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	claims := []client.Object{}
+	switch gk {
+	case ipamv1alpha1.IPClaimGroupVersionKind.GroupKind():
+		for _, a := range r.ipClaims {
+			claim := &ipamv1alpha1.IPClaim{}
+			claim.SetGroupVersionKind(ipamv1alpha1.IPClaimGroupVersionKind)
+			setObjectMeta(claim, a.Claim, a.ClaimLabels)
+			claim.Spec.Kind = a.Kind
+			claim.Spec.NetworkInstance = getIndexRef(a.NetworkInstance)
+			if a.CreatePrefix {
+				createPrefix := true
+				claim.Spec.CreatePrefix = &createPrefix
+			}
+			claim.Spec.UserDefinedLabels.Labels = a.Labels
+			claims = append(claims, a.getClaim(claim))
+		}
+	case vlanv1alpha1.VLANClaimGroupVersionKind.GroupKind():
+		for _, a := range r.vlanClaims {
+			claim := &vlanv1alpha1.VLANClaim{}
+			claim.SetGroupVersionKind(vlanv1alpha1.VLANClaimGroupVersionKind)
+			setObjectMeta(claim, a.Claim, a.ClaimLabels)
+			claim.Spec.VLANIndex = getIndexRef(a.VLANIndex)
+			claims = append(claims, a.getClaim(claim))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported claim, got: %s", gk.String())
+	}
+	return claims, nil
+}
+
+// load reads the claims from the file, no claims are read when the file does
+// not exist
+func (r *local) load() error {
+	if r.file == "" {
+		return nil
+	}
+	b, err := os.ReadFile(r.file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	c := &claims{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("cannot read claims from %s, err: %s", r.file, err.Error())
+	}
+	for _, a := range c.IPClaims {
+		r.ipClaims[a.getKey()] = a
+	}
+	for _, a := range c.VLANClaims {
+		r.vlanClaims[a.getKey()] = a
+	}
+	return nil
+}
+
+// save writes the claims, sorted by index and claim, to the file. The file
+// is replaced such that an interrupted save does not lose the claims.
+func (r *local) save() error {
+	if r.file == "" {
+		return nil
+	}
+	c := &claims{}
+	for _, a := range r.ipClaims {
+		c.IPClaims = append(c.IPClaims, a)
+	}
+	sort.Slice(c.IPClaims, func(i, j int) bool {
+		return c.IPClaims[i].getKey() < c.IPClaims[j].getKey()
+	})
+	for _, a := range r.vlanClaims {
+		c.VLANClaims = append(c.VLANClaims, a)
+	}
+	sort.Slice(c.VLANClaims, func(i, j int) bool {
+		return c.VLANClaims[i].getKey() < c.VLANClaims[j].getKey()
+	})
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.file), 0755); err != nil {
+		return err
+	}
+	tmp := r.file + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.file)
+}
+
+// getIndexKey returns the key of a network instance or vlan index of the
+// config
+func getIndexKey(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// isIndex returns true when the reference of a claim refers to the index
+// with the key, an index without namespace matches the reference in any
+// namespace
+func isIndex(key string, ref corev1.ObjectReference) bool {
+	return key == getIndexKey(ref.Namespace, ref.Name) || key == getIndexKey("", ref.Name)
+}
+
+// getIndexRef returns the reference of the index with the key
+func getIndexRef(key string) corev1.ObjectReference {
+	namespace, name, _ := strings.Cut(key, "/")
+	return corev1.ObjectReference{Namespace: namespace, Name: name}
+}
+
+// setObjectMeta sets the name and namespace of the claim with the key and the
+// labels of the claim
+func setObjectMeta(o client.Object, key string, claimLabels map[string]string) {
+	namespace, name, _ := strings.Cut(key, "/")
+	o.SetNamespace(namespace)
+	o.SetName(name)
+	o.SetLabels(claimLabels)
+}
+
+func getNSN(o client.Object) string {
+	return types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()}.String()
+}
@@ -0,0 +1,338 @@
+package local
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/resourcebackend/api/v1alpha1"
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
+	"github.com/nokia/k8s-ipam/pkg/iputil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testNetworkInstances = []v1alpha1.NetworkInstancePool{
+	{
+		Name: "vpc1",
+		Prefixes: []v1alpha1.PoolPrefix{
+			{Prefix: "10.0.0.0/16", Labels: map[string]string{"purpose": "network"}},
+			{Prefix: "2001:db8::/48"},
+			{Prefix: "172.16.0.0/24", Labels: map[string]string{"purpose": "loopback"}},
+		},
+	},
+}
+
+func getIPClaim(name string, kind ipamv1alpha1.PrefixKind, mutate func(*ipamv1alpha1.IPClaim)) *ipamv1alpha1.IPClaim {
+	claim := &ipamv1alpha1.IPClaim{}
+	claim.SetGroupVersionKind(ipamv1alpha1.IPClaimGroupVersionKind)
+	claim.SetName(name)
+	claim.SetNamespace("default")
+	claim.Spec.Kind = kind
+	claim.Spec.NetworkInstance.Name = "vpc1"
+	if mutate != nil {
+		mutate(claim)
+	}
+	return claim
+}
+
+func withPrefixLength(l uint8) func(*ipamv1alpha1.IPClaim) {
+	return func(c *ipamv1alpha1.IPClaim) {
+		c.Spec.PrefixLength = &l
+		createPrefix := true
+		c.Spec.CreatePrefix = &createPrefix
+		c.Spec.UserDefinedLabels.Labels = map[string]string{"cluster": c.GetName()}
+	}
+}
+
+func withSelector(l map[string]string) func(*ipamv1alpha1.IPClaim) {
+	return func(c *ipamv1alpha1.IPClaim) {
+		c.Spec.Selector = &metav1.LabelSelector{MatchLabels: l}
+	}
+}
+
+func withPrefix(p string) func(*ipamv1alpha1.IPClaim) {
+	return func(c *ipamv1alpha1.IPClaim) {
+		c.Spec.Prefix = &p
+	}
+}
+
+func withAddressFamily(af iputil.AddressFamily) func(*ipamv1alpha1.IPClaim) {
+	return func(c *ipamv1alpha1.IPClaim) {
+		c.Spec.AddressFamily = &af
+	}
+}
+
+func TestClaimIP(t *testing.T) {
+	cases := map[string]struct {
+		claims           []*ipamv1alpha1.IPClaim
+		expectedPrefixes []string
+		expectedGateways []string
+		expectErr        bool
+	}{
+		"Network": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, nil),
+				getIPClaim("b", ipamv1alpha1.PrefixKindNetwork, nil),
+			},
+			expectedPrefixes: []string{"10.0.0.2/16", "10.0.0.3/16"},
+			expectedGateways: []string{"10.0.0.1", "10.0.0.1"},
+		},
+		"NetworkIPv6": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, withAddressFamily(iputil.AddressFamilyIpv6)),
+			},
+			expectedPrefixes: []string{"2001:db8::2/48"},
+			expectedGateways: []string{"2001:db8::1"},
+		},
+		"Loopback": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindLoopback, withSelector(map[string]string{"purpose": "loopback"})),
+				getIPClaim("b", ipamv1alpha1.PrefixKindLoopback, withSelector(map[string]string{"purpose": "loopback"})),
+			},
+			expectedPrefixes: []string{"172.16.0.0/32", "172.16.0.1/32"},
+			expectedGateways: []string{"", ""},
+		},
+		"CreatePrefix": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, withPrefixLength(24)),
+				getIPClaim("x", ipamv1alpha1.PrefixKindNetwork, withAddressFamily(iputil.AddressFamilyIpv4)),
+				getIPClaim("b", ipamv1alpha1.PrefixKindNetwork, withPrefixLength(24)),
+				getIPClaim("y", ipamv1alpha1.PrefixKindNetwork, withSelector(map[string]string{"cluster": "b"})),
+				getIPClaim("z", ipamv1alpha1.PrefixKindNetwork, withSelector(map[string]string{"cluster": "b"})),
+			},
+			// x is claimed from the first prefix, b skips the prefix of a
+			expectedPrefixes: []string{"10.0.0.0/24", "10.0.0.2/24", "10.0.1.0/24", "10.0.1.2/24", "10.0.1.3/24"},
+			expectedGateways: []string{"", "10.0.0.1", "", "10.0.1.1", "10.0.1.1"},
+		},
+		"Pool": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("x", ipamv1alpha1.PrefixKindLoopback, withSelector(map[string]string{"purpose": "network"})),
+				getIPClaim("a", ipamv1alpha1.PrefixKindPool, func(c *ipamv1alpha1.IPClaim) {
+					l := uint8(20)
+					c.Spec.PrefixLength = &l
+				}),
+			},
+			// the pool skips the address of x
+			expectedPrefixes: []string{"10.0.0.0/32", "10.0.16.0/20"},
+			expectedGateways: []string{"", ""},
+		},
+		"Static": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, withPrefix("10.0.0.2/24")),
+				getIPClaim("b", ipamv1alpha1.PrefixKindNetwork, nil),
+			},
+			expectedPrefixes: []string{"10.0.0.2/24", "10.0.0.3/16"},
+			expectedGateways: []string{"10.0.0.1", "10.0.0.1"},
+		},
+		"StaticNotAvailable": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, nil),
+				getIPClaim("b", ipamv1alpha1.PrefixKindNetwork, withPrefix("10.0.0.2/16")),
+			},
+			expectErr: true,
+		},
+		"StaticNotInPool": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, withPrefix("192.168.0.2/24")),
+			},
+			expectErr: true,
+		},
+		"PrefixLengthMissing": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindPool, nil),
+			},
+			expectErr: true,
+		},
+		"NetworkInstanceNotFound": {
+			claims: []*ipamv1alpha1.IPClaim{
+				getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, func(c *ipamv1alpha1.IPClaim) {
+					c.Spec.NetworkInstance.Name = "vpc2"
+				}),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			be, err := New(Config{NetworkInstances: testNetworkInstances})
+			if err != nil {
+				t.Fatal(err)
+			}
+			prefixes := []string{}
+			gateways := []string{}
+			for _, claim := range tc.claims {
+				o, err := be.Claim(ctx, claim, nil)
+				if err != nil {
+					if tc.expectErr {
+						return
+					}
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				status := o.(*ipamv1alpha1.IPClaim).Status
+				prefixes = append(prefixes, *status.Prefix)
+				gateway := ""
+				if status.Gateway != nil {
+					gateway = *status.Gateway
+				}
+				gateways = append(gateways, gateway)
+			}
+			if tc.expectErr {
+				t.Fatalf("want error, got none")
+			}
+			assert.Equal(t, tc.expectedPrefixes, prefixes)
+			assert.Equal(t, tc.expectedGateways, gateways)
+		})
+	}
+}
+
+func getVLANClaim(name string, vlanID *uint16, vlanRange *string) *vlanv1alpha1.VLANClaim {
+	claim := &vlanv1alpha1.VLANClaim{}
+	claim.SetGroupVersionKind(vlanv1alpha1.VLANClaimGroupVersionKind)
+	claim.SetName(name)
+	claim.SetNamespace("default")
+	claim.Spec.VLANIndex.Name = "vpc1"
+	claim.Spec.VLANID = vlanID
+	claim.Spec.VLANRange = vlanRange
+	return claim
+}
+
+func TestClaimVLAN(t *testing.T) {
+	vlanID := func(id uint16) *uint16 { return &id }
+	vlanRange := func(r string) *string { return &r }
+
+	cases := map[string]struct {
+		claims        []*vlanv1alpha1.VLANClaim
+		expectedVLANs []string
+		expectErr     bool
+	}{
+		"Dynamic": {
+			claims: []*vlanv1alpha1.VLANClaim{
+				getVLANClaim("a", nil, nil),
+				getVLANClaim("b", nil, nil),
+			},
+			expectedVLANs: []string{"100", "101"},
+		},
+		"Static": {
+			claims: []*vlanv1alpha1.VLANClaim{
+				getVLANClaim("a", vlanID(100), nil),
+				getVLANClaim("b", nil, vlanRange("101-103")),
+				getVLANClaim("c", nil, nil),
+			},
+			expectedVLANs: []string{"100", "101-103", "104"},
+		},
+		"StaticNotAvailable": {
+			claims: []*vlanv1alpha1.VLANClaim{
+				getVLANClaim("a", nil, nil),
+				getVLANClaim("b", nil, vlanRange("99-101")),
+			},
+			expectErr: true,
+		},
+		"OutOfRange": {
+			claims: []*vlanv1alpha1.VLANClaim{
+				getVLANClaim("a", vlanID(300), nil),
+			},
+			expectErr: true,
+		},
+		"Exhausted": {
+			claims: []*vlanv1alpha1.VLANClaim{
+				getVLANClaim("a", nil, vlanRange("100-200")),
+				getVLANClaim("b", nil, nil),
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			be, err := New(Config{VLANIndexes: []v1alpha1.VLANIndexPool{
+				{Name: "vpc1", MinID: vlanID(100), MaxID: vlanID(200)},
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			vlans := []string{}
+			for _, claim := range tc.claims {
+				o, err := be.Claim(ctx, claim, nil)
+				if err != nil {
+					if tc.expectErr {
+						return
+					}
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				status := o.(*vlanv1alpha1.VLANClaim).Status
+				if status.VLANRange != nil {
+					vlans = append(vlans, *status.VLANRange)
+				} else {
+					vlans = append(vlans, (&vlanAllocation{Start: *status.VLANID}).getVLANs())
+				}
+			}
+			if tc.expectErr {
+				t.Fatalf("want error, got none")
+			}
+			assert.Equal(t, tc.expectedVLANs, vlans)
+		})
+	}
+}
+
+func TestPersist(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		File:             filepath.Join(t.TempDir(), "state", "resourcebackend.json"),
+		NetworkInstances: testNetworkInstances,
+		VLANIndexes:      []v1alpha1.VLANIndexPool{{Name: "vpc1"}},
+	}
+	be, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := be.Claim(ctx, getIPClaim(name, ipamv1alpha1.PrefixKindNetwork, nil), nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := be.Claim(ctx, getVLANClaim(name, nil, nil), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := be.DeleteClaim(ctx, getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, nil), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := be.DeleteClaim(ctx, getVLANClaim("a", nil, nil), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// a new backend keeps the claims of the file and reuses the released
+	// resources
+	be, err = New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := be.Claim(ctx, getIPClaim("c", ipamv1alpha1.PrefixKindNetwork, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "10.0.0.4/16", *o.(*ipamv1alpha1.IPClaim).Status.Prefix)
+	o, err = be.GetClaim(ctx, getIPClaim("b", ipamv1alpha1.PrefixKindNetwork, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "10.0.0.3/16", *o.(*ipamv1alpha1.IPClaim).Status.Prefix)
+	o, err = be.Claim(ctx, getIPClaim("d", ipamv1alpha1.PrefixKindNetwork, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "10.0.0.2/16", *o.(*ipamv1alpha1.IPClaim).Status.Prefix)
+	o, err = be.Claim(ctx, getVLANClaim("d", nil, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint16(1), *o.(*vlanv1alpha1.VLANClaim).Status.VLANID)
+
+	if _, err := be.GetClaim(ctx, getIPClaim("a", ipamv1alpha1.PrefixKindNetwork, nil), nil); err == nil {
+		t.Errorf("want error, got none")
+	}
+}
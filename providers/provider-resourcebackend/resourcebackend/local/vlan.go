@@ -0,0 +1,179 @@
+package local
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type vlanIndex struct {
+	// key is the namespace and name of the vlan index in the config
+	key   string
+	name  string
+	minID uint16
+	maxID uint16
+}
+
+// vlanAllocation is the vlan or the range of vlans of a vlan claim
+type vlanAllocation struct {
+	VLANIndex string `json:"vlanIndex"`
+	Claim     string `json:"claim"`
+	// Start and End are the first and the last vlan of the claim
+	Start uint16 `json:"start"`
+	End   uint16 `json:"end"`
+	// Range is true when the claim claims a range of vlans
+	Range bool `json:"range,omitempty"`
+	// ClaimLabels are the labels of the claim object, a list of the claims
+	// selects the claims by these labels
+	ClaimLabels map[string]string `json:"claimLabels,omitempty"`
+}
+
+func (r *vlanAllocation) getKey() string {
+	return r.VLANIndex + "/" + r.Claim
+}
+
+func (r *vlanAllocation) getClaim(claim *vlanv1alpha1.VLANClaim) *vlanv1alpha1.VLANClaim {
+	claim = claim.DeepCopy()
+	claim.Status.VLANID = nil
+	claim.Status.VLANRange = nil
+	if r.Range {
+		vlanRange := fmt.Sprintf("%d-%d", r.Start, r.End)
+		claim.Status.VLANRange = &vlanRange
+	} else {
+		vlanID := r.Start
+		claim.Status.VLANID = &vlanID
+	}
+	return claim
+}
+
+func (r *local) getVLANIndex(ref corev1.ObjectReference) *vlanIndex {
+	for _, vi := range r.vlanIndexes {
+		if isIndex(vi.key, ref) {
+			return vi
+		}
+	}
+	return nil
+}
+
+// getVLANKey returns the key of a vlan claim, the claim has no key when its
+// vlan index is not in the config
+func (r *local) getVLANKey(claim *vlanv1alpha1.VLANClaim) (string, bool) {
+	vi := r.getVLANIndex(claim.Spec.VLANIndex)
+	if vi == nil {
+		return "", false
+	}
+	return vi.key + "/" + getNSN(claim), true
+}
+
+// claimVLAN returns the allocation of the claim when the allocation still
+// satisfies the claim, otherwise the lowest free vlan is claimed
+func (r *local) claimVLAN(claim *vlanv1alpha1.VLANClaim) (*vlanv1alpha1.VLANClaim, error) {
+	vi := r.getVLANIndex(claim.Spec.VLANIndex)
+	if vi == nil {
+		return nil, fmt.Errorf("vlan index %s not found", claim.Spec.VLANIndex.Name)
+	}
+	req := &vlanAllocation{
+		VLANIndex:   vi.key,
+		Claim:       getNSN(claim),
+		ClaimLabels: claim.GetLabels(),
+	}
+	switch {
+	case claim.Spec.VLANID != nil:
+		req.Start, req.End = *claim.Spec.VLANID, *claim.Spec.VLANID
+	case claim.Spec.VLANRange != nil:
+		start, end, err := parseVLANRange(*claim.Spec.VLANRange)
+		if err != nil {
+			return nil, err
+		}
+		req.Start, req.End, req.Range = start, end, true
+	}
+
+	key := req.getKey()
+	old, ok := r.vlanClaims[key]
+	if ok && old.Start >= vi.minID && old.End <= vi.maxID &&
+		((req.Start == 0 && !old.Range) || (req.Start == old.Start && req.End == old.End && req.Range == old.Range)) {
+		a := *old
+		a.ClaimLabels = req.ClaimLabels
+		r.vlanClaims[key] = &a
+		if err := r.save(); err != nil {
+			r.vlanClaims[key] = old
+			return nil, err
+		}
+		return a.getClaim(claim), nil
+	}
+
+	delete(r.vlanClaims, key)
+	a, err := r.allocateVLAN(vi, req)
+	if err == nil {
+		r.vlanClaims[key] = a
+		err = r.save()
+	}
+	if err != nil {
+		delete(r.vlanClaims, key)
+		if old != nil {
+			r.vlanClaims[key] = old
+		}
+		return nil, err
+	}
+	return a.getClaim(claim), nil
+}
+
+// allocateVLAN claims the vlans the request requests or the lowest free vlan
+// when the request requests no vlan
+func (r *local) allocateVLAN(vi *vlanIndex, req *vlanAllocation) (*vlanAllocation, error) {
+	if req.Start != 0 {
+		if req.Start < vi.minID || req.End > vi.maxID {
+			return nil, fmt.Errorf("vlan %s is not within vlan index %s, range: %d-%d", req.getVLANs(), vi.name, vi.minID, vi.maxID)
+		}
+		if r.isVLANUsed(vi, req.Start, req.End) {
+			return nil, fmt.Errorf("vlan %s is not available in vlan index %s", req.getVLANs(), vi.name)
+		}
+		return req, nil
+	}
+	for id := uint32(vi.minID); id <= uint32(vi.maxID); id++ {
+		if !r.isVLANUsed(vi, uint16(id), uint16(id)) {
+			req.Start, req.End = uint16(id), uint16(id)
+			return req, nil
+		}
+	}
+	return nil, fmt.Errorf("no free vlan in vlan index %s", vi.name)
+}
+
+func (r *local) isVLANUsed(vi *vlanIndex, start, end uint16) bool {
+	for _, a := range r.vlanClaims {
+		if a.VLANIndex == vi.key && a.Start <= end && start <= a.End {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *vlanAllocation) getVLANs() string {
+	if r.Range {
+		return fmt.Sprintf("%d-%d", r.Start, r.End)
+	}
+	return strconv.Itoa(int(r.Start))
+}
+
+// parseVLANRange parses a range of vlans, e.g. 100-200
+func parseVLANRange(s string) (uint16, uint16, error) {
+	split := strings.Split(s, "-")
+	if len(split) != 2 {
+		return 0, 0, fmt.Errorf("invalid vlan range %s, expected: <start>-<end>", s)
+	}
+	start, err := strconv.ParseUint(strings.TrimSpace(split[0]), 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid vlan range %s, err: %s", s, err.Error())
+	}
+	end, err := strconv.ParseUint(strings.TrimSpace(split[1]), 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid vlan range %s, err: %s", s, err.Error())
+	}
+	if start == 0 || start > end {
+		return 0, 0, fmt.Errorf("invalid vlan range %s, expected: 0 < start <= end", s)
+	}
+	return uint16(start), uint16(end), nil
+}
@@ -3,16 +3,24 @@ package resourcebackend
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
 
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/crd"
 	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/resourcebackend/api/v1alpha1"
+	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/resourcebackend/local"
 	"github.com/nokia/k8s-ipam/pkg/proxy/beclient"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 func Provider() *schema.Provider {
 	p := &schema.Provider{
-		//Schema:         provSchema,
+		Schema: providerConfigSchema(),
 		ResourceMap: map[string]*schema.Resource{
 			"resourcebackend_ipclaim":   resourceResourceBackendIPClaim(),
 			"resourcebackend_vlanclaim": resourceResourceBackendVLANClaim(),
@@ -21,9 +29,10 @@ func Provider() *schema.Provider {
 			"resourcebackend_ipclaim":   dataSourceResourceBackendIPClaim(),
 			"resourcebackend_vlanclaim": dataSourceResourceBackendVLANClaim(),
 		},
-		//ListDataSourcesMap: map[string]*schema.Resource{
-		//	"resourcebackend_ipclaim": dataSourcesResourceBackendIPClaim(),
-		//},
+		ListDataSourcesMap: map[string]*schema.Resource{
+			"resourcebackend_ipclaim":   dataSourcesResourceBackendIPClaim(),
+			"resourcebackend_vlanclaim": dataSourcesResourceBackendVLANClaim(),
+		},
 	}
 	p.ConfigureContextFunc = func(ctx context.Context, d []byte) (any, diag.Diagnostics) {
 		return providerConfigure(ctx, d, p.Version)
@@ -31,6 +40,17 @@ func Provider() *schema.Provider {
 	return p
 }
 
+// providerConfigSchema returns the schema of the provider config from the
+// generated custom resource definition
+func providerConfigSchema() *spec.Schema {
+	s, err := schema.NewSchemaFromCRD(crd.ProviderConfig)
+	if err != nil {
+		slog.Error("invalid provider config schema", "err", err)
+		return nil
+	}
+	return s
+}
+
 func providerConfigure(ctx context.Context, d []byte, _ string) (any, diag.Diagnostics) {
 	providerConfig := &v1alpha1.ProviderConfig{}
 	if err := json.Unmarshal(d, providerConfig); err != nil {
@@ -42,8 +62,42 @@ func providerConfigure(ctx context.Context, d []byte, _ string) (any, diag.Diagn
 	}
 
 	if providerConfig.Spec.Kind == v1alpha1.ProviderKindMock {
-		return beclient.NewMock(), diag.Diagnostics{}
+		return newClient(newMockBackend()), diag.Diagnostics{}
+	}
+
+	if providerConfig.Spec.Kind == v1alpha1.ProviderKindLocal {
+		be, err := local.New(local.Config{
+			File:             getLocalFile(providerConfig),
+			NetworkInstances: providerConfig.Spec.NetworkInstances,
+			VLANIndexes:      providerConfig.Spec.VLANIndexes,
+		})
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return newClient(be), diag.Diagnostics{}
 	}
 
-	return beclient.New(ctx, providerConfig.Spec.Address), diag.Diagnostics{}
+	return newClient(beclient.New(ctx, providerConfig.Spec.Address)), diag.Diagnostics{}
+}
+
+// getLocalFile returns the file the local kind persists the claims to, a
+// relative file is relative to the state directory of kform. Without state
+// directory, e.g. for a provider that runs in debug mode, the .kform
+// directory in the working directory is used.
+func getLocalFile(providerConfig *v1alpha1.ProviderConfig) string {
+	file := providerConfig.Spec.GetFile()
+	if file == "" {
+		file = "resourcebackend.json"
+		if providerConfig.GetName() != "" {
+			file = fmt.Sprintf("resourcebackend-%s.json", providerConfig.GetName())
+		}
+	}
+	if filepath.IsAbs(file) {
+		return file
+	}
+	stateDir := os.Getenv(kfplugin.EnvStateDir)
+	if stateDir == "" {
+		stateDir = ".kform"
+	}
+	return filepath.Join(stateDir, file)
 }
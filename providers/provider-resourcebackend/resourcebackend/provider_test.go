@@ -0,0 +1,119 @@
+package resourcebackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	kftesting "github.com/henderiw-nephio/kform/kform-sdk-go/pkg/testing"
+	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/resourcebackend/api/v1alpha1"
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProviderConfigSchema(t *testing.T) {
+	s := Provider().Schema
+	if s == nil {
+		t.Fatal("want provider config schema, got nil")
+	}
+	if _, ok := s.Properties["spec"]; !ok {
+		t.Errorf("want spec in provider config schema, got: %v", s.Properties)
+	}
+}
+
+func getIPClaimConfig(claimLabels map[string]string) map[string]any {
+	return map[string]any{
+		"apiVersion": ipamv1alpha1.GroupVersion.String(),
+		"kind":       ipamv1alpha1.IPClaimKind,
+		"metadata": map[string]any{
+			"name":      "a",
+			"namespace": "default",
+			"labels":    claimLabels,
+		},
+		"spec": map[string]any{
+			"kind":            string(ipamv1alpha1.PrefixKindNetwork),
+			"networkInstance": map[string]any{"name": "vpc1"},
+		},
+	}
+}
+
+// getIPClaimNames returns the names of the ip claims of a list
+func getIPClaimNames(b []byte) ([]string, error) {
+	l := &ipamv1alpha1.IPClaimList{}
+	if err := json.Unmarshal(b, l); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, claim := range l.Items {
+		names = append(names, claim.GetName())
+	}
+	return names, nil
+}
+
+func TestProviderIPClaim(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "claims.json")
+	kftesting.Test(t, kftesting.TestCase{
+		ProviderName:    "resourcebackend",
+		ProviderFactory: Provider,
+		ProviderConfig: v1alpha1.BuildProviderConfig(metav1.ObjectMeta{Name: "test"}, v1alpha1.ProviderConfigSpec{
+			Kind: v1alpha1.ProviderKindLocal,
+			File: &file,
+			NetworkInstances: []v1alpha1.NetworkInstancePool{
+				{Name: "vpc1", Prefixes: []v1alpha1.PoolPrefix{{Prefix: "10.0.0.0/24"}}},
+			},
+		}),
+		ResourceType: "resourcebackend_ipclaim",
+		Scope:        kfplugin1.Scope_NAMESPACE,
+		Steps: []kftesting.TestStep{
+			{
+				Config:       getIPClaimConfig(nil),
+				ExpectObject: map[string]any{"status": map[string]any{"prefix": "10.0.0.2/24"}},
+			},
+			// the claim keeps its prefix when the labels change
+			{
+				Config: getIPClaimConfig(map[string]string{"app": "x"}),
+				ExpectObject: map[string]any{
+					"metadata": map[string]any{"labels": map[string]any{"app": "x"}},
+					"status":   map[string]any{"prefix": "10.0.0.2/24"},
+				},
+			},
+			{
+				Kind:          kftesting.StepKindList,
+				Config:        map[string]any{},
+				LabelSelector: &kfplugin1.LabelSelector{MatchLabels: map[string]string{"app": "x"}},
+				Check: func(obj []byte) error {
+					names, err := getIPClaimNames(obj)
+					if err != nil {
+						return err
+					}
+					if len(names) != 1 || names[0] != "a" {
+						return fmt.Errorf("want claim a, got: %v", names)
+					}
+					return nil
+				},
+			},
+		},
+		// the claim is released in the backend, the list no longer has it
+		CheckDestroy: func(ctx context.Context, client kfplugin1.ProviderClient, obj []byte) error {
+			resp, err := client.ListDataSource(ctx, &kfplugin1.ListDataSource_Request{Name: "resourcebackend_ipclaim", Obj: []byte("{}")})
+			if err != nil {
+				return err
+			}
+			if diags := diag.Diagnostics(resp.Diagnostics); diags.HasError() {
+				return diags.Error()
+			}
+			names, err := getIPClaimNames(resp.Obj)
+			if err != nil {
+				return err
+			}
+			if len(names) != 0 {
+				return fmt.Errorf("want no claims, got: %v", names)
+			}
+			return nil
+		},
+	})
+}
@@ -7,8 +7,8 @@ import (
 
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw/logger/log"
 	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
-	"github.com/nokia/k8s-ipam/pkg/proxy/beclient"
 )
 
 func resourceResourceBackendIPClaim() *schema.Resource {
@@ -16,16 +16,20 @@ func resourceResourceBackendIPClaim() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceResourceBackendIPClaimCreate,
 		ReadContext:   resourceResourceBackendIPClaimRead,
+		UpdateContext: resourceResourceBackendIPClaimUpdate,
+		DeleteContext: resourceResourceBackendIPClaimDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create:  &defaultTimout,
 			Read:    &defaultTimout,
+			Update:  &defaultTimout,
+			Delete:  &defaultTimout,
 			Default: &defaultTimout,
 		},
 	}
 }
 
 func resourceResourceBackendIPClaimCreate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
-	client := meta.(beclient.Client)
+	client := meta.(Client)
 
 	u := &ipamv1alpha1.IPClaim{}
 	if err := json.Unmarshal(d.GetObject(), u); err != nil {
@@ -45,7 +49,7 @@ func resourceResourceBackendIPClaimCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceResourceBackendIPClaimRead(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
-	client := meta.(beclient.Client)
+	client := meta.(Client)
 
 	u := &ipamv1alpha1.IPClaim{}
 	if err := json.Unmarshal(d.GetObject(), u); err != nil {
@@ -61,3 +65,75 @@ func resourceResourceBackendIPClaimRead(ctx context.Context, d *schema.ResourceO
 	}
 	return b, nil
 }
+
+// resourceResourceBackendIPClaimUpdate claims the new claim, the prefix of
+// the old claim is kept when the new claim does not request a prefix and the
+// prefix is still available. The old claim is released when the new claim is
+// another claim.
+func resourceResourceBackendIPClaimUpdate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	client := meta.(Client)
+	log := log.FromContext(ctx)
+
+	newu := &ipamv1alpha1.IPClaim{}
+	if err := json.Unmarshal(d.GetObject(), newu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	oldu := &ipamv1alpha1.IPClaim{}
+	if err := json.Unmarshal(d.GetOldObject(), oldu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// the old object can be the config of the previous run, the allocated
+	// value is read from the backend
+	if oldu.Status.Prefix == nil {
+		if o, err := client.GetClaim(ctx, oldu, nil); err == nil {
+			if claim, ok := o.(*ipamv1alpha1.IPClaim); ok {
+				oldu.Status = claim.Status
+			}
+		}
+	}
+
+	isSame := isSameIPClaim(oldu, newu)
+	if isSame && newu.Spec.Prefix == nil && oldu.Status.Prefix != nil {
+		pinned := newu.DeepCopy()
+		pinned.Spec.Prefix = oldu.Status.Prefix
+		claimed, err := client.Claim(ctx, pinned, nil)
+		if err == nil {
+			return marshal(claimed)
+		}
+		log.Info("cannot keep prefix, claiming a new prefix", "prefix", *oldu.Status.Prefix, "error", err.Error())
+	}
+	claimed, err := client.Claim(ctx, newu, nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if !isSame {
+		if err := client.DeleteClaim(ctx, oldu, nil); err != nil {
+			return nil, diag.Errorf("cannot release claim %s, err: %s", oldu.GetName(), err.Error())
+		}
+	}
+	return marshal(claimed)
+}
+
+// resourceResourceBackendIPClaimDelete releases the claim in the backend
+func resourceResourceBackendIPClaimDelete(ctx context.Context, d *schema.ResourceObject, meta interface{}) diag.Diagnostics {
+	client := meta.(Client)
+
+	u := &ipamv1alpha1.IPClaim{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.DeleteClaim(ctx, u, nil); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// isSameIPClaim returns true when the claims claim from the same network
+// instance with the same name
+func isSameIPClaim(a, b *ipamv1alpha1.IPClaim) bool {
+	return a.GetNamespace() == b.GetNamespace() &&
+		a.GetName() == b.GetName() &&
+		a.Spec.NetworkInstance.Namespace == b.Spec.NetworkInstance.Namespace &&
+		a.Spec.NetworkInstance.Name == b.Spec.NetworkInstance.Name
+}
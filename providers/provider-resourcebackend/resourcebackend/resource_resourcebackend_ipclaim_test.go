@@ -0,0 +1,171 @@
+package resourcebackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/resourcebackend/api/v1alpha1"
+	"github.com/henderiw-nephio/kform/providers/provider-resourcebackend/resourcebackend/local"
+	ipamv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/ipam/v1alpha1"
+	"github.com/nokia/k8s-ipam/pkg/proxy/beclient"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func getIPClaim(t *testing.T, name, networkInstance string, claimLabels map[string]string) []byte {
+	claim := &ipamv1alpha1.IPClaim{}
+	claim.SetGroupVersionKind(ipamv1alpha1.IPClaimGroupVersionKind)
+	claim.SetName(name)
+	claim.SetNamespace("default")
+	claim.SetLabels(claimLabels)
+	claim.Spec.Kind = ipamv1alpha1.PrefixKindNetwork
+	claim.Spec.NetworkInstance.Name = networkInstance
+	b, err := json.Marshal(claim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func getClaimNames(t *testing.T, c Client, gk k8sschema.GroupKind) []string {
+	t.Helper()
+	claims, err := c.ListClaims(context.Background(), gk, "", labels.Everything())
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, o := range claims {
+		names = append(names, o.GetName())
+	}
+	return names
+}
+
+// pinnedFailBackend fails the claims that request a prefix, as if the prefix
+// is no longer available
+type pinnedFailBackend struct {
+	beclient.Client
+}
+
+func (r *pinnedFailBackend) Claim(ctx context.Context, cr client.Object, d any) (client.Object, error) {
+	if claim, ok := cr.(*ipamv1alpha1.IPClaim); ok && claim.Spec.Prefix != nil {
+		return nil, fmt.Errorf("prefix %s is not available", *claim.Spec.Prefix)
+	}
+	return r.Client.Claim(ctx, cr, d)
+}
+
+func TestResourceResourceBackendIPClaimUpdate(t *testing.T) {
+	cases := map[string]struct {
+		backend        beclient.Client
+		oldObj         []byte
+		newObj         []byte
+		expectedPrefix *string
+		expectedClaims []string
+	}{
+		"KeepPrefix": {
+			backend:        newLocalBackend(t, ""),
+			oldObj:         getIPClaim(t, "a", "vpc1", nil),
+			newObj:         getIPClaim(t, "a", "vpc1", map[string]string{"app": "x"}),
+			expectedPrefix: ptr("10.0.0.2/24"),
+			expectedClaims: []string{"a"},
+		},
+		"PrefixNotAvailable": {
+			backend:        &pinnedFailBackend{Client: newLocalBackend(t, "")},
+			oldObj:         getIPClaim(t, "a", "vpc1", nil),
+			newObj:         getIPClaim(t, "a", "vpc1", nil),
+			expectedClaims: []string{"a"},
+		},
+		"OtherNetworkInstance": {
+			backend:        newLocalBackend(t, ""),
+			oldObj:         getIPClaim(t, "a", "vpc1", nil),
+			newObj:         getIPClaim(t, "a", "vpc2", nil),
+			expectedClaims: []string{"a"},
+		},
+		// the claim of the old name is released in the backend
+		"Renamed": {
+			backend:        newLocalBackend(t, ""),
+			oldObj:         getIPClaim(t, "a", "vpc1", nil),
+			newObj:         getIPClaim(t, "b", "vpc1", nil),
+			expectedClaims: []string{"b"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newClient(tc.backend)
+			if _, diags := resourceResourceBackendIPClaimCreate(ctx, &schema.ResourceObject{Obj: tc.oldObj}, c); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			b, diags := resourceResourceBackendIPClaimUpdate(ctx, &schema.ResourceObject{Obj: tc.newObj, OldObj: tc.oldObj}, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			claim := &ipamv1alpha1.IPClaim{}
+			if err := json.Unmarshal(b, claim); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.expectedPrefix, claim.Spec.Prefix)
+			assert.NotNil(t, claim.Status.Prefix)
+			assert.Equal(t, tc.expectedClaims, getClaimNames(t, c, ipamv1alpha1.IPClaimGroupVersionKind.GroupKind()))
+		})
+	}
+}
+
+func TestResourceResourceBackendIPClaimDelete(t *testing.T) {
+	ctx := context.Background()
+	obj := getIPClaim(t, "a", "vpc1", nil)
+
+	c := newClient(beclient.NewMock())
+	if _, diags := resourceResourceBackendIPClaimCreate(ctx, &schema.ResourceObject{Obj: obj}, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	// the mock of the backend does not support releasing claims
+	if diags := resourceResourceBackendIPClaimDelete(ctx, &schema.ResourceObject{Obj: obj}, c); !diags.HasError() {
+		t.Errorf("want error, got none")
+	}
+	assert.Equal(t, []string{"a"}, getClaimNames(t, c, ipamv1alpha1.IPClaimGroupVersionKind.GroupKind()))
+
+	// the claim is released in the backend, a new client of the backend
+	// file no longer lists it
+	file := filepath.Join(t.TempDir(), "claims.json")
+	c = newClient(newLocalBackend(t, file))
+	if _, diags := resourceResourceBackendIPClaimCreate(ctx, &schema.ResourceObject{Obj: obj}, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	if diags := resourceResourceBackendIPClaimDelete(ctx, &schema.ResourceObject{Obj: obj}, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	assert.Equal(t, []string{}, getClaimNames(t, c, ipamv1alpha1.IPClaimGroupVersionKind.GroupKind()))
+	assert.Equal(t, []string{}, getClaimNames(t, newClient(newLocalBackend(t, file)), ipamv1alpha1.IPClaimGroupVersionKind.GroupKind()))
+}
+
+// newLocalBackend returns a local backend with the network instances vpc1
+// and vpc2 and the vlan indexes vpc1 and vpc2, the claims are persisted to
+// the file when it is set
+func newLocalBackend(t *testing.T, file string) beclient.Client {
+	be, err := local.New(local.Config{
+		File: file,
+		NetworkInstances: []v1alpha1.NetworkInstancePool{
+			{Name: "vpc1", Prefixes: []v1alpha1.PoolPrefix{{Prefix: "10.0.0.0/24"}}},
+			{Name: "vpc2", Prefixes: []v1alpha1.PoolPrefix{{Prefix: "10.0.1.0/24"}}},
+		},
+		VLANIndexes: []v1alpha1.VLANIndexPool{
+			{Name: "vpc1", MinID: ptr[uint16](10), MaxID: ptr[uint16](100)},
+			{Name: "vpc2", MinID: ptr[uint16](10), MaxID: ptr[uint16](100)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return be
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
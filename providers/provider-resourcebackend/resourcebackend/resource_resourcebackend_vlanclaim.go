@@ -7,8 +7,8 @@ import (
 
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw/logger/log"
 	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
-	"github.com/nokia/k8s-ipam/pkg/proxy/beclient"
 )
 
 func resourceResourceBackendVLANClaim() *schema.Resource {
@@ -16,16 +16,20 @@ func resourceResourceBackendVLANClaim() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceResourceBackendVLANClaimCreate,
 		ReadContext:   resourceResourceBackendVLANClaimRead,
+		UpdateContext: resourceResourceBackendVLANClaimUpdate,
+		DeleteContext: resourceResourceBackendVLANClaimDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create:  &defaultTimout,
 			Read:    &defaultTimout,
+			Update:  &defaultTimout,
+			Delete:  &defaultTimout,
 			Default: &defaultTimout,
 		},
 	}
 }
 
 func resourceResourceBackendVLANClaimCreate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
-	client := meta.(beclient.Client)
+	client := meta.(Client)
 
 	u := &vlanv1alpha1.VLANClaim{}
 	if err := json.Unmarshal(d.GetObject(), u); err != nil {
@@ -45,7 +49,7 @@ func resourceResourceBackendVLANClaimCreate(ctx context.Context, d *schema.Resou
 }
 
 func resourceResourceBackendVLANClaimRead(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
-	client := meta.(beclient.Client)
+	client := meta.(Client)
 
 	u := &vlanv1alpha1.VLANClaim{}
 	if err := json.Unmarshal(d.GetObject(), u); err != nil {
@@ -61,3 +65,75 @@ func resourceResourceBackendVLANClaimRead(ctx context.Context, d *schema.Resourc
 	}
 	return b, nil
 }
+
+// resourceResourceBackendVLANClaimUpdate claims the new claim, the VLAN ID of
+// the old claim is kept when the new claim does not request a VLAN ID or
+// range and the VLAN ID is still available. The old claim is released when
+// the new claim is another claim.
+func resourceResourceBackendVLANClaimUpdate(ctx context.Context, d *schema.ResourceObject, meta interface{}) ([]byte, diag.Diagnostics) {
+	client := meta.(Client)
+	log := log.FromContext(ctx)
+
+	newu := &vlanv1alpha1.VLANClaim{}
+	if err := json.Unmarshal(d.GetObject(), newu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	oldu := &vlanv1alpha1.VLANClaim{}
+	if err := json.Unmarshal(d.GetOldObject(), oldu); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// the old object can be the config of the previous run, the allocated
+	// value is read from the backend
+	if oldu.Status.VLANID == nil {
+		if o, err := client.GetClaim(ctx, oldu, nil); err == nil {
+			if claim, ok := o.(*vlanv1alpha1.VLANClaim); ok {
+				oldu.Status = claim.Status
+			}
+		}
+	}
+
+	isSame := isSameVLANClaim(oldu, newu)
+	if isSame && newu.Spec.VLANID == nil && newu.Spec.VLANRange == nil && oldu.Status.VLANID != nil {
+		pinned := newu.DeepCopy()
+		pinned.Spec.VLANID = oldu.Status.VLANID
+		claimed, err := client.Claim(ctx, pinned, nil)
+		if err == nil {
+			return marshal(claimed)
+		}
+		log.Info("cannot keep vlan id, claiming a new vlan id", "vlanID", *oldu.Status.VLANID, "error", err.Error())
+	}
+	claimed, err := client.Claim(ctx, newu, nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if !isSame {
+		if err := client.DeleteClaim(ctx, oldu, nil); err != nil {
+			return nil, diag.Errorf("cannot release claim %s, err: %s", oldu.GetName(), err.Error())
+		}
+	}
+	return marshal(claimed)
+}
+
+// resourceResourceBackendVLANClaimDelete releases the claim in the backend
+func resourceResourceBackendVLANClaimDelete(ctx context.Context, d *schema.ResourceObject, meta interface{}) diag.Diagnostics {
+	client := meta.(Client)
+
+	u := &vlanv1alpha1.VLANClaim{}
+	if err := json.Unmarshal(d.GetObject(), u); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.DeleteClaim(ctx, u, nil); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// isSameVLANClaim returns true when the claims claim from the same VLAN
+// index with the same name
+func isSameVLANClaim(a, b *vlanv1alpha1.VLANClaim) bool {
+	return a.GetNamespace() == b.GetNamespace() &&
+		a.GetName() == b.GetName() &&
+		a.Spec.VLANIndex.Namespace == b.Spec.VLANIndex.Namespace &&
+		a.Spec.VLANIndex.Name == b.Spec.VLANIndex.Name
+}
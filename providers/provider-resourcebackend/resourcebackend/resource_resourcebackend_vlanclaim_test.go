@@ -0,0 +1,93 @@
+package resourcebackend
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	vlanv1alpha1 "github.com/nokia/k8s-ipam/apis/resource/vlan/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func getVLANClaim(t *testing.T, name, vlanIndex string, vlanID *uint16, claimLabels map[string]string) []byte {
+	claim := &vlanv1alpha1.VLANClaim{}
+	claim.SetGroupVersionKind(vlanv1alpha1.VLANClaimGroupVersionKind)
+	claim.SetName(name)
+	claim.SetNamespace("default")
+	claim.SetLabels(claimLabels)
+	claim.Spec.VLANIndex.Name = vlanIndex
+	claim.Spec.VLANID = vlanID
+	b, err := json.Marshal(claim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestResourceResourceBackendVLANClaimUpdate(t *testing.T) {
+	cases := map[string]struct {
+		oldObj         []byte
+		newObj         []byte
+		expectedVLANID *uint16
+		expectedClaims []string
+	}{
+		"KeepVLANID": {
+			oldObj:         getVLANClaim(t, "a", "vpc1", nil, nil),
+			newObj:         getVLANClaim(t, "a", "vpc1", nil, map[string]string{"app": "x"}),
+			expectedVLANID: ptr[uint16](10),
+			expectedClaims: []string{"a"},
+		},
+		"RequestedVLANID": {
+			oldObj:         getVLANClaim(t, "a", "vpc1", nil, nil),
+			newObj:         getVLANClaim(t, "a", "vpc1", ptr[uint16](20), nil),
+			expectedVLANID: ptr[uint16](20),
+			expectedClaims: []string{"a"},
+		},
+		"OtherVLANIndex": {
+			oldObj:         getVLANClaim(t, "a", "vpc1", nil, nil),
+			newObj:         getVLANClaim(t, "a", "vpc2", nil, nil),
+			expectedClaims: []string{"a"},
+		},
+		// the claim of the old name is released in the backend
+		"Renamed": {
+			oldObj:         getVLANClaim(t, "a", "vpc1", nil, nil),
+			newObj:         getVLANClaim(t, "b", "vpc1", nil, nil),
+			expectedClaims: []string{"b"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newClient(newLocalBackend(t, ""))
+			if _, diags := resourceResourceBackendVLANClaimCreate(ctx, &schema.ResourceObject{Obj: tc.oldObj}, c); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			b, diags := resourceResourceBackendVLANClaimUpdate(ctx, &schema.ResourceObject{Obj: tc.newObj, OldObj: tc.oldObj}, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Error())
+			}
+			claim := &vlanv1alpha1.VLANClaim{}
+			if err := json.Unmarshal(b, claim); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.expectedVLANID, claim.Spec.VLANID)
+			assert.NotNil(t, claim.Status.VLANID)
+			assert.Equal(t, tc.expectedClaims, getClaimNames(t, c, vlanv1alpha1.VLANClaimGroupVersionKind.GroupKind()))
+		})
+	}
+}
+
+func TestResourceResourceBackendVLANClaimDelete(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(newLocalBackend(t, ""))
+	obj := getVLANClaim(t, "a", "vpc1", nil, nil)
+	if _, diags := resourceResourceBackendVLANClaimCreate(ctx, &schema.ResourceObject{Obj: obj}, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	if diags := resourceResourceBackendVLANClaimDelete(ctx, &schema.ResourceObject{Obj: obj}, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Error())
+	}
+	assert.Equal(t, []string{}, getClaimNames(t, c, vlanv1alpha1.VLANClaimGroupVersionKind.GroupKind()))
+}
@@ -13,14 +13,18 @@ import (
 	docs "github.com/henderiw-nephio/kform/internal/docs/generated/applydocs"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/fns"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/inventory"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/providers"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/record"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
 	"github.com/henderiw-nephio/kform/tools/pkg/fsys"
 	"github.com/henderiw-nephio/kform/tools/pkg/pkgio"
 	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
+	"github.com/henderiw-nephio/kform/tools/pkg/store"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/parser"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cctx"
 )
 
 // NewRunner returns a command runner.
@@ -81,12 +85,23 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 	}
 	p.Parse(ctx, false)
 	if parserecorder.Get().HasError() {
-		parserecorder.Print()
+		recorder.PrintDiagnostics(os.Stdout, parserecorder.List())
 		log.Error("failed parsing modules", "error", parserecorder.Get().Error())
 		return parserecorder.Get().Error()
 	}
-	parserecorder.Print()
-	providerInventory, err := p.InitProviderInventory(ctx)
+	recorder.PrintDiagnostics(os.Stdout, parserecorder.List())
+	// the provider manager starts each provider once and stops all of them
+	// when apply exits, also when interrupted
+	providerManager := providers.NewManager(&providers.Config{
+		Store:    cctx.GetContextValue[store.Store](ctx, types.CtxKeyStore),
+		Insecure: cctx.GetContextValue[bool](ctx, types.CtxKeyInsecurePlugins),
+		StateDir: filepath.Join(r.rootPath, ".kform"),
+		// the built-in providers the parser added provider configs for
+		BuiltinProviders: cctx.GetContextValue[types.BuiltinProviders](ctx, types.CtxKeyBuiltinProviders),
+	})
+	defer providerManager.Close(context.WithoutCancel(ctx))
+
+	providerInventory, err := p.InitProviderInventory(ctx, providerManager)
 	if err != nil {
 		log.Error("failed initializing provider inventory", "error", err)
 		return err
@@ -116,6 +131,7 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 		Recorder:          runrecorder,
 		ProviderInstances: providerInstances,
 		ProviderInventory: providerInventory,
+		ProviderManager:   providerManager,
 	})
 	log.Info("executing provider runner DAG")
 	if err := rmfn.Run(ctx, &types.VertexContext{
@@ -131,6 +147,18 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 	}
 	log.Info("success executing provider DAG")
 
+	// the inventory holds the resources applied by the previous run, the
+	// resources that left the config are pruned after a successful run
+	inv, err := inventory.Read(r.rootPath)
+	if err != nil {
+		log.Error("failed reading inventory", "error", err)
+		return err
+	}
+	runID := inventory.NewRunID()
+	applied := cache.New[inventory.Resource]()
+	// the outputs of the module calls are kept in the inventory for the console
+	outputs := cache.New[vars.Variable]()
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -151,6 +179,10 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 			Recorder:          runrecorder,
 			ProviderInstances: providerInstances,
 			ProviderInventory: providerInventory,
+			RunID:             runID,
+			Inventory:         inv,
+			Applied:           applied,
+			Outputs:           outputs,
 		})
 
 		log.Info("executing module")
@@ -169,6 +201,26 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 
 		log.Info("success executing module")
 
+		// a failed run keeps the resources of the previous run, since they
+		// might not have been applied
+		if runrecorder.Get().HasError() {
+			log.Error("module run failed, skip pruning", "error", runrecorder.Get().Error())
+			if err := addOutputs(inv.Merge(runID, applied.List()), outputs).Write(r.rootPath); err != nil {
+				errCh <- err
+				return
+			}
+		} else {
+			newInv, err := inv.Prune(ctx, runID, applied.List(), providerInstances)
+			if err := addOutputs(newInv, outputs).Write(r.rootPath); err != nil {
+				errCh <- err
+				return
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+
 		fsys := fsys.NewDiskFS(r.rootPath)
 		if err := fsys.MkdirAll("out"); err != nil {
 			errCh <- err
@@ -197,16 +249,16 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 		log.Error("exec failed", "err", err)
 	}
 
-	providersList := providerInstances.List()
-	fmt.Println("exec Done", len(providersList))
-	for nsn, provider := range providersList {
-		if provider != nil {
-			provider.Close(ctx)
-			log.Info("closing provider", "nsn", nsn)
-			continue
-		}
-		log.Info("closing provider nil", "nsn", nsn)
-	}
+	fmt.Println("exec Done", len(providerInstances.List()))
 
 	return nil
 }
+
+// addOutputs adds the outputs of the module calls of the root module to the
+// inventory
+func addOutputs(inv *inventory.Inventory, outputs cache.Cache[vars.Variable]) *inventory.Inventory {
+	for nsn, v := range outputs.List() {
+		inv.AddOutputs(nsn.Name, v.Data)
+	}
+	return inv
+}
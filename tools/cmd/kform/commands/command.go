@@ -11,10 +11,14 @@ import (
 
 	"github.com/henderiw-nephio/kform/tools/cmd/kform/commands/apply"
 	"github.com/henderiw-nephio/kform/tools/cmd/kform/commands/auth"
+	"github.com/henderiw-nephio/kform/tools/cmd/kform/commands/console"
 	initcmd "github.com/henderiw-nephio/kform/tools/cmd/kform/commands/init"
 	"github.com/henderiw-nephio/kform/tools/cmd/kform/commands/pkg"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/providers/builtin"
 	"github.com/henderiw-nephio/kform/tools/pkg/fsys"
 	"github.com/henderiw-nephio/kform/tools/pkg/store"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cctx"
 )
 
 const (
@@ -26,7 +30,8 @@ const (
 )
 
 var (
-	configFile string
+	configFile      string
+	insecurePlugins bool
 )
 
 func GetMain(ctx context.Context) *cobra.Command {
@@ -46,9 +51,24 @@ func GetMain(ctx context.Context) *cobra.Command {
 			// initialize viper settings
 			initConfig()
 			// create package store if it does not exist
-			_, err := store.New(cmd.Context(),
+			s, err := store.New(cmd.Context(),
 				filepath.Join(xdg.ConfigHome, defaultConfigFileSubDir, defaultDBPath))
-			return err
+			if err != nil {
+				return err
+			}
+			// the store, the insecure plugins flag and the built-in providers
+			// are shared with the sub commands through the context
+			ctx := context.WithValue(cmd.Context(), types.CtxKeyStore, s)
+			ctx = context.WithValue(ctx, types.CtxKeyInsecurePlugins, insecurePlugins)
+			ctx = context.WithValue(ctx, types.CtxKeyBuiltinProviders, builtin.NewRegistry())
+			cmd.SetContext(ctx)
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			if s := cctx.GetContextValue[store.Store](cmd.Context(), types.CtxKeyStore); s != nil {
+				return s.Close()
+			}
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			h, err := cmd.Flags().GetBool("help")
@@ -64,9 +84,11 @@ func GetMain(ctx context.Context) *cobra.Command {
 
 	cmd.AddCommand(initcmd.NewCommand(ctx, version))
 	cmd.AddCommand(apply.NewCommand(ctx, version))
+	cmd.AddCommand(console.NewCommand(ctx, version))
 	cmd.AddCommand(auth.NewCommand(ctx, version))
 	cmd.AddCommand(pkg.NewCommand(ctx, version))
 	cmd.PersistentFlags().StringVar(&configFile, "config", "c", fmt.Sprintf("Default config file (%s/%s/%s.%s)", xdg.ConfigHome, defaultConfigFileSubDir, defaultConfigFileName, defaultConfigFileNameExt))
+	cmd.PersistentFlags().BoolVar(&insecurePlugins, "insecure-plugins", false, "skip the checksum verification of the providers and mutual TLS, only use this to develop providers")
 
 	return cmd
 }
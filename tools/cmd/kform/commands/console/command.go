@@ -0,0 +1,118 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/henderiw/logger/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	docs "github.com/henderiw-nephio/kform/internal/docs/generated/consoledocs"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/tools/pkg/console"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/fns"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/inventory"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/fsys"
+	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/parser"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, version string) *Runner {
+	r := &Runner{}
+	cmd := &cobra.Command{
+		Use:     "console DIR [flags]",
+		Args:    cobra.ExactArgs(1),
+		Short:   docs.ConsoleShort,
+		Long:    docs.ConsoleShort + "\n" + docs.ConsoleLong,
+		Example: docs.ConsoleExamples,
+		RunE:    r.runE,
+	}
+
+	r.Command = cmd
+	return r
+}
+
+func NewCommand(ctx context.Context, version string) *cobra.Command {
+	return NewRunner(ctx, version).Command
+}
+
+type Runner struct {
+	Command  *cobra.Command
+	rootPath string
+}
+
+func (r *Runner) runE(c *cobra.Command, args []string) error {
+	// the console only shows the warnings and errors of the parser
+	ctx := log.IntoContext(c.Context(), log.NewLogger(&log.HandlerOptions{Name: "kform-logger", MinLevel: slog.LevelWarn}))
+
+	r.rootPath = args[0]
+	if err := fsys.ValidateDirPath(r.rootPath); err != nil {
+		return err
+	}
+	if _, err := os.Stat(r.rootPath); err != nil {
+		return fmt.Errorf("cannot run console, path does not exist: %s", r.rootPath)
+	}
+
+	parserecorder := recorder.New[diag.Diagnostic]()
+	ctx = context.WithValue(ctx, types.CtxKeyRecorder, parserecorder)
+
+	p, err := parser.NewKformParser(ctx, r.rootPath)
+	if err != nil {
+		return err
+	}
+	p.Parse(ctx, false)
+	recorder.PrintDiagnostics(os.Stdout, parserecorder.List())
+	if parserecorder.Get().HasError() {
+		return parserecorder.Get().Error()
+	}
+	rm, err := p.GetRootModule(ctx)
+	if err != nil {
+		return fmt.Errorf("failed parsing no root module found")
+	}
+
+	varsCache := cache.New[vars.Variable]()
+	// the inputs get their defaults like they do in apply
+	inputFn := fns.NewInputFn(&fns.Config{RootModuleName: rm.NSN.Name, Vars: varsCache})
+	for _, vCtx := range rm.DAG.GetVertices() {
+		if vCtx.BlockType != types.BlockTypeInput {
+			continue
+		}
+		if err := inputFn.Run(ctx, vCtx, map[string]any{}); err != nil {
+			return err
+		}
+	}
+	// the resources and module outputs of the previous apply are the state
+	// of the module
+	inv, err := inventory.Read(r.rootPath)
+	if err != nil {
+		return err
+	}
+	if err := console.LoadInventory(ctx, varsCache, inv, rm.NSN.Name); err != nil {
+		return err
+	}
+	// the locals are evaluated against the inputs and the state
+	console.EvalLocals(ctx, varsCache, rm.DAG, rm.NSN.Name)
+
+	con := console.New(varsCache)
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return con.RunLines(ctx, os.Stdin, os.Stdout)
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, oldState)
+	return con.Run(ctx, struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout})
+}
@@ -62,8 +62,8 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 	}
 
 	// initialize the recorder
-	recorder := recorder.New[diag.Diagnostic]()
-	ctx = context.WithValue(ctx, types.CtxKeyRecorder, recorder)
+	parserecorder := recorder.New[diag.Diagnostic]()
+	ctx = context.WithValue(ctx, types.CtxKeyRecorder, parserecorder)
 
 	// create a kform parser
 	log.Info("parsing modules")
@@ -72,12 +72,12 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 		return err
 	}
 	p.Parse(ctx, true)
-	if recorder.Get().HasError() {
-		recorder.Print()
-		log.Error("failed parsing modules", "error", recorder.Get().Error())
-		return recorder.Get().Error()
+	if parserecorder.Get().HasError() {
+		recorder.PrintDiagnostics(os.Stdout, parserecorder.List())
+		log.Error("failed parsing modules", "error", parserecorder.Get().Error())
+		return parserecorder.Get().Error()
 	}
-	recorder.Print()
+	recorder.PrintDiagnostics(os.Stdout, parserecorder.List())
 
 	// init and/or restore backend (todo)
 	// download module (todo for remote download)
@@ -0,0 +1,92 @@
+package console
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Complete completes the reference before the position in the line, a
+// reference completes to the bound references, e.g. $input.int completes to
+// $input.interface, and a field completes to the fields of the value of its
+// parent, e.g. $input.interface[0].sp completes to $input.interface[0].spec.
+// It returns the new line and position and the candidates of the completion.
+func (r *Console) Complete(ctx context.Context, line string, pos int) (string, int, []string) {
+	start := pos
+	for start > 0 && isRefChar(line[start-1]) {
+		start--
+	}
+	token := line[start:pos]
+	path := strings.TrimPrefix(token, "$")
+	if path == "" {
+		return line, pos, nil
+	}
+
+	candidates := []string{}
+	for ref := range r.getVars() {
+		if strings.HasPrefix(ref, path) {
+			candidates = append(candidates, ref)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = r.getFields(ctx, path)
+	}
+	if len(candidates) == 0 {
+		return line, pos, nil
+	}
+	sort.Strings(candidates)
+
+	completion := getCommonPrefix(candidates)
+	if len(completion) <= len(path) {
+		return line, pos, candidates
+	}
+	newToken := token[:len(token)-len(path)] + completion
+	return line[:start] + newToken + line[pos:], start + len(newToken), candidates
+}
+
+// getFields returns the paths of the fields of the value of the parent of
+// the path that start with the last field of the path
+func (r *Console) getFields(ctx context.Context, path string) []string {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return nil
+	}
+	parent, prefix := path[:i], path[i+1:]
+	v, err := r.renderer.Render(quiet(ctx), "$"+parent)
+	if err != nil {
+		return nil
+	}
+	fields := []string{}
+	switch v := v.(type) {
+	case map[string]any:
+		for k := range v {
+			if strings.HasPrefix(k, prefix) {
+				fields = append(fields, parent+"."+k)
+			}
+		}
+	case map[any]any:
+		for k := range v {
+			if k, ok := k.(string); ok && strings.HasPrefix(k, prefix) {
+				fields = append(fields, parent+"."+k)
+			}
+		}
+	}
+	return fields
+}
+
+// isRefChar returns true for the characters of a reference, including the
+// indexes of a list, e.g. $input.interface[0].spec
+func isRefChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '$' || c == '[' || c == ']' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func getCommonPrefix(s []string) string {
+	prefix := s[0]
+	for _, x := range s[1:] {
+		for !strings.HasPrefix(x, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
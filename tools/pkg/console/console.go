@@ -0,0 +1,193 @@
+// Package console provides the read-eval-print loop of kform console, which
+// evaluates expressions against the vars of a module with the renderer that
+// renders the config of the blocks.
+package console
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/render"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/henderiw/logger/log"
+	"golang.org/x/term"
+	"sigs.k8s.io/yaml"
+)
+
+const prompt = "> "
+
+const help = `Enter an expression to evaluate it, e.g.
+  size(input.interface)
+  $input.interface[0].spec.networkInstance.name
+  prefix-${input.interface[0].metadata.name}
+
+Commands:
+  :vars   list the references that are bound
+  :help   show this help
+  :quit   exit the console
+
+Use tab to complete a reference or a field of its value.`
+
+type Console struct {
+	vars     cache.Cache[vars.Variable]
+	renderer *render.Renderer
+}
+
+// New returns a console that evaluates the expressions with the vars
+func New(varsCache cache.Cache[vars.Variable]) *Console {
+	return &Console{
+		vars:     varsCache,
+		renderer: &render.Renderer{Vars: varsCache},
+	}
+}
+
+// Run runs the read-eval-print loop on the terminal until :quit or the end
+// of the input, e.g. ctrl-d
+func (r *Console) Run(ctx context.Context, rw io.ReadWriter) error {
+	t := term.NewTerminal(rw, prompt)
+	t.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		newLine, newPos, candidates := r.Complete(ctx, line, pos)
+		if len(candidates) > 1 && newLine == line {
+			fmt.Fprintln(t, strings.Join(candidates, "  "))
+		}
+		return newLine, newPos, true
+	}
+	fmt.Fprintln(t, "kform console, enter :help for help")
+	for {
+		line, err := t.ReadLine()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if isQuit(line) {
+			return nil
+		}
+		r.print(ctx, t, line)
+	}
+}
+
+// RunLines evaluates the lines of the input, e.g. when the input is no
+// terminal
+func (r *Console) RunLines(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if isQuit(scanner.Text()) {
+			return nil
+		}
+		r.print(ctx, out, scanner.Text())
+	}
+	return scanner.Err()
+}
+
+func isQuit(line string) bool {
+	switch strings.TrimSpace(line) {
+	case ":quit", ":q", ":exit":
+		return true
+	}
+	return false
+}
+
+func (r *Console) print(ctx context.Context, out io.Writer, line string) {
+	s, err := r.Eval(ctx, line)
+	if err != nil {
+		fmt.Fprintf(out, "error: %s\n", err.Error())
+		return
+	}
+	if s != "" {
+		fmt.Fprintln(out, s)
+	}
+}
+
+// Eval evaluates the line, which is a command, e.g. :vars, or an expression.
+// A line with $ is rendered as a config value, e.g.
+// $input.interface[0].spec or prefix-${input.interface[0].metadata.name},
+// every other line is an expression, e.g. size(input.interface). The value is
+// returned as yaml.
+func (r *Console) Eval(ctx context.Context, line string) (string, error) {
+	line = strings.TrimSpace(line)
+	switch {
+	case line == "":
+		return "", nil
+	case strings.HasPrefix(line, ":"):
+		return r.command(line)
+	case !strings.Contains(line, "$"):
+		line = "${" + line + "}"
+	}
+	v, err := r.renderer.Render(quiet(ctx), line)
+	if err != nil {
+		return "", err
+	}
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
+
+// quiet returns the context with a logger that discards the logs of the
+// renderer, the console prints the errors of the evaluation
+func quiet(ctx context.Context) context.Context {
+	return log.IntoContext(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (r *Console) command(line string) (string, error) {
+	switch line {
+	case ":vars":
+		return r.listVars(), nil
+	case ":help":
+		return help, nil
+	default:
+		return "", fmt.Errorf("unknown command %s, enter :help for help", line)
+	}
+}
+
+// listVars returns the bound references with the number of their values
+func (r *Console) listVars() string {
+	refs := r.getVars()
+	if len(refs) == 0 {
+		return "no vars are bound"
+	}
+	names := make([]string, 0, len(refs))
+	for ref := range refs {
+		names = append(names, ref)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for i, ref := range names {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("%s (%d)", ref, len(refs[ref])))
+	}
+	return sb.String()
+}
+
+// getVars returns the values of the bound references, the outputs of a
+// module are stored in the var of the module
+func (r *Console) getVars() map[string][]any {
+	refs := map[string][]any{}
+	for nsn, v := range r.vars.List() {
+		if strings.HasPrefix(nsn.Name, celenv.NamespaceModule+".") {
+			for output, d := range v.Data {
+				refs[fmt.Sprintf("%s.%s", nsn.Name, output)] = d
+			}
+			continue
+		}
+		if d, ok := v.Data[vars.DummyKey]; ok {
+			refs[nsn.Name] = d
+		}
+	}
+	return refs
+}
@@ -0,0 +1,111 @@
+package console
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConsole(ctx context.Context) *Console {
+	varsCache := cache.New[vars.Variable]()
+	varsCache.Add(ctx, cache.NSN{Name: "input.interface"}, vars.Variable{Data: map[string][]any{
+		vars.DummyKey: {
+			map[string]any{
+				"metadata": map[string]any{"name": "n3"},
+				"spec": map[string]any{
+					"networkInstance": map[string]any{"name": "vpc-ran"},
+					"cniType":         "sriov",
+				},
+			},
+		},
+	}})
+	varsCache.Add(ctx, cache.NSN{Name: "input.context"}, vars.Variable{Data: map[string][]any{
+		vars.DummyKey: {"a", "b"},
+	}})
+	return New(varsCache)
+}
+
+func TestEval(t *testing.T) {
+	cases := map[string]struct {
+		line      string
+		want      string
+		expectErr bool
+	}{
+		"Expression": {
+			line: "size(input.context)",
+			want: "2",
+		},
+		"Reference": {
+			line: "$input.interface[0].spec.networkInstance.name",
+			want: "vpc-ran",
+		},
+		"Template": {
+			line: "prefix-${input.interface[0].metadata.name}",
+			want: "prefix-n3",
+		},
+		"Vars": {
+			line: ":vars",
+			want: "input.context (2)\ninput.interface (1)",
+		},
+		"Unresolved": {
+			line:      "$input.interface[0].spec.networkInstnace.name",
+			expectErr: true,
+		},
+		"UnknownCommand": {
+			line:      ":unknown",
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			got, err := newTestConsole(ctx).Eval(ctx, tc.line)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	cases := map[string]struct {
+		line           string
+		want           string
+		wantCandidates []string
+	}{
+		"Namespace": {
+			line:           "$inp",
+			want:           "$input.",
+			wantCandidates: []string{"input.context", "input.interface"},
+		},
+		"Reference": {
+			line:           "size(input.int",
+			want:           "size(input.interface",
+			wantCandidates: []string{"input.interface"},
+		},
+		"Field": {
+			line:           "$input.interface[0].spec.net",
+			want:           "$input.interface[0].spec.networkInstance",
+			wantCandidates: []string{"input.interface[0].spec.networkInstance"},
+		},
+		"NoCandidates": {
+			line: "$input.interface[0].spec.x",
+			want: "$input.interface[0].spec.x",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			got, pos, candidates := newTestConsole(ctx).Complete(ctx, tc.line, len(tc.line))
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, len(tc.want), pos)
+			assert.Equal(t, tc.wantCandidates, candidates)
+		})
+	}
+}
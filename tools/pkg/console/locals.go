@@ -0,0 +1,69 @@
+package console
+
+import (
+	"context"
+	"sort"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/dag"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/fns"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/record"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/henderiw/logger/log"
+)
+
+// EvalLocals adds the locals of the module to the vars, like apply does. A
+// local is evaluated after the locals it depends on, the inputs, resources
+// and module outputs it refers to are expected in the vars. A local that
+// cannot be evaluated, e.g. since a resource was never applied, is skipped
+// with a warning.
+func EvalLocals(ctx context.Context, varsCache cache.Cache[vars.Variable], d dag.DAG[*types.VertexContext], moduleName string) {
+	log := log.FromContext(ctx)
+	handler := fns.NewExecHandler(ctx, &fns.Config{
+		RootModuleName: moduleName,
+		ModuleName:     moduleName,
+		Vars:           varsCache,
+		Recorder:       recorder.New[record.Record](),
+	})
+	locals := map[string]*types.VertexContext{}
+	for name, vCtx := range d.GetVertices() {
+		if vCtx.BlockType == types.BlockTypeLocal {
+			locals[name] = vCtx
+		}
+	}
+
+	done := map[string]bool{}
+	var eval func(name string) bool
+	eval = func(name string) bool {
+		if ok, visited := done[name]; visited {
+			return ok
+		}
+		// the parser rejects cycles, marking the local upfront guards the
+		// recursion anyhow
+		done[name] = false
+		vCtx := locals[name]
+		for dep := range vCtx.GetBlockDependencies() {
+			if _, ok := locals[dep]; ok && !eval(dep) {
+				log.Warn("cannot evaluate local", "name", name, "dependency", dep)
+				return false
+			}
+		}
+		if !handler.BlockRun(ctx, name, vCtx) {
+			log.Warn("cannot evaluate local", "name", name)
+			return false
+		}
+		done[name] = true
+		return true
+	}
+
+	names := make([]string, 0, len(locals))
+	for name := range locals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		eval(name)
+	}
+}
@@ -0,0 +1,97 @@
+package console
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/dag"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/inventory"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLocal(name string, value string, deps ...string) *types.VertexContext {
+	dependencies := map[string]string{}
+	for _, dep := range deps {
+		dependencies[dep] = name
+	}
+	return &types.VertexContext{
+		FileName:   "a.yaml",
+		ModuleName: "module.a",
+		BlockType:  types.BlockTypeLocal,
+		BlockName:  name,
+		BlockContext: types.KformBlockContext{
+			Attributes: &types.KformBlockAttributes{Schema: &types.KformBlockSchema{}},
+			Value:      map[string]any{"name": value},
+		},
+		Dependencies: dependencies,
+	}
+}
+
+func TestEvalLocals(t *testing.T) {
+	cases := map[string]struct {
+		locals map[string]*types.VertexContext
+		want   map[string]any
+	}{
+		"ModuleOutput": {
+			locals: map[string]*types.VertexContext{
+				"local.name": newLocal("local.name", "${module.child.name[0]}", "module.child"),
+			},
+			want: map[string]any{
+				"local.name": "a",
+			},
+		},
+		"Dependency": {
+			locals: map[string]*types.VertexContext{
+				"local.b": newLocal("local.b", "${local.a[0].name}-b", "local.a"),
+				"local.a": newLocal("local.a", "${module.child.name[0]}", "module.child"),
+			},
+			want: map[string]any{
+				"local.a": "a",
+				"local.b": "a-b",
+			},
+		},
+		"Unresolved": {
+			locals: map[string]*types.VertexContext{
+				"local.b": newLocal("local.b", "${local.a[0].name}-b", "local.a"),
+				"local.a": newLocal("local.a", "${module.other.name[0]}", "module.other"),
+				"local.c": newLocal("local.c", "${module.child.name[0]}", "module.child"),
+			},
+			want: map[string]any{
+				"local.c": "a",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			d := dag.New[*types.VertexContext]()
+			for name, vCtx := range tc.locals {
+				d.AddVertex(ctx, name, vCtx)
+			}
+			inv := &inventory.Inventory{}
+			inv.AddOutputs("module.child", map[string][]any{"name": {"a"}})
+
+			varsCache := cache.New[vars.Variable]()
+			if err := LoadInventory(ctx, varsCache, inv, "module.a"); err != nil {
+				t.Fatal(err)
+			}
+			EvalLocals(ctx, varsCache, d, "module.a")
+
+			for name := range tc.locals {
+				v, err := varsCache.Get(cache.NSN{Name: name})
+				want, ok := tc.want[name]
+				if !ok {
+					assert.Error(t, err, name)
+					continue
+				}
+				if err != nil {
+					t.Fatalf("want local %s, err: %s", name, err.Error())
+				}
+				assert.Equal(t, []any{map[string]any{"apiVersion": "", "kind": "", "name": want}}, v.Data[vars.DummyKey], name)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/inventory"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+)
+
+// instance is a resource instance of the inventory
+type instance struct {
+	// index is the count index of the instance, -1 for a forEach key
+	index int
+	key   string
+	value any
+}
+
+// LoadInventory adds the resources of the module that the previous run
+// applied to the vars, e.g. the resource module.example.kubernetes_manifest.x[0]
+// of the inventory is the first value of kubernetes_manifest.x of the
+// module module.example. The value of a resource is the state the provider
+// returned, the instances are ordered by their index or key. The outputs of
+// the module calls, e.g. module.child, are added as they were stored.
+func LoadInventory(ctx context.Context, varsCache cache.Cache[vars.Variable], inv *inventory.Inventory, moduleName string) error {
+	blocks := map[string][]instance{}
+	for address, res := range inv.Resources {
+		if res.Module != moduleName {
+			continue
+		}
+		blockName, idx, key := parseAddress(strings.TrimPrefix(address, moduleName+"."))
+		b := res.State
+		if len(b) == 0 {
+			b = res.Obj
+		}
+		var v any
+		if err := json.Unmarshal(b, &v); err != nil {
+			return fmt.Errorf("cannot load resource %s from the inventory, err: %s", address, err.Error())
+		}
+		blocks[blockName] = append(blocks[blockName], instance{index: idx, key: key, value: v})
+	}
+	for blockName, instances := range blocks {
+		sort.Slice(instances, func(i, j int) bool {
+			if instances[i].index != instances[j].index {
+				return instances[i].index < instances[j].index
+			}
+			return instances[i].key < instances[j].key
+		})
+		d := make([]any, 0, len(instances))
+		for _, instance := range instances {
+			d = append(d, instance.value)
+		}
+		varsCache.Upsert(ctx, cache.NSN{Name: blockName}, vars.Variable{Data: map[string][]any{
+			vars.DummyKey: d,
+		}})
+	}
+	for name, outputs := range inv.Outputs {
+		varsCache.Upsert(ctx, cache.NSN{Name: name}, vars.Variable{Data: outputs})
+	}
+	return nil
+}
+
+// parseAddress returns the block name and the count index or forEach key of
+// the address of the resource within its module, e.g. kubernetes_manifest.x[0]
+// or kubernetes_manifest.x["key"]
+func parseAddress(address string) (string, int, string) {
+	blockName, suffix, ok := strings.Cut(address, "[")
+	if !ok {
+		return blockName, 0, ""
+	}
+	suffix = strings.TrimSuffix(suffix, "]")
+	if key, err := strconv.Unquote(suffix); err == nil {
+		return blockName, -1, key
+	}
+	idx, err := strconv.Atoi(suffix)
+	if err != nil {
+		return blockName, -1, suffix
+	}
+	return blockName, idx, ""
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -51,6 +50,12 @@ func NewExecHandler(ctx context.Context, cfg *Config) *ExecHandler {
 			Recorder:          cfg.Recorder,
 			ProviderInstances: cfg.ProviderInstances,
 			ProviderInventory: cfg.ProviderInventory,
+			ProviderManager:   cfg.ProviderManager,
+			ProviderConfigMap: cfg.ProviderConfigMap,
+			RunID:             cfg.RunID,
+			Inventory:         cfg.Inventory,
+			Applied:           cfg.Applied,
+			Outputs:           cfg.Outputs,
 		}),
 	}
 }
@@ -93,7 +98,7 @@ func (r *ExecHandler) BlockRun(ctx context.Context, vertexName string, vCtx *typ
 
 func (r *ExecHandler) runInstances(ctx context.Context, vCtx *types.VertexContext) error {
 	recorder := r.Recorder
-	isForEach, items, err := r.getLoopItems(ctx, vCtx.BlockContext.Attributes)
+	isForEach, items, err := r.getLoopItems(ctx, vCtx)
 	if err != nil {
 		return err
 	}
@@ -134,11 +139,13 @@ type item struct {
 	val any
 }
 
-func (r *ExecHandler) getLoopItems(ctx context.Context, attrs *types.KformBlockAttributes) (bool, *items, error) {
+func (r *ExecHandler) getLoopItems(ctx context.Context, vCtx *types.VertexContext) (bool, *items, error) {
+	attrs := vCtx.BlockContext.Attributes
 	log := log.FromContext(ctx)
 	log.Info("getLoopItems", "attrs", attrs)
 	renderer := &render.Renderer{
-		Vars: r.Vars,
+		Vars:        r.Vars,
+		Expressions: vCtx.Expressions,
 	}
 	isForEach := false
 	items := &items{
@@ -149,14 +156,12 @@ func (r *ExecHandler) getLoopItems(ctx context.Context, attrs *types.KformBlockA
 		isForEach = true
 		v, err := renderer.Render(ctx, *attrs.ForEach)
 		if err != nil {
-			if strings.Contains(err.Error(), "no such key") || strings.Contains(err.Error(), "not found") {
-				v = nil
-			} else {
-				return isForEach, items, errors.Wrap(err, "render loop forEach failed")
-			}
+			return isForEach, items, errors.Wrap(err, "render loop forEach failed")
 		}
 		log.Info("getLoopItems forEach render output", "value type", reflect.TypeOf(v), "value", v)
 		switch v := v.(type) {
+		case nil:
+			// a null or omitted forEach has no items
 		case []any:
 			// in a list we return key = int, val = any
 			for k, v := range v {
@@ -179,13 +184,13 @@ func (r *ExecHandler) getLoopItems(ctx context.Context, attrs *types.KformBlockA
 	if attrs != nil && attrs.Count != nil {
 		v, err := renderer.Render(ctx, *attrs.Count)
 		if err != nil {
-			if strings.Contains(err.Error(), "no such key") || strings.Contains(err.Error(), "not found") {
-				v = int64(0)
-			} else {
-				return isForEach, items, errors.Wrap(err, "render count failed")
-			}
+			return isForEach, items, errors.Wrap(err, "render count failed")
 		}
 		switch v := v.(type) {
+		case nil:
+			// a null or omitted count has no items
+			items = getSetWithInt(0)
+			return isForEach, items, nil
 		case string:
 			c, err := strconv.Atoi(v)
 			if err != nil {
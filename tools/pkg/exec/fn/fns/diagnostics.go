@@ -0,0 +1,34 @@
+package fns
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+)
+
+// getDiagnosticsError returns an error for the error diagnostics of a provider
+// response. Diagnostics that point to an attribute of the config are mapped
+// back to the location of the attribute in the kform file.
+func getDiagnosticsError(vCtx *types.VertexContext, diags diag.Diagnostics) error {
+	var err error
+	for _, d := range diags {
+		if d.Severity != kfplugin1.Severity_ERROR {
+			continue
+		}
+		msg := d.Detail
+		if d.Summary != "" {
+			msg = fmt.Sprintf("%s: %s", d.Summary, d.Detail)
+		}
+		path := diag.AttributePathToPointer(d.Attribute)
+		if path == "" {
+			err = errors.Join(err, errors.New(msg))
+			continue
+		}
+		pos := vCtx.GetPosition(append([]string{string(types.BlockContextKeyConfig)}, d.Attribute.Steps...)...)
+		err = errors.Join(err, fmt.Errorf("%s: attribute %s: %s", pos.String(), path, msg))
+	}
+	return err
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/inventory"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/providers"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/record"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
 	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
@@ -33,6 +35,20 @@ type Config struct {
 	ProviderInstances cache.Cache[plugin.Provider]
 	// used for the provider DAG run only
 	ProviderInventory cache.Cache[types.Provider]
+	// used for the provider DAG run only, owns the provider processes
+	ProviderManager providers.Manager
+	// used for the resources run to map the provider configs of the module
+	// to the provider configs of the root module
+	ProviderConfigMap types.ProviderConfigMap
+	// used for the resources run to identify the run that owns the resources
+	RunID string
+	// used for the resources run, holds the resources of the previous run
+	Inventory *inventory.Inventory
+	// used for the resources run, collects the resources applied by the run
+	Applied cache.Cache[inventory.Resource]
+	// used for the module run, collects the outputs of the module calls of
+	// the root module
+	Outputs cache.Cache[vars.Variable]
 }
 
 func NewMap(ctx context.Context, cfg *Config) Map {
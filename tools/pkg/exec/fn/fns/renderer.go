@@ -7,23 +7,26 @@ import (
 
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/render"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
 )
 
 type Renderer struct {
-	Vars   cache.Cache[vars.Variable]
-	Schema types.KformBlockSchema
+	Vars        cache.Cache[vars.Variable]
+	Schema      types.KformBlockSchema
+	Expressions map[string]*celenv.CheckedExpression
 }
 
 func (r *Renderer) RenderConfigOrValue(ctx context.Context, blockName string, x any, localVars map[string]any) (any, error) {
 	renderer := render.Renderer{
-		Vars:      r.Vars,
-		LocalVars: localVars,
+		Vars:        r.Vars,
+		LocalVars:   localVars,
+		Expressions: r.Expressions,
 	}
 	d, err := renderer.Render(ctx, x)
 	if err != nil {
-		return nil, fmt.Errorf("render failed for blockName %s, err: %s", blockName, err.Error())
+		return nil, fmt.Errorf("render failed for blockName %s, err: %w", blockName, err)
 	}
 	d, err = AddTypeMeta(ctx, r.Schema, d)
 	if err != nil {
@@ -37,8 +37,9 @@ func (r *localOrOutput) Run(ctx context.Context, vCtx *types.VertexContext, loca
 			return fmt.Errorf("cannot run without a schema for %s", vctx.GetContext(r.rootModuleName, vCtx))
 		}
 		renderer := &Renderer{
-			Vars:   r.vars,
-			Schema: *vCtx.BlockContext.Attributes.Schema,
+			Vars:        r.vars,
+			Schema:      *vCtx.BlockContext.Attributes.Schema,
+			Expressions: vCtx.Expressions,
 		}
 		d, err := renderer.RenderConfigOrValue(ctx, vCtx.BlockName, vCtx.BlockContext.Value, localVars)
 		if err != nil {
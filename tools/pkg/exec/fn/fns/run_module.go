@@ -9,6 +9,8 @@ import (
 	"github.com/henderiw-nephio/kform/tools/pkg/dag"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/render"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/inventory"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/providers"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/record"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vctx"
@@ -27,6 +29,12 @@ func NewModuleFn(cfg *Config) fn.BlockInstanceRunner {
 		recorder:          cfg.Recorder,
 		providerInventory: cfg.ProviderInventory,
 		providerInstances: cfg.ProviderInstances,
+		providerManager:   cfg.ProviderManager,
+		providerConfigMap: cfg.ProviderConfigMap,
+		runID:             cfg.RunID,
+		inventory:         cfg.Inventory,
+		applied:           cfg.Applied,
+		outputs:           cfg.Outputs,
 	}
 }
 
@@ -40,6 +48,12 @@ type module struct {
 	recorder          recorder.Recorder[record.Record]
 	providerInventory cache.Cache[types.Provider]
 	providerInstances cache.Cache[plugin.Provider]
+	providerManager   providers.Manager
+	providerConfigMap types.ProviderConfigMap
+	runID             string
+	inventory         *inventory.Inventory
+	applied           cache.Cache[inventory.Resource]
+	outputs           cache.Cache[vars.Variable]
 }
 
 /*
@@ -68,8 +82,9 @@ func (r *module) Run(ctx context.Context, vCtx *types.VertexContext, localVars m
 	if vCtx.BlockContext.InputParams != nil {
 		for inputvar, d := range vCtx.BlockContext.InputParams {
 			renderer := render.Renderer{
-				Vars:      r.vars,
-				LocalVars: localVars,
+				Vars:        r.vars,
+				LocalVars:   localVars,
+				Expressions: vCtx.Expressions,
 			}
 			d, err := renderer.Render(ctx, d)
 			if err != nil {
@@ -88,6 +103,13 @@ func (r *module) Run(ctx context.Context, vCtx *types.VertexContext, localVars m
 			}
 		}
 	}
+	// the providers of the module call map the provider configs of the child
+	// module to the provider configs of this module
+	providerConfigMap := r.providerConfigMap
+	if vCtx.BlockContext.Attributes != nil {
+		providerConfigMap = providerConfigMap.ModuleCall(vCtx.BlockContext.Attributes.Providers)
+	}
+
 	// prepare and execute the dag (provider or regular dag based on the provider flag)
 
 	// the vCtx.DAG is either the provider DAG or a regular DAG based on input
@@ -103,6 +125,12 @@ func (r *module) Run(ctx context.Context, vCtx *types.VertexContext, localVars m
 			Recorder:          r.recorder,
 			ProviderInstances: r.providerInstances,
 			ProviderInventory: r.providerInventory,
+			ProviderManager:   r.providerManager,
+			ProviderConfigMap: providerConfigMap,
+			RunID:             r.runID,
+			Inventory:         r.inventory,
+			Applied:           r.applied,
+			Outputs:           r.outputs,
 		}),
 	})
 	if err != nil {
@@ -121,7 +149,12 @@ func (r *module) Run(ctx context.Context, vCtx *types.VertexContext, localVars m
 						v = vars.Variable{Data: map[string][]any{}}
 					}
 					v.Data[split[1]] = d
-					r.vars.Upsert(ctx, cache.NSN{Name: fmt.Sprintf("module.%s", vCtx.BlockName)}, v)
+					r.vars.Upsert(ctx, cache.NSN{Name: vCtx.BlockName}, v)
+					// the outputs of the module calls of the root module are
+					// kept in the inventory
+					if r.outputs != nil && vCtx.ModuleName == r.rootModuleName && vCtx.BlockName != r.rootModuleName {
+						r.outputs.Upsert(ctx, cache.NSN{Name: vCtx.BlockName}, v)
+					}
 				}
 			}
 		}
@@ -9,6 +9,7 @@ import (
 	"github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/providers"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/record"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vctx"
@@ -25,6 +26,7 @@ func NewProviderFn(cfg *Config) fn.BlockInstanceRunner {
 		recorder:          cfg.Recorder,
 		providerInventory: cfg.ProviderInventory,
 		providerInstances: cfg.ProviderInstances,
+		providerManager:   cfg.ProviderManager,
 	}
 }
 
@@ -36,6 +38,7 @@ type provider struct {
 	recorder          recorder.Recorder[record.Record]
 	providerInventory cache.Cache[types.Provider]
 	providerInstances cache.Cache[plugin.Provider]
+	providerManager   providers.Manager
 }
 
 func (r *provider) Run(ctx context.Context, vCtx *types.VertexContext, localVars map[string]any) error {
@@ -47,8 +50,9 @@ func (r *provider) Run(ctx context.Context, vCtx *types.VertexContext, localVars
 		return fmt.Errorf("cannot run without a schema for %s", vctx.GetContext(r.rootModuleName, vCtx))
 	}
 	renderer := &Renderer{
-		Vars:   r.vars,
-		Schema: *vCtx.BlockContext.Attributes.Schema,
+		Vars:        r.vars,
+		Schema:      *vCtx.BlockContext.Attributes.Schema,
+		Expressions: vCtx.Expressions,
 	}
 	d, err := renderer.RenderConfigOrValue(ctx, vCtx.BlockName, vCtx.BlockContext.Config, localVars)
 	if err != nil {
@@ -67,13 +71,15 @@ func (r *provider) Run(ctx context.Context, vCtx *types.VertexContext, localVars
 	}
 	log.Info("providerConfig", "config", string(providerConfigByte))
 
-	// initialize the provider
-	p, err := r.providerInventory.Get(cache.NSN{Name: vCtx.BlockName})
+	// initialize the provider, aliased provider configs share the provider
+	// in the inventory but get their own provider instance
+	p, err := r.providerInventory.Get(cache.NSN{Name: types.GetProviderName(vCtx.BlockName)})
 	if err != nil {
 		log.Error("provider not found in inventory", "err", err)
 		return fmt.Errorf("provider %s not found in inventory err: %s", vctx.GetContext(r.rootModuleName, vCtx), err.Error())
 	}
-	provider, err := p.Initializer()
+	// the provider manager starts the provider once per provider config
+	provider, err := r.providerManager.Instance(ctx, vCtx.BlockName, p)
 	if err != nil {
 		return err
 	}
@@ -89,8 +95,8 @@ func (r *provider) Run(ctx context.Context, vCtx *types.VertexContext, localVars
 		return fmt.Errorf("provider %s not found in inventory err: %s", vctx.GetContext(r.rootModuleName, vCtx), err.Error())
 	}
 	if diag.Diagnostics(cfgresp.Diagnostics).HasError() {
-		log.Error("failed to configure provider", "error", err.Error())
-		return fmt.Errorf("provider %s not found in inventory err: %s", vctx.GetContext(r.rootModuleName, vCtx), err.Error())
+		log.Error("failed to configure provider", "error", diag.Diagnostics(cfgresp.Diagnostics).Error())
+		return fmt.Errorf("cannot configure provider %s err: %w", vctx.GetContext(r.rootModuleName, vCtx), getDiagnosticsError(vCtx, cfgresp.Diagnostics))
 	}
 	log.Info("configure response", "diag", cfgresp.Diagnostics)
 
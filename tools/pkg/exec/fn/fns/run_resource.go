@@ -10,6 +10,8 @@ import (
 	"github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/render"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/inventory"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vctx"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
@@ -22,6 +24,10 @@ func NewResourceFn(cfg *Config) fn.BlockInstanceRunner {
 		rootModuleName:    cfg.RootModuleName,
 		vars:              cfg.Vars,
 		providerInstances: cfg.ProviderInstances,
+		providerConfigMap: cfg.ProviderConfigMap,
+		runID:             cfg.RunID,
+		inventory:         cfg.Inventory,
+		applied:           cfg.Applied,
 	}
 }
 
@@ -29,6 +35,10 @@ type resource struct {
 	rootModuleName    string
 	vars              cache.Cache[vars.Variable]
 	providerInstances cache.Cache[plugin.Provider]
+	providerConfigMap types.ProviderConfigMap
+	runID             string
+	inventory         *inventory.Inventory
+	applied           cache.Cache[inventory.Resource]
 }
 
 func (r *resource) Run(ctx context.Context, vCtx *types.VertexContext, localVars map[string]any) error {
@@ -49,12 +59,13 @@ func (r *resource) Run(ctx context.Context, vCtx *types.VertexContext, localVars
 	}
 	// adds the metaType to the config
 	renderer := &Renderer{
-		Vars:   r.vars,
-		Schema: *vCtx.BlockContext.Attributes.Schema,
+		Vars:        r.vars,
+		Schema:      *vCtx.BlockContext.Attributes.Schema,
+		Expressions: vCtx.Expressions,
 	}
 	d, err := renderer.RenderConfigOrValue(ctx, vCtx.BlockName, vCtx.BlockContext.Config, localVars)
 	if err != nil {
-		return fmt.Errorf("cannot render config for %s", vctx.GetContext(r.rootModuleName, vCtx))
+		return fmt.Errorf("cannot render config for %s, err: %w", vctx.GetContext(r.rootModuleName, vCtx), err)
 	}
 	/*
 		d, err = AddTypeMeta(ctx, *vCtx.BlockContext.Attributes.Schema, d)
@@ -75,19 +86,30 @@ func (r *resource) Run(ctx context.Context, vCtx *types.VertexContext, localVars
 	// lookup the provider in the provider instances
 	// based on the blockType run either data or resource
 	// add the data in the variable
-	fmt.Println("provider", vCtx.Provider)
+	// the provider config of a child module resource is mapped to a
+	// provider config of the root module, e.g. kubernetes.edge01
+	providerConfig := r.providerConfigMap.Get(vCtx.Provider)
+	log.Debug("provider", "providerConfig", providerConfig)
 
-	provider, err := r.providerInstances.Get(cache.NSN{Name: vCtx.Provider})
+	provider, err := r.providerInstances.Get(cache.NSN{Name: providerConfig})
 	if err != nil {
 		log.Info("cannot get provider", "error", err.Error())
 		return err
 	}
+	// the owner tags the logs of the provider and the objects it manages
+	address := vctx.GetResourceAddress(vCtx, localVars)
+	ctx = plugin.WithOwner(ctx, plugin.Owner{
+		Module:  vCtx.ModuleName,
+		Address: address,
+		RunID:   r.runID,
+	})
+	resourceType := strings.Split(vCtx.BlockName, ".")[0]
 
 	switch vCtx.BlockType {
 	case types.BlockTypeData:
 		resp, err := provider.ReadDataSource(ctx, &kfplugin1.ReadDataSource_Request{
 			Name: strings.Split(vCtx.BlockName, ".")[0],
-			Obj: b,
+			Obj:  b,
 		})
 		if err != nil {
 			log.Error("cannot read resource", "error", err.Error())
@@ -95,28 +117,83 @@ func (r *resource) Run(ctx context.Context, vCtx *types.VertexContext, localVars
 		}
 		if diag.Diagnostics(resp.Diagnostics).HasError() {
 			log.Error("request failed", "error", diag.Diagnostics(resp.Diagnostics).Error())
-			return err
+			return getDiagnosticsError(vCtx, resp.Diagnostics)
 		}
 		b = resp.Obj
 	case types.BlockTypeResource:
-		resp, err := provider.CreateResource(ctx, &kfplugin1.CreateResource_Request{
-			Name: strings.Split(vCtx.BlockName, ".")[0],
-			Obj: b,
+		// the provider validates the resource before it is created or
+		// updated, e.g. the kind of a manifest is resolved
+		validateResp, err := provider.ValidateResource(ctx, &kfplugin1.ValidateResource_Request{
+			Name: resourceType,
+			Obj:  b,
 		})
 		if err != nil {
-			log.Error("cannot read resource", "error", err.Error())
+			log.Error("cannot validate resource", "error", err.Error())
 			return err
 		}
-		if diag.Diagnostics(resp.Diagnostics).HasError() {
-			log.Error("request failed", "error", diag.Diagnostics(resp.Diagnostics).Error())
-			return err
+		if diag.Diagnostics(validateResp.Diagnostics).HasError() {
+			log.Error("validation failed", "error", diag.Diagnostics(validateResp.Diagnostics).Error())
+			return getDiagnosticsError(vCtx, validateResp.Diagnostics)
 		}
-		b = resp.Obj
+		var diags diag.Diagnostics
+		var obj []byte
+		// a resource applied by the previous run is updated, such that the
+		// provider can clean up what the new config no longer has
+		if prev, ok := r.inventory.Get(address); ok && prev.ProviderConfig == providerConfig && prev.Type == resourceType {
+			resp, err := provider.UpdateResource(ctx, &kfplugin1.UpdateResource_Request{
+				Name:     resourceType,
+				NewObj:   b,
+				OldObj:   prev.Obj,
+				OldState: prev.State,
+			})
+			if err != nil {
+				log.Error("cannot update resource", "error", err.Error())
+				return err
+			}
+			diags, obj = resp.Diagnostics, resp.Obj
+		} else {
+			resp, err := provider.CreateResource(ctx, &kfplugin1.CreateResource_Request{
+				Name: resourceType,
+				Obj:  b,
+			})
+			if err != nil {
+				log.Error("cannot create resource", "error", err.Error())
+				return err
+			}
+			diags, obj = resp.Diagnostics, resp.Obj
+		}
+		if diags.HasError() {
+			log.Error("request failed", "error", diags.Error())
+			return getDiagnosticsError(vCtx, diags)
+		}
+		if r.applied != nil {
+			r.applied.Upsert(ctx, cache.NSN{Name: address}, inventory.Resource{
+				Module:         vCtx.ModuleName,
+				ProviderConfig: providerConfig,
+				Type:           resourceType,
+				Obj:            b,
+				State:          obj,
+			})
+		}
+		b = obj
 	case types.BlockTypeList:
-		// TBD how do we deal with a list
+		// the selector and namespace of the list can hold expressions
+		listRenderer := &render.Renderer{Vars: r.vars, LocalVars: localVars, Expressions: vCtx.Expressions}
+		var labelSelector *kfplugin1.LabelSelector
+		var namespace string
+		if attrs := vCtx.BlockContext.Attributes; attrs != nil {
+			if labelSelector, err = renderSelector(ctx, listRenderer, attrs.Selector); err != nil {
+				return fmt.Errorf("%s: %s", vctx.GetContext(r.rootModuleName, vCtx), err.Error())
+			}
+			if namespace, err = renderNamespace(ctx, listRenderer, attrs.Namespace); err != nil {
+				return fmt.Errorf("%s: %s", vctx.GetContext(r.rootModuleName, vCtx), err.Error())
+			}
+		}
 		resp, err := provider.ListDataSource(ctx, &kfplugin1.ListDataSource_Request{
-			Name: strings.Split(vCtx.BlockName, ".")[0],
-			Obj: b,
+			Name:          strings.Split(vCtx.BlockName, ".")[0],
+			Obj:           b,
+			LabelSelector: labelSelector,
+			Namespace:     namespace,
 		})
 		if err != nil {
 			log.Error("cannot read resource", "error", err.Error())
@@ -124,7 +201,7 @@ func (r *resource) Run(ctx context.Context, vCtx *types.VertexContext, localVars
 		}
 		if diag.Diagnostics(resp.Diagnostics).HasError() {
 			log.Error("request failed", "error", diag.Diagnostics(resp.Diagnostics).Error())
-			return err
+			return getDiagnosticsError(vCtx, resp.Diagnostics)
 		}
 		b = resp.Obj
 	default:
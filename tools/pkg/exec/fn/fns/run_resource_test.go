@@ -0,0 +1,60 @@
+package fns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/render"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunResourceUnresolved(t *testing.T) {
+	cases := map[string]struct {
+		config any
+		want   render.UnresolvedError
+	}{
+		"MisspelledPath": {
+			config: map[string]any{
+				"spec": map[string]any{"vlanID": "${input.interface[0].spec.vlna}"},
+			},
+			want: render.UnresolvedError{Path: "spec.vlanID", Reference: "input.interface[0].spec.vlna"},
+		},
+		"MisspelledBlock": {
+			config: map[string]any{
+				"spec": map[string]any{"vlanID": "${input.interfaces[0].spec.vlan}"},
+			},
+			want: render.UnresolvedError{Path: "spec.vlanID", Reference: "input.interfaces", Unknown: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			varsCache := cache.New[vars.Variable]()
+			varsCache.Add(ctx, cache.NSN{Name: "input.interface"}, vars.Variable{Data: map[string][]any{
+				vars.DummyKey: {map[string]any{"spec": map[string]any{"vlan": int64(10)}}},
+			}})
+
+			r := &resource{vars: varsCache}
+			err := r.Run(ctx, &types.VertexContext{
+				FileName:   "a.yaml",
+				ModuleName: "a",
+				BlockType:  types.BlockTypeResource,
+				BlockName:  "kubernetes_manifest.a",
+				BlockContext: types.KformBlockContext{
+					Attributes: &types.KformBlockAttributes{Schema: &types.KformBlockSchema{}},
+					Config:     tc.config,
+				},
+			}, map[string]any{})
+
+			var uerr *render.UnresolvedError
+			if !errors.As(err, &uerr) {
+				t.Fatalf("want unresolved error, got: %v", err)
+			}
+			assert.Equal(t, tc.want, *uerr)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package fns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/render"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+)
+
+// renderSelector renders the expressions in the labels, keys and values of
+// the selector of a list block and converts it to the label selector of the
+// list request. The operators are validated by the parser and not rendered.
+func renderSelector(ctx context.Context, renderer *render.Renderer, selector *types.KformBlockSelector) (*kfplugin1.LabelSelector, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	labelSelector := &kfplugin1.LabelSelector{}
+	for k, v := range selector.MatchLabels {
+		value, err := renderString(ctx, renderer, v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render selector matchLabels %s, err: %s", k, err.Error())
+		}
+		if labelSelector.MatchLabels == nil {
+			labelSelector.MatchLabels = map[string]string{}
+		}
+		labelSelector.MatchLabels[k] = value
+	}
+	for idx, req := range selector.MatchExpressions {
+		key, err := renderString(ctx, renderer, req.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render selector matchExpressions[%d] key, err: %s", idx, err.Error())
+		}
+		operator := req.Operator
+		var values []string
+		for _, v := range req.Values {
+			value, err := renderString(ctx, renderer, v)
+			if err != nil {
+				return nil, fmt.Errorf("cannot render selector matchExpressions[%d] values, err: %s", idx, err.Error())
+			}
+			values = append(values, value)
+		}
+		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, &kfplugin1.LabelSelectorRequirement{
+			Key:      &key,
+			Operator: &operator,
+			Values:   values,
+		})
+	}
+	return labelSelector, nil
+}
+
+// renderNamespace renders the namespace of a list block
+func renderNamespace(ctx context.Context, renderer *render.Renderer, namespace *string) (string, error) {
+	if namespace == nil {
+		return "", nil
+	}
+	ns, err := renderString(ctx, renderer, *namespace)
+	if err != nil {
+		return "", fmt.Errorf("cannot render namespace, err: %s", err.Error())
+	}
+	return ns, nil
+}
+
+func renderString(ctx context.Context, renderer *render.Renderer, x string) (string, error) {
+	d, err := renderer.Render(ctx, x)
+	if err != nil {
+		return "", err
+	}
+	s, ok := d.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string, got: %T", d)
+	}
+	return s, nil
+}
@@ -0,0 +1,72 @@
+package fns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/fn/render"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSelector(t *testing.T) {
+	cases := map[string]struct {
+		selector            *types.KformBlockSelector
+		expectedMatchLabels map[string]string
+		expectedKeys        []string
+		expectedValues      [][]string
+	}{
+		"Nil": {},
+		"Static": {
+			selector: &types.KformBlockSelector{
+				MatchLabels: map[string]string{"app": "nginx"},
+				MatchExpressions: []types.KformBlockSelectorRequirement{
+					{Key: "tier", Operator: "In", Values: []string{"frontend", "backend"}},
+				},
+			},
+			expectedMatchLabels: map[string]string{"app": "nginx"},
+			expectedKeys:        []string{"tier"},
+			expectedValues:      [][]string{{"frontend", "backend"}},
+		},
+		"Expression": {
+			selector: &types.KformBlockSelector{
+				MatchLabels: map[string]string{"app": "$input.app[0]"},
+				MatchExpressions: []types.KformBlockSelectorRequirement{
+					{Key: "tier", Operator: "In", Values: []string{"$input.tier[0]"}},
+				},
+			},
+			expectedMatchLabels: map[string]string{"app": "redis"},
+			expectedKeys:        []string{"tier"},
+			expectedValues:      [][]string{{"backend"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			varsCache := cache.New[vars.Variable]()
+			varsCache.Add(ctx, cache.NSN{Name: "input.app"}, vars.Variable{Data: map[string][]any{vars.DummyKey: {"redis"}}})
+			varsCache.Add(ctx, cache.NSN{Name: "input.tier"}, vars.Variable{Data: map[string][]any{vars.DummyKey: {"backend"}}})
+
+			got, err := renderSelector(ctx, &render.Renderer{Vars: varsCache, LocalVars: map[string]any{}}, tc.selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if tc.selector == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tc.expectedMatchLabels, got.GetMatchLabels())
+			keys := []string{}
+			values := [][]string{}
+			for _, req := range got.GetMatchExpressions() {
+				keys = append(keys, req.GetKey())
+				values = append(values, req.GetValues())
+			}
+			assert.Equal(t, tc.expectedKeys, keys)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+}
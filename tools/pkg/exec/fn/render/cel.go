@@ -1,10 +1,8 @@
 package render
 
 import (
-	"regexp"
-	"strings"
-
 	"github.com/google/cel-go/cel"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
 )
 
 const (
@@ -24,39 +22,12 @@ var LocalVars = map[string]struct{}{
 	LoopKeyForEachVal: {},
 }
 
-var LoopAttr = map[string]*cel.Type{
-	LoopAttrCount:   cel.IntType,
-	LoopAttrForEach: cel.DynType,
-}
-
-const specialCharExpr = "[$&+,:;=?@#|'<>-^*()%!]"
-
-func IsCelExpression(s string) (bool, error) {
-	return regexp.MatchString(specialCharExpr, s)
-}
-
-func getCelEnv(vars map[string]any) (*cel.Env, error) {
-	// replace reference . to _ otherwise cell complains to do json lookups of a struct
-	newvars := map[string]any{}
-	for k, v := range vars {
-		newvars[strings.ReplaceAll(k, ".", "_")] = v
-	}
-	//fmt.Println("cellvars", newvars)
-
-	var opts []cel.EnvOption
-	opts = append(opts, cel.HomogeneousAggregateLiterals())
-	//opts = append(opts, cel.EagerlyValidateDeclarations(true), cel.DefaultUTCTimeZone(true))
-	//opts = append(opts, library.ExtensionLibs...)
-
-	for k := range newvars {
-		//fmt.Println("cellvar", k)
-		// for builtin variables like count, forEach we know the type
-		// this provide more type safety
-		if ct, ok := LoopAttr[k]; ok {
-			opts = append(opts, cel.Variable(k, ct))
-		} else {
-			opts = append(opts, cel.Variable(k, cel.DynType))
-		}
+// getCelEnv returns the cel environment with the namespaces of the references
+// as variables, e.g. input, module, each
+func getCelEnv(namespaces []string) (*cel.Env, error) {
+	celVars := make(map[string]*cel.Type, len(namespaces))
+	for _, namespace := range namespaces {
+		celVars[namespace] = cel.DynType
 	}
-	return cel.NewEnv(opts...)
+	return celenv.NewEnv(celVars)
 }
@@ -0,0 +1,227 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// UnresolvedError is the error of a value with a reference that cannot be
+// resolved, either the reference has no value, e.g. the block of the
+// reference has no instances, or the path into the value of the reference
+// does not exist. optional() omits such value iso failing.
+type UnresolvedError struct {
+	// Path is the path of the value in the rendered data, e.g. spec.vlanID
+	Path string
+	// Reference is the exact path of the unresolved reference, e.g.
+	// input.interface[0].spec.vlan
+	Reference string
+	// Unknown is true when the reference has no value
+	Unknown bool
+}
+
+func (r *UnresolvedError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("unresolved reference " + r.Reference)
+	if r.Path != "" {
+		sb.WriteString(" at " + r.Path)
+	}
+	if r.Unknown {
+		sb.WriteString(", the reference has no value")
+	} else {
+		sb.WriteString(", the path does not exist")
+	}
+	sb.WriteString(", use optional() to omit the value")
+	return sb.String()
+}
+
+// getUnresolvedError returns the unresolved error of the evaluation of the
+// expression, nil when the evaluation failed for another reason. It looks
+// for a select or index chain which evaluated to an error and walks the path
+// of the chain in the variables of the expression, the first key or index
+// that does not exist is the unresolved path.
+func getUnresolvedError(ast *cel.Ast, state interpreter.EvalState, vars map[string]any) *UnresolvedError {
+	var uerr *UnresolvedError
+	walkExpr(ast.Expr(), func(e *exprpb.Expr) bool {
+		if !isChain(e) {
+			return true
+		}
+		if v, ok := state.Value(e.GetId()); !ok || !types.IsError(v) {
+			return true
+		}
+		root, steps, ok := getChain(e, state)
+		if !ok {
+			return true
+		}
+		v, ok := vars[root]
+		if !ok {
+			// e.g. the variable of a comprehension
+			return true
+		}
+		if path, ok := getMissingPath(root, v, steps); ok {
+			uerr = &UnresolvedError{Reference: path}
+			return false
+		}
+		return true
+	})
+	return uerr
+}
+
+// step is a select or index of a select or index chain
+type step struct {
+	// field is the field of a select
+	field string
+	// index is the value of an index
+	index ref.Val
+}
+
+func isIndex(e *exprpb.Expr) bool {
+	call := e.GetCallExpr()
+	return call != nil && call.GetFunction() == "_[_]" && call.GetTarget() == nil && len(call.GetArgs()) == 2
+}
+
+func isChain(e *exprpb.Expr) bool {
+	return (e.GetSelectExpr() != nil && !e.GetSelectExpr().GetTestOnly()) || isIndex(e)
+}
+
+// getChain returns the root identifier and the steps of the chain from the
+// root, the value of a non constant index is the value tracked in the state
+func getChain(e *exprpb.Expr, state interpreter.EvalState) (string, []step, bool) {
+	steps := []step{}
+	for {
+		switch {
+		case e.GetSelectExpr() != nil:
+			steps = append(steps, step{field: e.GetSelectExpr().GetField()})
+			e = e.GetSelectExpr().GetOperand()
+		case isIndex(e):
+			index := e.GetCallExpr().GetArgs()[1]
+			var v ref.Val
+			if c := index.GetConstExpr(); c != nil {
+				switch x := c.GetConstantKind().(type) {
+				case *exprpb.Constant_StringValue:
+					v = types.String(x.StringValue)
+				case *exprpb.Constant_Int64Value:
+					v = types.Int(x.Int64Value)
+				case *exprpb.Constant_Uint64Value:
+					v = types.Uint(x.Uint64Value)
+				}
+			} else if tracked, ok := state.Value(index.GetId()); ok {
+				v = tracked
+			}
+			if v == nil {
+				return "", nil, false
+			}
+			steps = append(steps, step{index: v})
+			e = e.GetCallExpr().GetArgs()[0]
+		case e.GetIdentExpr() != nil:
+			// reverse the steps, such that they start at the root
+			for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+				steps[i], steps[j] = steps[j], steps[i]
+			}
+			return e.GetIdentExpr().GetName(), steps, true
+		default:
+			// the root of the chain is no identifier, e.g. a function call
+			return "", nil, false
+		}
+	}
+}
+
+// getMissingPath walks the steps in the value and returns the path up to the
+// first key or index that does not exist in the value
+func getMissingPath(root string, v any, steps []step) (string, bool) {
+	path := root
+	for _, s := range steps {
+		var key any
+		switch {
+		case s.index == nil:
+			key = s.field
+			path += "." + s.field
+		case s.index.Type() == types.StringType:
+			key = s.index.Value()
+			path += "[" + strconv.Quote(s.index.Value().(string)) + "]"
+		default:
+			key = s.index.Value()
+			path += fmt.Sprintf("[%v]", s.index.Value())
+		}
+		var found bool
+		switch x := v.(type) {
+		case map[string]any:
+			k, ok := key.(string)
+			if !ok {
+				return "", false
+			}
+			v, found = x[k]
+		case map[any]any:
+			v, found = x[key]
+		case []any:
+			idx, ok := toInt(key)
+			if !ok {
+				return "", false
+			}
+			if found = idx >= 0 && idx < len(x); found {
+				v = x[idx]
+			}
+		default:
+			// the value cannot be selected or indexed, which is a type error
+			return "", false
+		}
+		if !found {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func toInt(v any) (int, bool) {
+	switch x := v.(type) {
+	case int64:
+		return int(x), true
+	case uint64:
+		return int(x), true
+	case float64:
+		if x == float64(int(x)) {
+			return int(x), true
+		}
+	}
+	return 0, false
+}
+
+// walkExpr walks the expression depth first, the walk stops when fn returns
+// false
+func walkExpr(e *exprpb.Expr, fn func(e *exprpb.Expr) bool) bool {
+	if e == nil {
+		return true
+	}
+	if !fn(e) {
+		return false
+	}
+	children := []*exprpb.Expr{}
+	switch x := e.GetExprKind().(type) {
+	case *exprpb.Expr_SelectExpr:
+		children = append(children, x.SelectExpr.GetOperand())
+	case *exprpb.Expr_CallExpr:
+		children = append(children, x.CallExpr.GetTarget())
+		children = append(children, x.CallExpr.GetArgs()...)
+	case *exprpb.Expr_ListExpr:
+		children = append(children, x.ListExpr.GetElements()...)
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range x.StructExpr.GetEntries() {
+			children = append(children, entry.GetMapKey(), entry.GetValue())
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		c := x.ComprehensionExpr
+		children = append(children, c.GetIterRange(), c.GetAccuInit(), c.GetLoopCondition(), c.GetLoopStep(), c.GetResult())
+	}
+	for _, child := range children {
+		if !walkExpr(child, fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,71 +1,88 @@
 package render
 
 import (
-	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/interpreter"
+
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
-	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
-	"github.com/henderiw-nephio/kform/tools/pkg/util/sets"
 )
 
-func (r *Renderer) getRefsFromExpression(expr string) (map[string]any, error) {
-	// we get the variables from the expression by parsing the information
-	// from the $ variables
-	refs, err := getRefsFromExpr(expr)
-	if err != nil {
-		return nil, err
-	}
-	//fmt.Println("refs", refs.UnsortedList())
-	// we create a new copy to ensure we dont loose the init values
-	newVars := make(map[string]any, refs.Len()+len(r.LocalVars))
-	for _, ref := range refs.UnsortedList() {
-		// first lookup the vars in the initVars, which are the local vars for count
-		// for_each, etc
-		v, ok := r.LocalVars[ref]
+// getRefsFromExpression returns the variables of the expression and the
+// references that have no value. The value of a reference is nested in the
+// variable of its namespace, e.g. the value of input.interface is
+// input["interface"] and the value of module.x.output is
+// module["x"]["output"].
+func (r *Renderer) getRefsFromExpression(x *celenv.Expression) (map[string]any, []string) {
+	newVars := make(map[string]any, x.Namespaces.Len())
+	unknowns := []string{}
+	for _, ref := range x.References.UnsortedList() {
+		v, ok := r.getRefValue(ref)
 		if !ok {
-			// lookup in the local var failed, so lookup the real vars
-			varVal, err := r.Vars.Get(cache.NSN{Name: ref})
-			if err != nil {
-				return nil, err
-			}
-			split := strings.Split(ref, ".")
-			if split[0] == string(types.BlockTypeModule) {
-				if len(split) != 3 {
-					return nil, fmt.Errorf("getRefsFromExpression, expecting module ref with module.<moduleName>.<outputname>, got: %s", ref)
-				}
-				if v, ok = varVal.Data[split[2]]; !ok {
-					return nil, fmt.Errorf("getRefsFromExpression, ref error module output %s does not exist in var", ref)
-				}
-			} else {
-				if v, ok = varVal.Data[vars.DummyKey]; !ok {
-					return nil, fmt.Errorf("getRefsFromExpression, ref %s does not exist in var", ref)
-				}
-			}
+			unknowns = append(unknowns, ref)
+			continue
 		}
-		newVars[ref] = v
+		setRefValue(newVars, strings.Split(ref, "."), v)
 	}
-	for ref, v := range r.LocalVars {
-		newVars[ref] = v
+	sort.Strings(unknowns)
+	return newVars, unknowns
+}
+
+// getRefValue returns the value of the reference, false when the reference
+// has no value, e.g. a block without instances did not store its variable
+func (r *Renderer) getRefValue(ref string) (any, bool) {
+	// first lookup the vars in the local vars, which are the vars for count
+	// for_each, etc
+	if v, ok := r.LocalVars[ref]; ok {
+		return v, true
+	}
+	// lookup in the local var failed, so lookup the real vars
+	split := strings.Split(ref, ".")
+	if split[0] == celenv.NamespaceModule {
+		// the outputs of a module are stored in the var of the module
+		varVal, err := r.Vars.Get(cache.NSN{Name: strings.Join(split[:2], ".")})
+		if err != nil {
+			return nil, false
+		}
+		v, ok := varVal.Data[split[2]]
+		return v, ok
 	}
-	return newVars, nil
+	varVal, err := r.Vars.Get(cache.NSN{Name: ref})
+	if err != nil {
+		return nil, false
+	}
+	v, ok := varVal.Data[vars.DummyKey]
+	return v, ok
 }
 
-func getRefsFromExpr(expr string) (sets.Set[string], error) {
-	//vars := []string{}
-	vars := sets.New[string]()
-	depsSplit := strings.Split(expr, "$")
-	if len(depsSplit) == 1 {
-		return vars, nil
+// getUnknownPatterns returns the attribute patterns of the references that
+// have no value, such that CEL evaluates them as unknown
+func getUnknownPatterns(unknowns []string) []*interpreter.AttributePattern {
+	patterns := make([]*interpreter.AttributePattern, 0, len(unknowns))
+	for _, ref := range unknowns {
+		split := strings.Split(ref, ".")
+		pattern := cel.AttributePattern(split[0])
+		for _, name := range split[1:] {
+			pattern = pattern.QualString(name)
+		}
+		patterns = append(patterns, pattern)
 	}
-	for _, dep := range depsSplit[1:] {
-		depSplit := strings.Split(dep, ".")
-		if len(depSplit) < 2 {
-			return vars, fmt.Errorf("a dependency always need <resource-type>.<resource-identifier>, got: %s", dep)
+	return patterns
+}
+
+// setRefValue sets the value in the nested maps of the path
+func setRefValue(m map[string]any, path []string, v any) {
+	for _, p := range path[:len(path)-1] {
+		next, ok := m[p].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[p] = next
 		}
-		vars.Insert(types.ParseReferenceString(strings.Join(depSplit[:2], ".")))
-		//vars = append(vars, types.ParseReferenceString(strings.Join(depSplit[:2], ".")))
+		m = next
 	}
-	return vars, nil
+	m[path[len(path)-1]] = v
 }
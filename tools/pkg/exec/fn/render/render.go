@@ -2,11 +2,15 @@ package render
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/vars"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
 	"github.com/henderiw/logger/log"
 )
@@ -14,143 +18,212 @@ import (
 type Renderer struct {
 	Vars      cache.Cache[vars.Variable]
 	LocalVars map[string]any
+	// Expressions are the expressions that are checked by the parser, keyed
+	// by their source, the other expressions are compiled when rendered
+	Expressions map[string]*celenv.CheckedExpression
 }
 
+// Render renders the expressions in the value. A value of which a reference
+// cannot be resolved fails with an UnresolvedError, unless the value is
+// optional, e.g. ${optional(input.x[0].spec.vlanID)}, an omitted value is
+// removed from its map or list. An omitted or null value renders to nil.
 func (r *Renderer) Render(ctx context.Context, v any) (any, error) {
-	var err error
+	v, err := r.render(ctx, "", v)
+	if err != nil {
+		return nil, err
+	}
+	if celenv.IsOmitted(v) {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// render renders the value at the path in the rendered data
+func (r *Renderer) render(ctx context.Context, path string, v any) (any, error) {
 	switch x := v.(type) {
 	case map[string]any:
 		for k, v := range x {
-			x[k], err = r.Render(ctx, v)
+			newv, err := r.render(ctx, getFieldPath(path, k), v)
 			if err != nil {
-				fmt.Println("render map[string]any", err.Error())
-				if strings.Contains(err.Error(), "no such key") || strings.Contains(err.Error(), "not found") {
-					delete(x, k)
-					continue
-				} else {
-					return nil, err
-				}
+				return nil, err
+			}
+			if celenv.IsOmitted(newv) {
+				delete(x, k)
+				continue
 			}
+			x[k] = newv
 		}
 		return v, nil
 	case map[any]any:
 		for k, v := range x {
-			if x, ok := k.(string); ok {
-				k, err = r.handleString(ctx, x)
+			fieldPath := getFieldPath(path, fmt.Sprint(k))
+			if s, ok := k.(string); ok {
+				newk, err := r.handleString(ctx, fieldPath, s)
 				if err != nil {
-					fmt.Println("render map[any]any", err.Error())
 					return nil, err
 				}
-			}
-			x[k], err = r.Render(ctx, v)
-			if err != nil {
-				if strings.Contains(err.Error(), "no such key") || strings.Contains(err.Error(), "not found") {
+				if celenv.IsOmitted(newk) {
 					delete(x, k)
-				} else {
-					return nil, err
+					continue
 				}
+				k = newk
+			}
+			newv, err := r.render(ctx, fieldPath, v)
+			if err != nil {
+				return nil, err
+			}
+			if celenv.IsOmitted(newv) {
+				delete(x, k)
+				continue
 			}
+			x[k] = newv
 		}
 		return v, nil
 	case []any:
-		newv := []any{}
+		newv := make([]any, 0, len(x))
 		for i, v := range x {
-			// rather then deleting the entry when the rendering failed harmlessly
-			// -> add the entry to a new list (newx)
-			newx, err := r.Render(ctx, v)
+			newx, err := r.render(ctx, fmt.Sprintf("%s[%d]", path, i), v)
 			if err != nil {
-				if strings.Contains(err.Error(), "no such key") || strings.Contains(err.Error(), "not found") {
-					x[i] = nil
-					continue
-				} else {
-					return nil, err
-				}
+				return nil, err
+			}
+			// an omitted entry is removed from the list
+			if celenv.IsOmitted(newx) {
+				continue
 			}
 			newv = append(newv, newx)
 		}
 		return newv, nil
 	case string:
-		return r.handleString(ctx, x)
+		return r.handleString(ctx, path, x)
 	default:
-		return v, err
+		return v, nil
 	}
 }
 
-func (r *Renderer) handleString(ctx context.Context, x string) (any, error) {
+func getFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// handleString renders the string at the path, an unresolved error gets the
+// path of the string
+func (r *Renderer) handleString(ctx context.Context, path, x string) (any, error) {
+	v, err := r.renderString(ctx, x)
+	if err != nil {
+		var uerr *UnresolvedError
+		if errors.As(err, &uerr) {
+			uerr.Path = path
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func (r *Renderer) renderString(ctx context.Context, x string) (any, error) {
 	log := log.FromContext(ctx)
-	isCelExpr, err := IsCelExpression(x)
+	v, err := celenv.ParseValue(x)
 	if err != nil {
 		return nil, err
 	}
-	if isCelExpr {
-		// get the variables from the expression
-		varsForExpr, err := r.getRefsFromExpression(x)
-		if err != nil {
-			return nil, err
+	if v.IsLiteral() {
+		if v.Ambiguous {
+			log.Warn("value is a literal string, use ${...} to evaluate it as an expression", "value", x)
 		}
-		// remove the $ from the string, otherwise cell will complain
-		x = strings.ReplaceAll(x, "$", "")
-		// replace reference . to _ otherwise cell complains to do json lookups of a struct
-		for origRef := range varsForExpr {
-			newRef := strings.ReplaceAll(origRef, ".", "_")
-			//fmt.Println("origRef", origRef)
-			//fmt.Println("newRef", newRef)
-			x = strings.ReplaceAll(x, origRef, newRef)
-		}
-		//x = strings.ReplaceAll(x, "\"", "")
-		log.Info("expression", "expr", x)
-		env, err := getCelEnv(varsForExpr)
+		return v.Parts[0].Literal, nil
+	}
+	if v.IsExpression() {
+		val, err := r.evaluate(ctx, v.Parts[0].Expression)
 		if err != nil {
-			log.Error("cel environment failed", "error", err)
 			return nil, err
 		}
-		ast, iss := env.Compile(x)
-		if iss.Err() != nil {
-			log.Error("compile env to ast failed", "error", iss.Err())
-			return nil, err
+		switch {
+		case celenv.IsOmitted(val):
+			return celenv.Omitted, nil
+		case val == types.NullValue:
+			return nil, nil
 		}
-		_, err = cel.AstToCheckedExpr(ast)
-		if err != nil {
-			log.Error("ast to checked expression failed", "error", err)
-			return nil, err
+		return val.Value(), nil
+	}
+	// interpolate the expressions in the string
+	var sb strings.Builder
+	for _, p := range v.Parts {
+		if p.Expression == nil {
+			sb.WriteString(p.Literal)
+			continue
 		}
-		prog, err := env.Program(ast,
-			cel.EvalOptions(cel.OptOptimize),
-			// TODO: uncomment after updating to latest k8s
-			//cel.OptimizeRegex(library.ExtensionLibRegexOptimizations...),
-		)
+		val, err := r.evaluate(ctx, p.Expression)
 		if err != nil {
-			log.Error("env program failed", "expression", x, "error", err)
 			return nil, err
 		}
-
-		// replace the reference since cel does not deal with . for json references
-		newVarsForExpr := map[string]any{}
-		for k, v := range varsForExpr {
-			newVarsForExpr[strings.ReplaceAll(k, ".", "_")] = v
+		// a template with an omitted value is omitted
+		if celenv.IsOmitted(val) {
+			return celenv.Omitted, nil
 		}
-		//fmt.Println("newVarsForExpr", newVarsForExpr)
-
-		val, _, err := prog.Eval(newVarsForExpr)
-		if err != nil {
-			log.Error("evaluate program failed", "expression", x, "error", err)
-			return nil, err
+		s := val.ConvertToType(types.StringType)
+		if types.IsError(s) {
+			return nil, fmt.Errorf("cannot interpolate expression %s, err: %v", p.Expression.Source, s)
 		}
+		sb.WriteString(s.Value().(string))
+	}
+	return sb.String(), nil
+}
 
-		/*
-			result, err := val.ConvertToNative(reflect.TypeOf(""))
-			if err != nil {
-				log.Error("value conversion failed", "error", iss.Err())
-				return nil, err
-			}
+// compile returns the environment and the checked ast of the expression
+func (r *Renderer) compile(ctx context.Context, expr *celenv.Expression) (*cel.Env, *cel.Ast, error) {
+	log := log.FromContext(ctx)
+	if x, ok := r.Expressions[expr.Source]; ok {
+		return x.Env, x.Ast, nil
+	}
+	env, err := getCelEnv(expr.Namespaces.UnsortedList())
+	if err != nil {
+		log.Error("cel environment failed", "error", err)
+		return nil, nil, err
+	}
+	ast, iss := env.Compile(expr.Source)
+	if iss.Err() != nil {
+		log.Error("compile env to ast failed", "error", iss.Err())
+		return nil, nil, iss.Err()
+	}
+	return env, ast, nil
+}
 
-			s, ok := result.(string)
-			if !ok {
-				return nil, fmt.Errorf("expression returned non-string value: %v", result)
-			}
-		*/
-		return val.Value(), nil
-		// provide a return
+// evaluate evaluates the expression, the references without value are
+// unknown. An unknown result or a path that does not exist in the value of a
+// reference fails with an UnresolvedError.
+func (r *Renderer) evaluate(ctx context.Context, expr *celenv.Expression) (ref.Val, error) {
+	log := log.FromContext(ctx)
+	// get the variables from the expression
+	varsForExpr, unknowns := r.getRefsFromExpression(expr)
+	log.Info("expression", "expr", expr.Source)
+	env, ast, err := r.compile(ctx, expr)
+	if err != nil {
+		return nil, err
+	}
+	opts := append(celenv.ProgramOptions(), cel.EvalOptions(cel.OptTrackState, cel.OptPartialEval))
+	prog, err := env.Program(ast, opts...)
+	if err != nil {
+		log.Error("env program failed", "expression", expr.Source, "error", err)
+		return nil, err
+	}
+	activation, err := cel.PartialVars(varsForExpr, getUnknownPatterns(unknowns)...)
+	if err != nil {
+		return nil, err
+	}
+	val, details, err := prog.Eval(activation)
+	if err != nil {
+		if details == nil {
+			return nil, err
+		}
+		if uerr := getUnresolvedError(ast, details.State(), varsForExpr); uerr != nil {
+			return nil, uerr
+		}
+		log.Error("evaluate program failed", "expression", expr.Source, "error", err)
+		return nil, err
+	}
+	if types.IsUnknown(val) {
+		return nil, &UnresolvedError{Reference: strings.Join(unknowns, ", "), Unknown: true}
 	}
-	return x, nil
+	return val, nil
 }
@@ -2,6 +2,7 @@ package render
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -108,6 +109,32 @@ func TestRender(t *testing.T) {
 			expression: `$input.interface.all(i, i.metadata.name == "default")`,
 			want:       false,
 		},
+		"DashName": {
+			vars: map[string][]any{
+				"kubernetes_manifest.porch-repo": buildInterfaceVpc(),
+			},
+			expression: `$kubernetes_manifest.porch-repo[0].spec.networkInstance.name`,
+			want:       "vpc-ran",
+		},
+		"Literal": {
+			vars:       map[string][]any{},
+			expression: "my-app",
+			want:       "my-app",
+		},
+		"Interpolation": {
+			vars: map[string][]any{
+				"input.interface": buildInterfaceVpc(),
+			},
+			expression: "${input.interface[0].spec.networkInstance.name}-${size(input.interface)}",
+			want:       "vpc-ran-1",
+		},
+		"WholeValue": {
+			vars: map[string][]any{
+				"input.interface": buildInterfaces(),
+			},
+			expression: "${size(input.interface)}",
+			want:       int64(2),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -117,7 +144,7 @@ func TestRender(t *testing.T) {
 			for k, v := range tc.vars {
 				varCache.Add(ctx, cache.NSN{Name: k}, vars.Variable{
 					Data: map[string][]any{
-						"local": v,
+						vars.DummyKey: v,
 					},
 				})
 			}
@@ -140,3 +167,87 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderUnresolved(t *testing.T) {
+	cases := map[string]struct {
+		vars    map[string][]any
+		data    any
+		want    any
+		wantErr *UnresolvedError
+	}{
+		"MissingKey": {
+			vars: map[string][]any{
+				"input.interface": buildInterfaceDefault(),
+			},
+			data: map[string]any{
+				"spec": map[string]any{"vlanID": "$input.interface[0].spec.vlanID"},
+			},
+			wantErr: &UnresolvedError{Path: "spec.vlanID", Reference: "input.interface[0].spec.vlanID"},
+		},
+		"IndexOutOfRange": {
+			vars: map[string][]any{
+				"input.interface": buildInterfaceDefault(),
+			},
+			data:    []any{"${input.interface[1].metadata.name}"},
+			wantErr: &UnresolvedError{Path: "[0]", Reference: "input.interface[1]"},
+		},
+		"Unknown": {
+			vars:    map[string][]any{},
+			data:    map[string]any{"name": "prefix-${input.interface[0].metadata.name}"},
+			wantErr: &UnresolvedError{Path: "name", Reference: "input.interface", Unknown: true},
+		},
+		"Optional": {
+			vars: map[string][]any{
+				"input.interface": buildInterfaceDefault(),
+			},
+			data: map[string]any{
+				"name":   "${input.interface[0].metadata.name}",
+				"vlanID": "${optional(input.interface[0].spec.vlanID)}",
+				"labels": []any{"${optional(resource.x[0].metadata.name)}", "a"},
+			},
+			want: map[string]any{
+				"name":   "n3",
+				"labels": []any{"a"},
+			},
+		},
+		"Null": {
+			vars: map[string][]any{
+				"input.interface": {map[string]any{"spec": map[string]any{"vlanID": nil}}},
+			},
+			data: map[string]any{"vlanID": "${input.interface[0].spec.vlanID}"},
+			want: map[string]any{"vlanID": nil},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			varCache := cache.New[vars.Variable]()
+			for k, v := range tc.vars {
+				varCache.Add(ctx, cache.NSN{Name: k}, vars.Variable{
+					Data: map[string][]any{
+						vars.DummyKey: v,
+					},
+				})
+			}
+			r := &Renderer{Vars: varCache}
+
+			v, err := r.Render(ctx, tc.data)
+			if tc.wantErr != nil {
+				var uerr *UnresolvedError
+				if !errors.As(err, &uerr) {
+					t.Fatalf("want unresolved error, got: %v", err)
+				}
+				if diff := cmp.Diff(tc.wantErr, uerr); diff != "" {
+					t.Errorf("-want, +got:\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if diff := cmp.Diff(tc.want, v); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/henderiw/logger/log"
+	"sigs.k8s.io/yaml"
+)
+
+// InventoryFile is the file in the .kform directory that holds the resources
+// applied by the last successful run. A later run updates the resources that
+// are still in the config and prunes the resources that left the config.
+const InventoryFile = "inventory.yaml"
+
+type Inventory struct {
+	// RunID is the id of the run that applied the resources
+	RunID string `json:"runID,omitempty"`
+	// Resources holds the applied resources keyed by resource address
+	Resources map[string]Resource `json:"resources,omitempty"`
+	// Outputs holds the outputs of the module calls of the root module keyed
+	// by module call and output name, e.g. module.example and name
+	Outputs map[string]map[string][]any `json:"outputs,omitempty"`
+}
+
+type Resource struct {
+	// Module is the module of the resource
+	Module string `json:"module"`
+	// ProviderConfig is the provider config of the root module that applied
+	// the resource, e.g. kubernetes.edge01
+	ProviderConfig string `json:"providerConfig"`
+	// Type is the resource type of the provider, e.g. kubernetes_manifest
+	Type string `json:"type"`
+	// Obj is the rendered config of the resource
+	Obj json.RawMessage `json:"obj"`
+	// State is the object the provider returned for the config, providers
+	// keep the values they generated in it
+	State json.RawMessage `json:"state,omitempty"`
+}
+
+// NewRunID returns a unique id for a kform run
+func NewRunID() string {
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102150405"), strings.Split(uuid.NewString(), "-")[0])
+}
+
+func getInventoryPath(rootPath string) string {
+	return filepath.Join(rootPath, ".kform", InventoryFile)
+}
+
+// Read reads the inventory file, an empty inventory is returned when the file
+// does not exist.
+func Read(rootPath string) (*Inventory, error) {
+	inv := &Inventory{Resources: map[string]Resource{}}
+	b, err := os.ReadFile(getInventoryPath(rootPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return inv, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, inv); err != nil {
+		return nil, fmt.Errorf("cannot read inventory file, err: %s", err.Error())
+	}
+	if inv.Resources == nil {
+		inv.Resources = map[string]Resource{}
+	}
+	return inv, nil
+}
+
+// Write writes the inventory file
+func (r *Inventory) Write(rootPath string) error {
+	if err := os.MkdirAll(filepath.Dir(getInventoryPath(rootPath)), 0755); err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(getInventoryPath(rootPath), b, 0644)
+}
+
+// Get returns the resource of the address applied by the previous run
+func (r *Inventory) Get(address string) (Resource, bool) {
+	if r == nil {
+		return Resource{}, false
+	}
+	res, ok := r.Resources[address]
+	return res, ok
+}
+
+// Prune deletes the resources of the inventory that were not applied by the
+// run, the applied resources are keyed by resource address. The returned
+// inventory holds the applied resources and the resources that could not be
+// pruned, such that a later run retries them.
+func (r *Inventory) Prune(ctx context.Context, runID string, applied map[cache.NSN]Resource, providerInstances cache.Cache[plugin.Provider]) (*Inventory, error) {
+	log := log.FromContext(ctx)
+	newInv := &Inventory{RunID: runID, Resources: map[string]Resource{}}
+	for nsn, res := range applied {
+		newInv.Resources[nsn.Name] = res
+	}
+
+	addresses := make([]string, 0, len(r.Resources))
+	for address := range r.Resources {
+		if _, ok := newInv.Resources[address]; !ok {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	var errs error
+	for _, address := range addresses {
+		res := r.Resources[address]
+		log.Info("pruning resource", "address", address)
+		if err := res.delete(ctx, address, runID, providerInstances); err != nil {
+			log.Error("cannot prune resource", "address", address, "error", err.Error())
+			errs = errors.Join(errs, fmt.Errorf("cannot prune %s, err: %s", address, err.Error()))
+			newInv.Resources[address] = res
+		}
+	}
+	return newInv, errs
+}
+
+// Merge returns the inventory of a run that failed, the applied resources are
+// added to the resources of the inventory and nothing is pruned. The outputs
+// of the inventory are kept.
+func (r *Inventory) Merge(runID string, applied map[cache.NSN]Resource) *Inventory {
+	newInv := &Inventory{RunID: runID, Resources: map[string]Resource{}}
+	for address, res := range r.Resources {
+		newInv.Resources[address] = res
+	}
+	for nsn, res := range applied {
+		newInv.Resources[nsn.Name] = res
+	}
+	for moduleName, outputs := range r.Outputs {
+		newInv.AddOutputs(moduleName, outputs)
+	}
+	return newInv
+}
+
+// AddOutputs sets the outputs of the module call of the root module, the
+// outputs are keyed by output name
+func (r *Inventory) AddOutputs(moduleName string, outputs map[string][]any) *Inventory {
+	if r.Outputs == nil {
+		r.Outputs = map[string]map[string][]any{}
+	}
+	r.Outputs[moduleName] = outputs
+	return r
+}
+
+func (r Resource) delete(ctx context.Context, address, runID string, providerInstances cache.Cache[plugin.Provider]) error {
+	provider, err := providerInstances.Get(cache.NSN{Name: r.ProviderConfig})
+	if err != nil {
+		return fmt.Errorf("provider %s not found", r.ProviderConfig)
+	}
+	ctx = plugin.WithOwner(ctx, plugin.Owner{
+		Module:  r.Module,
+		Address: address,
+		RunID:   runID,
+	})
+	resp, err := provider.DeleteResource(ctx, &kfplugin1.DeleteResource_Request{
+		Name: r.Type,
+		Obj:  r.Obj,
+	})
+	if err != nil {
+		return err
+	}
+	return diag.Diagnostics(resp.Diagnostics).Error()
+}
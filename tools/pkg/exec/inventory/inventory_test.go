@@ -0,0 +1,127 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+type provider struct {
+	plugin.Provider
+	deleted []string
+	fail    map[string]bool
+}
+
+func (r *provider) DeleteResource(ctx context.Context, req *kfplugin1.DeleteResource_Request) (*kfplugin1.DeleteResource_Response, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	address := md.Get(plugin.ResourceAddressKey)[0]
+	if r.fail[address] {
+		return &kfplugin1.DeleteResource_Response{Diagnostics: diag.Errorf("delete failed")}, nil
+	}
+	r.deleted = append(r.deleted, address)
+	return &kfplugin1.DeleteResource_Response{}, nil
+}
+
+func TestPrune(t *testing.T) {
+	res := Resource{Module: "example", ProviderConfig: "kubernetes", Type: "kubernetes_manifest", Obj: []byte(`{}`)}
+
+	cases := map[string]struct {
+		previous          []string
+		applied           []string
+		fail              map[string]bool
+		expectErr         bool
+		expectedDeleted   []string
+		expectedInventory []string
+	}{
+		"Unchanged": {
+			previous:          []string{"a", "b"},
+			applied:           []string{"a", "b"},
+			expectedInventory: []string{"a", "b"},
+		},
+		"Removed": {
+			previous:          []string{"a", "b", "c"},
+			applied:           []string{"a"},
+			expectedDeleted:   []string{"b", "c"},
+			expectedInventory: []string{"a"},
+		},
+		"Added": {
+			previous:          []string{"a"},
+			applied:           []string{"a", "b"},
+			expectedInventory: []string{"a", "b"},
+		},
+		"PruneFailed": {
+			previous:          []string{"a", "b", "c"},
+			applied:           []string{"a"},
+			fail:              map[string]bool{"b": true},
+			expectErr:         true,
+			expectedDeleted:   []string{"c"},
+			expectedInventory: []string{"a", "b"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			p := &provider{fail: tc.fail}
+			providerInstances := cache.New[plugin.Provider]()
+			providerInstances.Add(ctx, cache.NSN{Name: "kubernetes"}, p)
+
+			inv := &Inventory{Resources: map[string]Resource{}}
+			for _, address := range tc.previous {
+				inv.Resources[address] = res
+			}
+			applied := map[cache.NSN]Resource{}
+			for _, address := range tc.applied {
+				applied[cache.NSN{Name: address}] = res
+			}
+
+			newInv, err := inv.Prune(ctx, "run2", applied, providerInstances)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, err)
+			}
+			assert.Equal(t, tc.expectedDeleted, p.deleted)
+			assert.Equal(t, "run2", newInv.RunID)
+			assert.Equal(t, len(tc.expectedInventory), len(newInv.Resources))
+			for _, address := range tc.expectedInventory {
+				if _, ok := newInv.Get(address); !ok {
+					t.Errorf("want %s in inventory", address)
+				}
+			}
+		})
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	rootPath := t.TempDir()
+	inv, err := Read(rootPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(inv.Resources))
+
+	inv = inv.Merge("run1", map[cache.NSN]Resource{
+		{Name: "example.kubernetes_manifest.a"}: {Module: "example", ProviderConfig: "kubernetes", Type: "kubernetes_manifest", Obj: []byte(`{"kind":"ConfigMap"}`)},
+	}).AddOutputs("module.child", map[string][]any{"name": {"a"}})
+	if err := inv.Write(rootPath); err != nil {
+		t.Fatal(err)
+	}
+	newInv, err := Read(rootPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "run1", newInv.RunID)
+	res, ok := newInv.Get("example.kubernetes_manifest.a")
+	if !ok {
+		t.Fatalf("want resource in inventory")
+	}
+	assert.JSONEq(t, `{"kind":"ConfigMap"}`, string(res.Obj))
+	assert.Equal(t, map[string][]any{"name": {"a"}}, newInv.Outputs["module.child"])
+	// a failed run keeps the outputs of the previous run
+	assert.Equal(t, newInv.Outputs, newInv.Merge("run2", nil).Outputs)
+}
@@ -0,0 +1,71 @@
+package builtin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfserver1"
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
+	"github.com/henderiw-nephio/kform/providers/provider-kform/kform"
+	kformv1alpha1 "github.com/henderiw-nephio/kform/providers/provider-kform/kform/api/v1alpha1"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+)
+
+type builtinProvider struct {
+	// apiVersion of the provider config
+	apiVersion string
+	provider   func() *schema.Provider
+}
+
+// providers are the providers that are built into kform. A built-in provider
+// runs in-process, it needs no provider requirement and when the provider
+// has no config an empty provider config is used.
+var providers = map[string]builtinProvider{
+	"kform": {
+		apiVersion: kformv1alpha1.APIVersion,
+		provider:   kform.Provider,
+	},
+}
+
+// NewRegistry returns the registry of the providers that are built into
+// kform
+func NewRegistry() types.BuiltinProviders {
+	return &registry{providers: providers}
+}
+
+type registry struct {
+	providers map[string]builtinProvider
+}
+
+// IsBuiltin returns true when the provider is built into kform
+func (r *registry) IsBuiltin(name string) bool {
+	_, ok := r.providers[name]
+	return ok
+}
+
+// List returns the names of the built-in providers
+func (r *registry) List() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetAPIVersion returns the apiVersion of the provider config of a built-in
+// provider
+func (r *registry) GetAPIVersion(name string) string {
+	return r.providers[name].apiVersion
+}
+
+// NewProvider returns a new instance of the built-in provider which runs
+// in-process
+func (r *registry) NewProvider(name string) (kfplugin.Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("provider %s is not a built-in provider, expected: %v", name, r.List())
+	}
+	return kfplugin.NewInProcessProvider(kfserver1.New(name, schema.NewGRPCProviderServer(p.provider()))), nil
+}
@@ -0,0 +1,206 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// EnvLogProviderPrefix is the prefix of the environment variable that sets
+	// the log level of a provider, e.g. KFORM_LOG_PROVIDER_kubernetes=debug
+	EnvLogProviderPrefix = "KFORM_LOG_PROVIDER_"
+	// EnvLogProviderPath is the environment variable that sets the file
+	// the provider logs of a run are written to.
+	EnvLogProviderPath = "KFORM_LOG_PROVIDER_PATH"
+)
+
+var (
+	logFileOnce sync.Once
+	logFile     io.Writer
+)
+
+// getLogFile returns the log file of the run, the file is truncated the first
+// time it is opened such that it only contains the logs of this run.
+func getLogFile() io.Writer {
+	logFileOnce.Do(func() {
+		path := os.Getenv(EnvLogProviderPath)
+		if path == "" {
+			return
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			slog.Error("cannot open provider log file", "path", path, "error", err)
+			return
+		}
+		logFile = &syncWriter{w: f}
+	})
+	return logFile
+}
+
+// GetProviderLogLevel returns the log level of the provider set through the
+// KFORM_LOG_PROVIDER_<name> environment variable, the default is info.
+func GetProviderLogLevel(name string) slog.Level {
+	if v := os.Getenv(EnvLogProviderPrefix + name); v != "" {
+		return parseLevel(v, slog.LevelInfo)
+	}
+	return slog.LevelInfo
+}
+
+// ProviderOutput creates an io.Writer that forwards the output of a provider
+// to the kform logger. Structured json log lines (slog or hclog) are parsed
+// such that the level and attributes of the provider log are retained, other
+// output is logged at debug level. Every log is tagged with the provider name.
+func ProviderOutput(log *slog.Logger, name, source string) io.Writer {
+	level := GetProviderLogLevel(name)
+	w := &providerOutput{
+		level: level,
+		log:   log.With("provider", name, "source", source),
+	}
+	if f := getLogFile(); f != nil {
+		w.fileLog = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level})).
+			With("provider", name, "source", source)
+	}
+	return w
+}
+
+type providerOutput struct {
+	m       sync.Mutex
+	buf     []byte
+	level   slog.Level
+	log     *slog.Logger
+	fileLog *slog.Logger
+}
+
+func (w *providerOutput) Write(d []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	w.buf = append(w.buf, d...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.logLine(w.buf[:idx])
+		w.buf = w.buf[idx+1:]
+	}
+	return len(d), nil
+}
+
+func (w *providerOutput) logLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+	level, msg, attrs := parseLine(line)
+	if level < w.level {
+		return
+	}
+	w.log.Log(context.Background(), level, msg, attrs...)
+	if w.fileLog != nil {
+		w.fileLog.Log(context.Background(), level, msg, attrs...)
+	}
+}
+
+// parseLine parses a slog or hclog json log line, other lines are returned
+// as message with the level inferred from the commonly used prefixes.
+func parseLine(line []byte) (slog.Level, string, []any) {
+	raw := map[string]any{}
+	if err := json.Unmarshal(line, &raw); err != nil {
+		s := string(line)
+		switch {
+		case strings.HasPrefix(s, "[TRACE]"), strings.HasPrefix(s, "[DEBUG]"):
+			return slog.LevelDebug, s, nil
+		case strings.HasPrefix(s, "[INFO]"):
+			return slog.LevelInfo, s, nil
+		case strings.HasPrefix(s, "[WARN]"):
+			return slog.LevelWarn, s, nil
+		case strings.HasPrefix(s, "[ERROR]"):
+			return slog.LevelError, s, nil
+		default:
+			return slog.LevelDebug, s, nil
+		}
+	}
+
+	level := slog.LevelInfo
+	msg := ""
+	for _, k := range []string{"@level", slog.LevelKey} {
+		if v, ok := raw[k].(string); ok {
+			level = parseLevel(v, slog.LevelInfo)
+			delete(raw, k)
+		}
+	}
+	for _, k := range []string{"@message", slog.MessageKey, "message"} {
+		if v, ok := raw[k].(string); ok {
+			msg = v
+			delete(raw, k)
+		}
+	}
+	// kform adds its own timestamp and logger
+	for _, k := range []string{"@timestamp", slog.TimeKey, "logger"} {
+		delete(raw, k)
+	}
+
+	attrs := []any{}
+	flatten("", raw, &attrs)
+	return level, msg, attrs
+}
+
+// flatten flattens the attribute groups, e.g. the data group of the kform
+// logger, in key value pairs sorted by key
+func flatten(prefix string, raw map[string]any, attrs *[]any) {
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := raw[k]
+		key := k
+		if prefix != "" && prefix != "data" {
+			key = fmt.Sprintf("%s.%s", prefix, k)
+		}
+		if m, ok := v.(map[string]any); ok && k == "data" {
+			flatten(key, m, attrs)
+			continue
+		}
+		*attrs = append(*attrs, key, v)
+	}
+}
+
+func parseLevel(s string, def slog.Level) slog.Level {
+	switch strings.ToLower(s) {
+	case "trace", "debug":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(s)); err == nil {
+		return l
+	}
+	return def
+}
+
+type syncWriter struct {
+	m sync.Mutex
+	w io.Writer
+}
+
+func (r *syncWriter) Write(b []byte) (int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.w.Write(b)
+}
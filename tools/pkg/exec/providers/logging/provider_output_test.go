@@ -0,0 +1,162 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProviderLogLevel(t *testing.T) {
+	cases := map[string]struct {
+		env  string
+		want slog.Level
+	}{
+		"Default": {
+			want: slog.LevelInfo,
+		},
+		"Debug": {
+			env:  "debug",
+			want: slog.LevelDebug,
+		},
+		"Trace": {
+			env:  "TRACE",
+			want: slog.LevelDebug,
+		},
+		"Error": {
+			env:  "error",
+			want: slog.LevelError,
+		},
+		"Invalid": {
+			env:  "verbose",
+			want: slog.LevelInfo,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.env != "" {
+				t.Setenv(EnvLogProviderPrefix+"kubernetes", tc.env)
+			}
+			// the level of one provider does not apply to the others
+			t.Setenv(EnvLogProviderPrefix+"resourcebackend", "error")
+			assert.Equal(t, tc.want, GetProviderLogLevel("kubernetes"))
+		})
+	}
+}
+
+func TestProviderOutputLevel(t *testing.T) {
+	lines := strings.Join([]string{
+		`{"level":"DEBUG","msg":"debug msg"}`,
+		`{"@level":"info","@message":"info msg"}`,
+		`[WARN] warn msg`,
+		`{"level":"ERROR","msg":"error msg","data":{"name":"a"}}`,
+		`plain output`,
+	}, "\n") + "\n"
+
+	cases := map[string]struct {
+		level slog.Level
+		want  []string
+	}{
+		"Debug": {
+			level: slog.LevelDebug,
+			want:  []string{"debug msg", "info msg", "[WARN] warn msg", "error msg", "plain output"},
+		},
+		"Info": {
+			level: slog.LevelInfo,
+			want:  []string{"info msg", "[WARN] warn msg", "error msg"},
+		},
+		"Error": {
+			level: slog.LevelError,
+			want:  []string{"error msg"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := &providerOutput{
+				level: tc.level,
+				log:   slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+			}
+			// writes are split over lines
+			w.Write([]byte(lines[:10]))
+			w.Write([]byte(lines[10:]))
+
+			assert.Equal(t, tc.want, getMessages(t, buf.Bytes()))
+		})
+	}
+}
+
+func TestProviderOutputLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})).With("run", "a")
+
+	ProviderOutput(logger, "kubernetes", "stdout").Write([]byte("{\"level\":\"INFO\",\"msg\":\"info msg\"}\n"))
+
+	entries := getEntries(t, buf.Bytes())
+	assert.Equal(t, 1, len(entries))
+	// the provider logger is derived from the kform logger
+	assert.Equal(t, "a", entries[0]["run"])
+	assert.Equal(t, "kubernetes", entries[0]["provider"])
+	assert.Equal(t, "stdout", entries[0]["source"])
+	assert.Equal(t, "info msg", entries[0][slog.MessageKey])
+}
+
+func TestProviderOutputLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provider.log")
+	t.Setenv(EnvLogProviderPath, path)
+	t.Setenv(EnvLogProviderPrefix+"kubernetes", "debug")
+	// the log file is opened once per run
+	logFileOnce = sync.Once{}
+	logFile = nil
+	t.Cleanup(func() {
+		logFileOnce = sync.Once{}
+		logFile = nil
+	})
+
+	ProviderOutput(slog.Default(), "kubernetes", "stderr").Write([]byte("{\"level\":\"DEBUG\",\"msg\":\"debug msg\",\"data\":{\"name\":\"a\"}}\n"))
+	ProviderOutput(slog.Default(), "resourcebackend", "stderr").Write([]byte("{\"level\":\"DEBUG\",\"msg\":\"filtered msg\"}\n[ERROR] error msg\n"))
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cannot read log file: %s", err.Error())
+	}
+	assert.Equal(t, []string{"debug msg", "[ERROR] error msg"}, getMessages(t, b))
+
+	entries := getEntries(t, b)
+	assert.Equal(t, "kubernetes", entries[0]["provider"])
+	assert.Equal(t, "stderr", entries[0]["source"])
+	assert.Equal(t, "a", entries[0]["name"])
+	assert.Equal(t, "resourcebackend", entries[1]["provider"])
+	assert.Equal(t, "ERROR", entries[1][slog.LevelKey])
+}
+
+func getMessages(t *testing.T, b []byte) []string {
+	t.Helper()
+	msgs := []string{}
+	for _, entry := range getEntries(t, b) {
+		msgs = append(msgs, entry[slog.MessageKey].(string))
+	}
+	return msgs
+}
+
+func getEntries(t *testing.T, b []byte) []map[string]any {
+	t.Helper()
+	entries := []map[string]any{}
+	for _, line := range bytes.Split(bytes.TrimSpace(b), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		entry := map[string]any{}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Fatalf("invalid log line %q: %s", string(line), err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
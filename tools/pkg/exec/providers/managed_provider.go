@@ -0,0 +1,201 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw/logger/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ kfplugin.Provider = &managedProvider{}
+
+// managedProvider is a provider instance of a provider config. When the
+// provider process crashed it is restarted and reconfigured with the last
+// known configuration, idempotent calls are retried once.
+type managedProvider struct {
+	m           sync.Mutex
+	name        string
+	initializer types.Initializer
+	provider    kfplugin.Provider
+	// configReq is the last configuration of the provider
+	configReq *kfplugin1.Configure_Request
+	closed    bool
+}
+
+type exiter interface {
+	Exited() bool
+}
+
+// getProvider returns the running provider, the provider is restarted when
+// it exited.
+func (r *managedProvider) getProvider(ctx context.Context) (kfplugin.Provider, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.closed {
+		return nil, fmt.Errorf("provider %s is closed", r.name)
+	}
+	if r.provider != nil {
+		if p, ok := r.provider.(exiter); !ok || !p.Exited() {
+			return r.provider, nil
+		}
+	}
+	return r.restart(ctx)
+}
+
+// restart starts a new provider process and configures it with the last
+// known configuration.
+func (r *managedProvider) restart(ctx context.Context) (kfplugin.Provider, error) {
+	log := log.FromContext(ctx)
+	log.Warn("restarting provider", "name", r.name)
+	if r.provider != nil {
+		r.provider.Close(ctx)
+		r.provider = nil
+	}
+	provider, err := r.initializer()
+	if err != nil {
+		return nil, fmt.Errorf("cannot restart provider %s, err: %s", r.name, err.Error())
+	}
+	if r.configReq != nil {
+		resp, err := provider.Configure(ctx, r.configReq)
+		if err != nil {
+			provider.Close(ctx)
+			return nil, fmt.Errorf("cannot reconfigure provider %s, err: %s", r.name, err.Error())
+		}
+		if diag.Diagnostics(resp.Diagnostics).HasError() {
+			provider.Close(ctx)
+			return nil, fmt.Errorf("cannot reconfigure provider %s, err: %s", r.name, diag.Diagnostics(resp.Diagnostics).Error())
+		}
+	}
+	r.provider = provider
+	return provider, nil
+}
+
+// invalidate closes the provider such that the next call restarts it,
+// unless another call already restarted it.
+func (r *managedProvider) invalidate(ctx context.Context, provider kfplugin.Provider) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.provider == provider {
+		r.provider.Close(ctx)
+		r.provider = nil
+	}
+}
+
+// isUnavailable returns true when the provider process is no longer reachable
+func isUnavailable(provider kfplugin.Provider, err error) bool {
+	if status.Code(err) == codes.Unavailable {
+		return true
+	}
+	p, ok := provider.(exiter)
+	return ok && p.Exited()
+}
+
+// retry runs an idempotent call, when the provider process crashed during the
+// call the provider is restarted and the call is retried once.
+func retry[T any](ctx context.Context, r *managedProvider, call func(kfplugin.Provider) (T, error)) (T, error) {
+	provider, err := r.getProvider(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	resp, err := call(provider)
+	if err == nil || !isUnavailable(provider, err) {
+		return resp, err
+	}
+	log.FromContext(ctx).Warn("provider unavailable, retrying", "name", r.name, "error", err.Error())
+	r.invalidate(ctx, provider)
+	provider, err = r.getProvider(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return call(provider)
+}
+
+// once runs a call that is not idempotent, the provider is restarted before
+// the call when it exited but the call is not retried.
+func once[T any](ctx context.Context, r *managedProvider, call func(kfplugin.Provider) (T, error)) (T, error) {
+	provider, err := r.getProvider(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return call(provider)
+}
+
+func (r *managedProvider) Capabilities(ctx context.Context, req *kfplugin1.Capabilities_Request) (*kfplugin1.Capabilities_Response, error) {
+	return retry(ctx, r, func(p kfplugin.Provider) (*kfplugin1.Capabilities_Response, error) {
+		return p.Capabilities(ctx, req)
+	})
+}
+
+func (r *managedProvider) Configure(ctx context.Context, req *kfplugin1.Configure_Request) (*kfplugin1.Configure_Response, error) {
+	resp, err := retry(ctx, r, func(p kfplugin.Provider) (*kfplugin1.Configure_Response, error) {
+		return p.Configure(ctx, req)
+	})
+	if err == nil && !diag.Diagnostics(resp.Diagnostics).HasError() {
+		r.m.Lock()
+		r.configReq = req
+		r.m.Unlock()
+	}
+	return resp, err
+}
+
+func (r *managedProvider) StopProvider(ctx context.Context, req *kfplugin1.StopProvider_Request) (*kfplugin1.StopProvider_Response, error) {
+	return once(ctx, r, func(p kfplugin.Provider) (*kfplugin1.StopProvider_Response, error) {
+		return p.StopProvider(ctx, req)
+	})
+}
+
+func (r *managedProvider) ReadDataSource(ctx context.Context, req *kfplugin1.ReadDataSource_Request) (*kfplugin1.ReadDataSource_Response, error) {
+	return retry(ctx, r, func(p kfplugin.Provider) (*kfplugin1.ReadDataSource_Response, error) {
+		return p.ReadDataSource(ctx, req)
+	})
+}
+
+func (r *managedProvider) ListDataSource(ctx context.Context, req *kfplugin1.ListDataSource_Request) (*kfplugin1.ListDataSource_Response, error) {
+	return retry(ctx, r, func(p kfplugin.Provider) (*kfplugin1.ListDataSource_Response, error) {
+		return p.ListDataSource(ctx, req)
+	})
+}
+
+func (r *managedProvider) ValidateResource(ctx context.Context, req *kfplugin1.ValidateResource_Request) (*kfplugin1.ValidateResource_Response, error) {
+	return once(ctx, r, func(p kfplugin.Provider) (*kfplugin1.ValidateResource_Response, error) {
+		return p.ValidateResource(ctx, req)
+	})
+}
+
+func (r *managedProvider) CreateResource(ctx context.Context, req *kfplugin1.CreateResource_Request) (*kfplugin1.CreateResource_Response, error) {
+	return once(ctx, r, func(p kfplugin.Provider) (*kfplugin1.CreateResource_Response, error) {
+		return p.CreateResource(ctx, req)
+	})
+}
+
+func (r *managedProvider) UpdateResource(ctx context.Context, req *kfplugin1.UpdateResource_Request) (*kfplugin1.UpdateResource_Response, error) {
+	return once(ctx, r, func(p kfplugin.Provider) (*kfplugin1.UpdateResource_Response, error) {
+		return p.UpdateResource(ctx, req)
+	})
+}
+
+func (r *managedProvider) DeleteResource(ctx context.Context, req *kfplugin1.DeleteResource_Request) (*kfplugin1.DeleteResource_Response, error) {
+	return once(ctx, r, func(p kfplugin.Provider) (*kfplugin1.DeleteResource_Response, error) {
+		return p.DeleteResource(ctx, req)
+	})
+}
+
+func (r *managedProvider) Close(ctx context.Context) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.closed = true
+	if r.provider != nil {
+		r.provider.Close(ctx)
+		r.provider = nil
+	}
+}
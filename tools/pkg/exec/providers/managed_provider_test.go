@@ -0,0 +1,121 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeProvider struct {
+	kfplugin.Provider
+	exited     bool
+	configured []byte
+	// unavailable makes the data source and resource calls fail as if the
+	// process crashed
+	unavailable bool
+}
+
+func (r *fakeProvider) Exited() bool { return r.exited }
+
+func (r *fakeProvider) Configure(ctx context.Context, req *kfplugin1.Configure_Request) (*kfplugin1.Configure_Response, error) {
+	r.configured = req.Config
+	return &kfplugin1.Configure_Response{}, nil
+}
+
+func (r *fakeProvider) ReadDataSource(ctx context.Context, req *kfplugin1.ReadDataSource_Request) (*kfplugin1.ReadDataSource_Response, error) {
+	if r.unavailable {
+		r.exited = true
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	return &kfplugin1.ReadDataSource_Response{}, nil
+}
+
+func (r *fakeProvider) CreateResource(ctx context.Context, req *kfplugin1.CreateResource_Request) (*kfplugin1.CreateResource_Response, error) {
+	if r.unavailable {
+		r.exited = true
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	return &kfplugin1.CreateResource_Response{}, nil
+}
+
+func (r *fakeProvider) Close(ctx context.Context) { r.exited = true }
+
+func TestManagedProvider(t *testing.T) {
+	cases := map[string]struct {
+		call         func(ctx context.Context, p *managedProvider) error
+		crashed      bool
+		unavailable  bool
+		expectErr    bool
+		expectStarts int
+	}{
+		"Running": {
+			call: func(ctx context.Context, p *managedProvider) error {
+				_, err := p.ReadDataSource(ctx, &kfplugin1.ReadDataSource_Request{})
+				return err
+			},
+			expectStarts: 0,
+		},
+		"CrashedBeforeCall": {
+			call: func(ctx context.Context, p *managedProvider) error {
+				_, err := p.CreateResource(ctx, &kfplugin1.CreateResource_Request{})
+				return err
+			},
+			crashed:      true,
+			expectStarts: 1,
+		},
+		"RetryIdempotentCall": {
+			call: func(ctx context.Context, p *managedProvider) error {
+				_, err := p.ReadDataSource(ctx, &kfplugin1.ReadDataSource_Request{})
+				return err
+			},
+			unavailable:  true,
+			expectStarts: 1,
+		},
+		"NoRetryNonIdempotentCall": {
+			call: func(ctx context.Context, p *managedProvider) error {
+				_, err := p.CreateResource(ctx, &kfplugin1.CreateResource_Request{})
+				return err
+			},
+			unavailable:  true,
+			expectErr:    true,
+			expectStarts: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			starts := 0
+			p := &managedProvider{
+				name: "kubernetes",
+				initializer: func() (kfplugin.Provider, error) {
+					starts++
+					return &fakeProvider{}, nil
+				},
+				provider: &fakeProvider{unavailable: tc.unavailable},
+			}
+			if _, err := p.Configure(ctx, &kfplugin1.Configure_Request{Config: []byte(`{"a":"b"}`)}); err != nil {
+				t.Fatalf("unexpected configure error: %s", err.Error())
+			}
+			p.provider.(*fakeProvider).exited = tc.crashed
+
+			err := tc.call(ctx, p)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("want error %t, got: %v", tc.expectErr, err)
+			}
+			if starts != tc.expectStarts {
+				t.Errorf("want %d provider starts, got: %d", tc.expectStarts, starts)
+			}
+			if tc.expectStarts > 0 {
+				// a restarted provider is reconfigured with the last config
+				if got := string(p.provider.(*fakeProvider).configured); got != `{"a":"b"}` {
+					t.Errorf("want restarted provider to be reconfigured, got config: %s", got)
+				}
+			}
+		})
+	}
+}
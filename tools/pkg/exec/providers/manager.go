@@ -0,0 +1,242 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
+	"github.com/henderiw-nephio/kform/tools/pkg/exec/providers/builtin"
+	"github.com/henderiw-nephio/kform/tools/pkg/pkgio"
+	"github.com/henderiw-nephio/kform/tools/pkg/store"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/henderiw/logger/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// storeProject is the project under which the capabilities and schemas of
+// the providers are cached in the store
+const storeProject = "kform-providers"
+
+// Manager owns the lifecycle of the provider processes. Every provider config
+// gets a single long-lived provider process which is shared by the provider
+// and the resource runs.
+type Manager interface {
+	// Init returns the inventory entry of the provider. The capabilities and
+	// schemas are retrieved from the store by the digest of the provider
+	// executable, when they are not cached the provider is started and the
+	// process is kept for the first instance of the provider.
+	types.ProviderManager
+	// Instance returns the provider instance of the provider config, the
+	// provider is started when it is not running yet.
+	Instance(ctx context.Context, configName string, p types.Provider) (kfplugin.Provider, error)
+	// Close stops all provider processes in a deterministic order
+	Close(ctx context.Context)
+}
+
+type Config struct {
+	// Store is optional and is used to cache the capabilities of the providers
+	Store store.Store
+	// Insecure disables the checksum verification of the provider executables
+	// and mutual TLS between kform and the providers
+	Insecure bool
+	// StateDir is the state directory of kform which is passed to the
+	// providers, such that a provider can persist data next to the state
+	StateDir string
+	// BuiltinProviders is the registry of the providers that run in-process,
+	// by default the providers that are built into kform
+	BuiltinProviders types.BuiltinProviders
+}
+
+// NewManager returns a provider manager
+func NewManager(cfg *Config) Manager {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	builtinProviders := cfg.BuiltinProviders
+	if builtinProviders == nil {
+		builtinProviders = builtin.NewRegistry()
+	}
+	return &manager{
+		store:     cfg.Store,
+		builtin:   builtinProviders,
+		insecure:  cfg.Insecure,
+		stateDir:  cfg.StateDir,
+		idle:      map[string]kfplugin.Provider{},
+		instances: map[string]*managedProvider{},
+	}
+}
+
+type manager struct {
+	m        sync.Mutex
+	store    store.Store
+	builtin  types.BuiltinProviders
+	insecure bool
+	stateDir string
+	// idle holds the provider processes started to retrieve the capabilities
+	// which are not yet handed out as instance, keyed by provider name
+	idle map[string]kfplugin.Provider
+	// instances holds the provider instances keyed by provider config name
+	instances map[string]*managedProvider
+}
+
+func (r *manager) Init(ctx context.Context, nsn cache.NSN, execPath, checksum string) (types.Provider, error) {
+	log := log.FromContext(ctx).With("nsn", nsn.Name)
+
+	p := types.Provider{}
+	var key *store.Plugin
+	if r.builtin.IsBuiltin(nsn.Name) {
+		// a built-in provider runs in-process, there is no executable to
+		// cache the capabilities and schemas for
+		p.InitBuiltin(ctx, nsn, r.builtin)
+	} else {
+		p.Init(ctx, execPath, r.stateDir, nsn, types.PluginSecurity{
+			Checksum: checksum,
+			Insecure: r.insecure,
+		})
+		var err error
+		key, err = getStoreKey(nsn, execPath, checksum)
+		if err != nil {
+			// e.g. a reattached provider has no executable
+			log.Debug("cannot cache provider capabilities and schemas", "error", err.Error())
+		}
+	}
+	if capResp := r.getCapabilities(ctx, key); capResp != nil {
+		log.Debug("provider capabilities from store", "version", key.Version)
+		p.SetCapabilities(ctx, capResp)
+		return p, nil
+	}
+
+	provider, err := p.Initializer()
+	if err != nil {
+		log.Error("failed starting provider", "error", err.Error())
+		return p, fmt.Errorf("failed starting provider %s, err: %s", nsn.Name, err.Error())
+	}
+	capResp, err := provider.Capabilities(ctx, &kfplugin1.Capabilities_Request{})
+	if err != nil {
+		provider.Close(ctx)
+		log.Error("cannot get provider capabilities", "error", err.Error())
+		return p, fmt.Errorf("cannot get provider capabilities %s, err: %s", nsn.Name, err.Error())
+	}
+	p.SetCapabilities(ctx, capResp)
+	r.saveCapabilities(ctx, key, capResp)
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	if old, ok := r.idle[nsn.Name]; ok {
+		old.Close(ctx)
+	}
+	r.idle[nsn.Name] = provider
+	return p, nil
+}
+
+func (r *manager) Instance(ctx context.Context, configName string, p types.Provider) (kfplugin.Provider, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if mp, ok := r.instances[configName]; ok {
+		return mp, nil
+	}
+	provider, ok := r.idle[p.NSN.Name]
+	if ok {
+		delete(r.idle, p.NSN.Name)
+	} else {
+		var err error
+		provider, err = p.Initializer()
+		if err != nil {
+			return nil, fmt.Errorf("failed starting provider %s, err: %s", p.NSN.Name, err.Error())
+		}
+	}
+	mp := &managedProvider{
+		name:        configName,
+		initializer: p.Initializer,
+		provider:    provider,
+	}
+	r.instances[configName] = mp
+	return mp, nil
+}
+
+func (r *manager) Close(ctx context.Context) {
+	log := log.FromContext(ctx)
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for _, name := range sortedKeys(r.instances) {
+		log.Info("closing provider", "name", name)
+		r.instances[name].Close(ctx)
+	}
+	for _, name := range sortedKeys(r.idle) {
+		log.Info("closing idle provider", "name", name)
+		r.idle[name].Close(ctx)
+	}
+	r.instances = map[string]*managedProvider{}
+	r.idle = map[string]kfplugin.Provider{}
+}
+
+func (r *manager) getCapabilities(ctx context.Context, key *store.Plugin) *kfplugin1.Capabilities_Response {
+	if r.store == nil || key == nil {
+		return nil
+	}
+	b, err := r.store.Get(ctx, *key)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			log.FromContext(ctx).Debug("cannot get provider capabilities from store", "error", err.Error())
+		}
+		return nil
+	}
+	capResp := &kfplugin1.Capabilities_Response{}
+	if err := proto.Unmarshal(b, capResp); err != nil {
+		log.FromContext(ctx).Debug("cannot unmarshal provider capabilities", "error", err.Error())
+		return nil
+	}
+	return capResp
+}
+
+func (r *manager) saveCapabilities(ctx context.Context, key *store.Plugin, capResp *kfplugin1.Capabilities_Response) {
+	if r.store == nil || key == nil {
+		return
+	}
+	b, err := proto.Marshal(capResp)
+	if err != nil {
+		log.FromContext(ctx).Debug("cannot marshal provider capabilities", "error", err.Error())
+		return
+	}
+	if err := r.store.Save(ctx, *key, b); err != nil {
+		log.FromContext(ctx).Debug("cannot save provider capabilities", "error", err.Error())
+	}
+}
+
+// getStoreKey returns the key of the provider in the store, the version is
+// the digest of the provider executable. The locked checksum is used when
+// available.
+func getStoreKey(nsn cache.NSN, execPath, checksum string) (*store.Plugin, error) {
+	digest := checksum
+	if digest == "" {
+		var err error
+		digest, err = pkgio.GetChecksum(execPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &store.Plugin{
+		Project: storeProject,
+		Name:    nsn.Name,
+		Os:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+		Version: digest,
+	}, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/tools/pkg/store"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+// memStore is a store that keeps the plugins in memory keyed by name and
+// version
+type memStore struct {
+	store.Store
+	plugins map[string][]byte
+}
+
+func (r *memStore) Get(ctx context.Context, p store.Plugin) ([]byte, error) {
+	b, ok := r.plugins[p.Name+"/"+p.Version]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return b, nil
+}
+
+func (r *memStore) Save(ctx context.Context, p store.Plugin, v []byte) error {
+	r.plugins[p.Name+"/"+p.Version] = v
+	return nil
+}
+
+func TestManagerInitCached(t *testing.T) {
+	nsn := cache.NSN{Name: "kubernetes"}
+	cases := map[string]struct {
+		checksum  string
+		expectErr bool
+	}{
+		"Cached": {
+			checksum: "sha256:abc",
+		},
+		// another digest is another provider executable, which is started
+		// to get its capabilities and schemas
+		"OtherDigest": {
+			checksum:  "sha256:def",
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := &memStore{plugins: map[string][]byte{}}
+			m := NewManager(&Config{Store: s, Insecure: true}).(*manager)
+			key, err := getStoreKey(nsn, "", "sha256:abc")
+			if err != nil {
+				t.Fatalf("cannot get store key: %s", err.Error())
+			}
+			m.saveCapabilities(ctx, key, &kfplugin1.Capabilities_Response{
+				Resources:            []string{"kubernetes_manifest"},
+				ReadDataSources:      []string{"kubernetes_manifest"},
+				ProviderConfigSchema: []byte(`{"type":"object","properties":{"spec":{"type":"object"}}}`),
+				ResourceSchemas: map[string][]byte{
+					"kubernetes_manifest": []byte(`{"type":"object"}`),
+				},
+			})
+
+			// the executable does not exist, the provider can only be
+			// initialized from the store
+			p, err := m.Init(ctx, nsn, "/nonexistent/provider-kubernetes", tc.checksum)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, p.Resources.Has("kubernetes_manifest"))
+			assert.True(t, p.ReadDataSources.Has("kubernetes_manifest"))
+			if assert.NotNil(t, p.ConfigSchema) {
+				assert.Contains(t, p.ConfigSchema.Properties, "spec")
+			}
+			assert.Contains(t, p.Schemas, "kubernetes_manifest")
+			assert.Empty(t, m.idle)
+		})
+	}
+}
@@ -33,3 +33,20 @@ func GetContextFromModule(rootModuleName, moduleName string) string {
 
 	return sb.String()
 }
+
+// GetResourceAddress returns the address of the block instance,
+// e.g. module.kubernetes_manifest.example[0] or
+// module.kubernetes_manifest.example["key"] when running in a loop
+func GetResourceAddress(vctx *types.VertexContext, localVars map[string]any) string {
+	if vctx == nil {
+		return ""
+	}
+	addr := fmt.Sprintf("%s.%s", vctx.ModuleName, vctx.BlockName)
+	if v, ok := localVars[types.LoopKeyForEachKey]; ok {
+		return fmt.Sprintf("%s[%q]", addr, fmt.Sprint(v))
+	}
+	if v, ok := localVars[types.LoopKeyCountIndex]; ok {
+		return fmt.Sprintf("%s[%v]", addr, v)
+	}
+	return addr
+}
@@ -0,0 +1,125 @@
+package pkgio
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/address"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"sigs.k8s.io/yaml"
+)
+
+// ProviderLockFile is the file in the .kform directory that holds the
+// checksums of the installed provider executables. The checksums are recorded
+// by kform init and verified before a provider is executed.
+const ProviderLockFile = "providers.lock.yaml"
+
+type ProviderLock struct {
+	// Providers holds the lock entries keyed by provider name
+	Providers map[string]ProviderLockEntry `json:"providers"`
+}
+
+type ProviderLockEntry struct {
+	// ExecPath is the path of the executable relative to .kform/providers
+	ExecPath string `json:"execPath"`
+	// Checksum is the digest of the executable, e.g. sha256:<hex>
+	Checksum string `json:"checksum"`
+}
+
+func getProviderLockPath(rootPath string) string {
+	return filepath.Join(rootPath, ".kform", ProviderLockFile)
+}
+
+// ReadProviderLock reads the provider lock file, an empty lock is returned
+// when the file does not exist.
+func ReadProviderLock(rootPath string) (*ProviderLock, error) {
+	lock := &ProviderLock{Providers: map[string]ProviderLockEntry{}}
+	b, err := os.ReadFile(getProviderLockPath(rootPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lock, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, lock); err != nil {
+		return nil, fmt.Errorf("cannot read provider lock file, err: %s", err.Error())
+	}
+	if lock.Providers == nil {
+		lock.Providers = map[string]ProviderLockEntry{}
+	}
+	return lock, nil
+}
+
+// Write writes the provider lock file
+func (r *ProviderLock) Write(rootPath string) error {
+	b, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(getProviderLockPath(rootPath), b, 0644)
+}
+
+// GetChecksum returns the checksum of the provider executable, an empty
+// string is returned when the provider executable is not locked.
+func (r *ProviderLock) GetChecksum(name, execPath string) string {
+	entry, ok := r.Providers[name]
+	if !ok || entry.ExecPath != execPath {
+		return ""
+	}
+	return entry.Checksum
+}
+
+// Update records the checksums of the installed providers. The checksum of
+// an executable that was locked before is validated and not updated, such
+// that a modified executable is detected. With insecure a modified
+// executable is locked with its new checksum, used to develop providers.
+// Providers that are no longer required are removed from the lock.
+func (r *ProviderLock) Update(rootPath string, providers cache.Cache[*address.Package], insecure bool) error {
+	pkgs := providers.List()
+	for name := range r.Providers {
+		if _, ok := pkgs[cache.NSN{Name: name}]; !ok {
+			delete(r.Providers, name)
+		}
+	}
+
+	names := make([]string, 0, len(pkgs))
+	for nsn := range pkgs {
+		names = append(names, nsn.Name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pkg := pkgs[cache.NSN{Name: name}]
+		execPath := pkg.ExecPath()
+		checksum, err := GetChecksum(filepath.Join(rootPath, ".kform", "providers", execPath))
+		if err != nil {
+			return fmt.Errorf("cannot get checksum of provider %s, err: %s", name, err.Error())
+		}
+		if locked := r.GetChecksum(name, execPath); !insecure && locked != "" && locked != checksum {
+			return fmt.Errorf("checksum mismatch for provider %s, locked: %s, got: %s; remove %s to reinstall the provider or use --insecure-plugins",
+				name, locked, checksum, filepath.Join(".kform", "providers", pkg.BasePath()))
+		}
+		r.Providers[name] = ProviderLockEntry{
+			ExecPath: execPath,
+			Checksum: checksum,
+		}
+	}
+	return nil
+}
+
+// GetChecksum returns the sha256 digest of the file, e.g. sha256:<hex>
+func GetChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,98 @@
+package pkgio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/address"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+)
+
+func TestProviderLockUpdate(t *testing.T) {
+	pkg := &address.Package{
+		Address:         &address.Address{HostName: "github.com", Namespace: "example", Name: "kubernetes"},
+		Platform:        &address.Platform{OS: "linux", Arch: "amd64"},
+		SelectedVersion: "v0.0.1",
+	}
+
+	cases := map[string]struct {
+		locked    map[string]ProviderLockEntry
+		data      string
+		insecure  bool
+		expectErr bool
+	}{
+		"NotLocked": {
+			locked: map[string]ProviderLockEntry{
+				"removed": {ExecPath: "a/b", Checksum: "sha256:00"},
+			},
+			data: "provider",
+		},
+		"Unchanged": {
+			locked: map[string]ProviderLockEntry{
+				"kubernetes": {ExecPath: pkg.ExecPath(), Checksum: "sha256:5c4c1964340aca5b65393bbe9d3249cdd71be26665b3320ad694f034f2743283"},
+			},
+			data: "provider",
+		},
+		"Modified": {
+			locked: map[string]ProviderLockEntry{
+				"kubernetes": {ExecPath: pkg.ExecPath(), Checksum: "sha256:00"},
+			},
+			data:      "provider",
+			expectErr: true,
+		},
+		"ModifiedInsecure": {
+			locked: map[string]ProviderLockEntry{
+				"kubernetes": {ExecPath: pkg.ExecPath(), Checksum: "sha256:00"},
+			},
+			data:     "provider",
+			insecure: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rootPath := t.TempDir()
+			execPath := filepath.Join(rootPath, ".kform", "providers", pkg.ExecPath())
+			if err := os.MkdirAll(filepath.Dir(execPath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(execPath, []byte(tc.data), 0755); err != nil {
+				t.Fatal(err)
+			}
+			providers := cache.New[*address.Package]()
+			providers.Add(context.Background(), cache.NSN{Name: "kubernetes"}, pkg)
+
+			lock := &ProviderLock{Providers: tc.locked}
+			err := lock.Update(rootPath, providers, tc.insecure)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if _, ok := lock.Providers["removed"]; ok {
+				t.Errorf("want unused provider removed from the lock")
+			}
+			checksum, _ := GetChecksum(execPath)
+			if got := lock.GetChecksum("kubernetes", pkg.ExecPath()); got != checksum {
+				t.Errorf("want checksum %s, got: %s", checksum, got)
+			}
+			// the lock file roundtrips
+			if err := os.MkdirAll(filepath.Join(rootPath, ".kform"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := lock.Write(rootPath); err != nil {
+				t.Fatal(err)
+			}
+			newLock, err := ReadProviderLock(rootPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := newLock.GetChecksum("kubernetes", pkg.ExecPath()); got != checksum {
+				t.Errorf("want checksum %s after read, got: %s", checksum, got)
+			}
+		})
+	}
+}
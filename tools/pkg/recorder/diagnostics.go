@@ -0,0 +1,119 @@
+package recorder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+)
+
+// PrintDiagnostics prints the diagnostics compiler-style. When the position
+// of a diagnostic is known a snippet of the source is added, e.g.
+//
+//	main.yaml:23:15: error: unknown reference $input.dnnn
+//	   23 |       name: $input.dnnn[0].metadata.name
+//	      |             ^
+func PrintDiagnostics(w io.Writer, diags []diag.Diagnostic) {
+	sources := map[string][]string{}
+	for _, d := range diags {
+		fmt.Fprintln(w, FormatDiagnostic(d, sources))
+	}
+}
+
+// FormatDiagnostic returns the diagnostic in a compiler-style format.
+// sources caches the lines of the source files that were already read.
+func FormatDiagnostic(d diag.Diagnostic, sources map[string][]string) string {
+	var sb strings.Builder
+	if d.Position != nil {
+		sb.WriteString(fmt.Sprintf("%s: ", d.Position.String()))
+	}
+	sb.WriteString(fmt.Sprintf("%s: %s", getSeverity(d.GetSeverity()), getMessage(d)))
+	if d.Position == nil {
+		if d.GetContext() != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", d.GetContext()))
+		}
+		return sb.String()
+	}
+	if snippet := getSnippet(*d.Position, sources); snippet != "" {
+		sb.WriteString("\n")
+		sb.WriteString(snippet)
+	}
+	return sb.String()
+}
+
+func getSeverity(s kfplugin1.Severity) string {
+	switch s {
+	case kfplugin1.Severity_ERROR:
+		return "error"
+	case kfplugin1.Severity_WARNING:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+func getMessage(d diag.Diagnostic) string {
+	msg := d.GetDetail()
+	if d.GetSummary() != "" {
+		msg = fmt.Sprintf("%s: %s", d.GetSummary(), msg)
+	}
+	if path := diag.AttributePathToPointer(d.GetAttribute()); path != "" {
+		msg = fmt.Sprintf("attribute %s: %s", path, msg)
+	}
+	return msg
+}
+
+func getSnippet(pos diag.Position, sources map[string][]string) string {
+	if !pos.IsValid() {
+		return ""
+	}
+	lines, ok := sources[pos.FileName]
+	if !ok {
+		lines = readLines(pos.FileName)
+		sources[pos.FileName] = lines
+	}
+	if pos.Line > len(lines) {
+		return ""
+	}
+	line := lines[pos.Line-1]
+	lineNbr := fmt.Sprintf("%d", pos.Line)
+	pad := strings.Repeat(" ", len(lineNbr))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  %s | %s\n", lineNbr, line))
+	sb.WriteString(fmt.Sprintf("  %s |", pad))
+	if pos.Column > 0 {
+		// keep tabs such that the caret aligns with the source
+		var indent strings.Builder
+		for i, c := range line {
+			if i >= pos.Column-1 {
+				break
+			}
+			if c == '\t' {
+				indent.WriteRune('\t')
+			} else {
+				indent.WriteRune(' ')
+			}
+		}
+		sb.WriteString(fmt.Sprintf(" %s^", indent.String()))
+	}
+	return sb.String()
+}
+
+func readLines(fileName string) []string {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
@@ -0,0 +1,68 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSource = "spec:\n- resource:\n    name: $input.dnnn[0].metadata.name\n\tvalue: $input.x\n"
+
+func TestFormatDiagnostic(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "main.yaml")
+	if err := os.WriteFile(fileName, []byte(testSource), 0644); err != nil {
+		t.Fatalf("cannot write source: %s", err.Error())
+	}
+
+	cases := map[string]struct {
+		diag diag.Diagnostic
+		want string
+	}{
+		"Position": {
+			diag: diag.DiagErrorf("unknown reference $input.dnnn").
+				WithPosition(diag.Position{FileName: fileName, Line: 3, Column: 11}),
+			want: fileName + ":3:11: error: unknown reference $input.dnnn\n" +
+				"  3 |     name: $input.dnnn[0].metadata.name\n" +
+				"    |           ^",
+		},
+		"Tab": {
+			diag: diag.DiagWarnf("unused reference").
+				WithPosition(diag.Position{FileName: fileName, Line: 4, Column: 9}),
+			want: fileName + ":4:9: warning: unused reference\n" +
+				"  4 | \tvalue: $input.x\n" +
+				"    | \t       ^",
+		},
+		"Line": {
+			diag: diag.DiagErrorf("invalid block").
+				WithPosition(diag.Position{FileName: fileName, Line: 2}),
+			want: fileName + ":2: error: invalid block\n" +
+				"  2 | - resource:\n" +
+				"    |",
+		},
+		"LineOutOfRange": {
+			diag: diag.DiagErrorf("invalid block").
+				WithPosition(diag.Position{FileName: fileName, Line: 20, Column: 1}),
+			want: fileName + ":20:1: error: invalid block",
+		},
+		"Attribute": {
+			diag: diag.DiagErrorf("invalid image").
+				WithAttribute(diag.NewAttributePath("spec", "containers", "0", "image")).
+				WithPosition(diag.Position{FileName: fileName, Line: 2, Column: 3}),
+			want: fileName + ":2:3: error: attribute /spec/containers/0/image: invalid image\n" +
+				"  2 | - resource:\n" +
+				"    |   ^",
+		},
+		"NoPosition": {
+			diag: diag.DiagFromErrWithContext("fileName=main.yaml", os.ErrNotExist),
+			want: "error: file does not exist (fileName=main.yaml)",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, FormatDiagnostic(tc.diag, map[string][]string{}))
+		})
+	}
+}
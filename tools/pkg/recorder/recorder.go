@@ -8,6 +8,7 @@ import (
 type Recorder[T Record] interface {
 	Record(r T)
 	Get() Records
+	List() []T
 	Print()
 }
 
@@ -42,6 +43,14 @@ func (r *recorder[T]) Get() Records {
 	return r.records
 }
 
+func (r *recorder[T]) List() []T {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	l := make([]T, len(r.records))
+	copy(l, r.records)
+	return l
+}
+
 func (r *recorder[T]) Print() {
 	r.m.RLock()
 	defer r.m.RUnlock()
@@ -44,6 +44,9 @@ func (d *badgerDBStore) Get(ctx context.Context, p Plugin) ([]byte, error) {
 		defer it.Close()
 
 		it.Seek(k)
+		if !it.ValidForPrefix(k) {
+			return ErrNotFound
+		}
 		v, err = it.Item().ValueCopy(nil)
 		return err
 	})
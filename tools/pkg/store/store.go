@@ -6,8 +6,12 @@ import (
 	"fmt"
 )
 
+// ErrNotFound is returned when the plugin is not found in the store
+var ErrNotFound = errors.New("plugin not found")
+
 type Store interface {
-	// Get returns a plugin bytes
+	// Get returns a plugin bytes, ErrNotFound is returned when the plugin
+	// is not found
 	Get(ctx context.Context, p Plugin) ([]byte, error)
 	// Save stores a plugin in the store
 	Save(ctx context.Context, p Plugin, v []byte) error
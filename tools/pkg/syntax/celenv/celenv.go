@@ -0,0 +1,67 @@
+// Package celenv provides the CEL environment in which kform checks and
+// evaluates expressions. The parser and the renderer build their environment
+// with this package, such that an expression that is valid at parse time
+// evaluates with the same functions at run time.
+//
+// The libraries are pinned by the versions in go.mod, an expression is
+// portable across kform releases that pin the same versions:
+//   - github.com/google/cel-go v0.17.8: ext.Strings (version 2), ext.Lists,
+//     ext.Sets, ext.Encoders and ext.Math
+//   - k8s.io/apiserver v0.29.15: the Kubernetes libraries for lists, regex,
+//     urls and quantities
+//
+// cel-go is pinned to the version the Kubernetes libraries are built against,
+// both are bumped together. On top of these kform adds its own libraries, see
+// Net and Optional.
+package celenv
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apiserver/pkg/cel/library"
+)
+
+// StringsVersion is the version of the cel-go strings library
+const StringsVersion = 2
+
+// EnvOptions returns the language settings and the libraries of the kform
+// CEL environment, without variables
+func EnvOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.HomogeneousAggregateLiterals(),
+		cel.DefaultUTCTimeZone(true),
+		// cel-go extension libraries
+		ext.Strings(ext.StringsVersion(StringsVersion)),
+		ext.Sets(),
+		ext.Encoders(),
+		ext.Math(),
+		ext.Lists(),
+		// kubernetes libraries
+		library.Lists(),
+		library.Regex(),
+		library.URLs(),
+		library.Quantity(),
+		// kform libraries
+		Net(),
+		Optional(),
+	}
+}
+
+// ProgramOptions returns the options of the programs of the kform CEL
+// environment
+func ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{
+		cel.EvalOptions(cel.OptOptimize),
+		// compile the regex of the regex library once iso on every call
+		cel.OptimizeRegex(library.FindRegexOptimization, library.FindAllRegexOptimization),
+	}
+}
+
+// NewEnv returns a kform CEL environment with the variables
+func NewEnv(vars map[string]*cel.Type) (*cel.Env, error) {
+	opts := EnvOptions()
+	for k, t := range vars {
+		opts = append(opts, cel.Variable(k, t))
+	}
+	return cel.NewEnv(opts...)
+}
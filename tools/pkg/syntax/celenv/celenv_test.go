@@ -0,0 +1,82 @@
+package celenv
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLibraries(t *testing.T) {
+	cases := map[string]struct {
+		expr string
+		want any
+	}{
+		"Strings": {
+			expr: `"a-b-c".split("-").join("_").upperAscii()`,
+			want: "A_B_C",
+		},
+		"Sets": {
+			expr: `sets.contains(x, ["a"])`,
+			want: true,
+		},
+		"Encoders": {
+			expr: `string(base64.decode(base64.encode(b"token")))`,
+			want: "token",
+		},
+		"Math": {
+			expr: `math.greatest([1, 3, 2])`,
+			want: int64(3),
+		},
+		"Lists": {
+			expr: `[1, 2, 3].sum()`,
+			want: int64(6),
+		},
+		"ListsSlice": {
+			expr: `[1, 2, 3, 4].slice(1, 3).sum()`,
+			want: int64(5),
+		},
+		"Regex": {
+			expr: `"vlan-100".find("[0-9]+")`,
+			want: "100",
+		},
+		"URLs": {
+			expr: `url("https://example.com:8443/path").getPort()`,
+			want: "8443",
+		},
+		"Quantity": {
+			expr: `quantity("1Gi").isGreaterThan(quantity("512Mi"))`,
+			want: true,
+		},
+		"Optional": {
+			expr: `optional(x[0])`,
+			want: "a",
+		},
+		"OptionalOmitted": {
+			expr: `optional(x[2])`,
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env, err := NewEnv(map[string]*cel.Type{"x": cel.DynType})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ast, iss := env.Compile(tc.expr)
+			if iss.Err() != nil {
+				t.Fatalf("cannot compile %s, err: %s", tc.expr, iss.Err().Error())
+			}
+			prog, err := env.Program(ast, ProgramOptions()...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			val, _, err := prog.Eval(map[string]any{"x": []string{"a", "b"}})
+			if err != nil {
+				t.Fatalf("cannot evaluate %s, err: %s", tc.expr, err.Error())
+			}
+			assert.Equal(t, tc.want, val.Value())
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package celenv
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/operators"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/sets"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/openapi"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// TypeResolver returns the type of the value of a reference, e.g. the type of
+// input.interface, or nil when the type is unknown, such that the reference is
+// dyn
+type TypeResolver func(ref string) *apiservercel.DeclType
+
+// CheckedExpression is a type-checked expression, the environment in which the
+// expression is checked is used to build the program of the expression at run
+// time
+type CheckedExpression struct {
+	Expression *Expression
+	Env        *cel.Env
+	Ast        *cel.Ast
+	// objects are the object types of the namespaces keyed by type name
+	objects map[string]*apiservercel.DeclType
+}
+
+var (
+	baseEnvOnce sync.Once
+	baseEnv     *cel.Env
+	baseEnvErr  error
+)
+
+func getBaseEnv() (*cel.Env, error) {
+	baseEnvOnce.Do(func() {
+		baseEnv, baseEnvErr = NewEnv(nil)
+	})
+	return baseEnv, baseEnvErr
+}
+
+// Check compiles and type-checks the expression. The namespaces of the
+// expression are objects with a field per reference when the resolver knows
+// the type of a reference of the namespace, otherwise the namespace is dyn.
+func Check(x *Expression, resolve TypeResolver) (*CheckedExpression, error) {
+	base, err := getBaseEnv()
+	if err != nil {
+		return nil, err
+	}
+	opts := []cel.EnvOption{}
+	declTypes := []*apiservercel.DeclType{}
+	objects := map[string]*apiservercel.DeclType{}
+	for _, namespace := range x.Namespaces.UnsortedList() {
+		t := getNamespaceType(x, namespace, resolve)
+		if t == nil {
+			opts = append(opts, cel.Variable(namespace, cel.DynType))
+			continue
+		}
+		declTypes = append(declTypes, t)
+		addObjectTypes(objects, t)
+		opts = append(opts, cel.Variable(namespace, t.CelType()))
+	}
+	if len(declTypes) > 0 {
+		providerOpts, err := apiservercel.NewDeclTypeProvider(declTypes...).EnvOptions(base.TypeProvider())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, providerOpts...)
+	}
+	env, err := base.Extend(opts...)
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Parse(x.Source)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	// a name with a dash is selected by index, the index is checked as the
+	// select of the field of the namespace
+	parsed, err := cel.AstToParsedExpr(ast)
+	if err != nil {
+		return nil, err
+	}
+	selectIndexedNames(parsed.GetExpr(), x.Namespaces)
+	ast, iss = env.Check(cel.ParsedExprToAst(parsed))
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	return &CheckedExpression{Expression: x, Env: env, Ast: ast, objects: objects}, nil
+}
+
+// ResultType returns the type of the result of the expression, nil when the
+// type is dyn or cannot be expressed as a declared type
+func (r *CheckedExpression) ResultType() *apiservercel.DeclType {
+	return getDeclType(r.Ast.ResultType(), r.objects)
+}
+
+// selectIndexedNames replaces the constant string index of a namespace, e.g.
+// kubernetes_manifest["porch-repo"], by a select of the field of the
+// namespace, which evaluates the same on the map of the namespace
+func selectIndexedNames(e *exprpb.Expr, namespaces sets.Set[string]) {
+	if e == nil {
+		return
+	}
+	switch k := e.GetExprKind().(type) {
+	case *exprpb.Expr_SelectExpr:
+		selectIndexedNames(k.SelectExpr.GetOperand(), namespaces)
+	case *exprpb.Expr_CallExpr:
+		call := k.CallExpr
+		if call.GetFunction() == operators.Index && call.GetTarget() == nil && len(call.GetArgs()) == 2 {
+			operand, index := call.GetArgs()[0], call.GetArgs()[1]
+			if operand.GetIdentExpr() != nil && namespaces.Has(operand.GetIdentExpr().GetName()) &&
+				index.GetConstExpr() != nil && index.GetConstExpr().GetStringValue() != "" {
+				e.ExprKind = &exprpb.Expr_SelectExpr{SelectExpr: &exprpb.Expr_Select{
+					Operand: operand,
+					Field:   index.GetConstExpr().GetStringValue(),
+				}}
+				return
+			}
+		}
+		selectIndexedNames(call.GetTarget(), namespaces)
+		for _, arg := range call.GetArgs() {
+			selectIndexedNames(arg, namespaces)
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, elem := range k.ListExpr.GetElements() {
+			selectIndexedNames(elem, namespaces)
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range k.StructExpr.GetEntries() {
+			selectIndexedNames(entry.GetMapKey(), namespaces)
+			selectIndexedNames(entry.GetValue(), namespaces)
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		c := k.ComprehensionExpr
+		for _, x := range []*exprpb.Expr{c.GetIterRange(), c.GetAccuInit(), c.GetLoopCondition(), c.GetLoopStep(), c.GetResult()} {
+			selectIndexedNames(x, namespaces)
+		}
+	}
+}
+
+// getNamespaceType returns the object type of the namespace with a field per
+// reference of the namespace, nil when no reference has a known type. A name
+// with a dash is a field of the namespace as well, see selectIndexedNames.
+func getNamespaceType(x *Expression, namespace string, resolve TypeResolver) *apiservercel.DeclType {
+	if resolve == nil || namespace == NamespaceModule {
+		return nil
+	}
+	fields := map[string]*apiservercel.DeclField{}
+	typed := false
+	for _, ref := range x.References.UnsortedList() {
+		if GetNamespace(ref) != namespace {
+			continue
+		}
+		name := strings.TrimPrefix(ref, namespace+".")
+		t := resolve(ref)
+		if t == nil {
+			t = apiservercel.DynType
+		} else {
+			typed = true
+		}
+		fields[name] = apiservercel.NewDeclField(name, t, true, nil, nil)
+	}
+	if !typed {
+		return nil
+	}
+	return apiservercel.NewObjectType(fmt.Sprintf("kform.%s", namespace), fields)
+}
+
+// NewSchemaType returns the type of a resource with the openapi schema, the
+// resource gets the apiVersion and kind fields of a kubernetes resource and
+// the metadata is dyn since the schema of a custom resource does not hold the
+// object meta, e.g. labels and namespace. The name is the name of the type,
+// which must differ from the path of the references, e.g.
+// kform.schema.req.nephio.org.v1alpha1.Interface.
+func NewSchemaType(name string, s *spec.Schema) *apiservercel.DeclType {
+	t := openapi.SchemaDeclType(s, false)
+	if t == nil || !t.IsObject() {
+		return nil
+	}
+	fields := make(map[string]*apiservercel.DeclField, len(t.Fields)+3)
+	for k, f := range t.Fields {
+		fields[k] = f
+	}
+	fields["apiVersion"] = apiservercel.NewDeclField("apiVersion", apiservercel.StringType, true, nil, nil)
+	fields["kind"] = apiservercel.NewDeclField("kind", apiservercel.StringType, true, nil, nil)
+	fields["metadata"] = apiservercel.NewDeclField("metadata", apiservercel.DynType, true, nil, nil)
+	return apiservercel.NewObjectType("object", fields).MaybeAssignTypeName(name)
+}
+
+// NewListType returns the type of a list of which the elements are of type t
+func NewListType(t *apiservercel.DeclType) *apiservercel.DeclType {
+	return apiservercel.NewListType(t, -1)
+}
+
+// addObjectTypes adds the object type and the object types of its fields and
+// elements to the objects keyed by type name
+func addObjectTypes(objects map[string]*apiservercel.DeclType, t *apiservercel.DeclType) {
+	switch {
+	case t == nil:
+	case t.IsObject():
+		if _, ok := objects[t.TypeName()]; ok {
+			return
+		}
+		objects[t.TypeName()] = t
+		for _, f := range t.Fields {
+			addObjectTypes(objects, f.Type)
+		}
+	case t.IsList():
+		addObjectTypes(objects, t.ElemType)
+	case t.IsMap():
+		addObjectTypes(objects, t.KeyType)
+		addObjectTypes(objects, t.ElemType)
+	}
+}
+
+// getDeclType returns the declared type of the checked type, the object
+// types are looked up by name in objects. It returns nil for dyn and for the
+// types without declared type.
+func getDeclType(t *exprpb.Type, objects map[string]*apiservercel.DeclType) *apiservercel.DeclType {
+	switch k := t.GetTypeKind().(type) {
+	case *exprpb.Type_Primitive:
+		switch k.Primitive {
+		case exprpb.Type_BOOL:
+			return apiservercel.BoolType
+		case exprpb.Type_INT64:
+			return apiservercel.IntType
+		case exprpb.Type_UINT64:
+			return apiservercel.UintType
+		case exprpb.Type_DOUBLE:
+			return apiservercel.DoubleType
+		case exprpb.Type_STRING:
+			return apiservercel.StringType
+		case exprpb.Type_BYTES:
+			return apiservercel.BytesType
+		}
+	case *exprpb.Type_WellKnown:
+		switch k.WellKnown {
+		case exprpb.Type_TIMESTAMP:
+			return apiservercel.TimestampType
+		case exprpb.Type_DURATION:
+			return apiservercel.DurationType
+		}
+	case *exprpb.Type_ListType_:
+		elem := getDeclType(k.ListType.GetElemType(), objects)
+		if elem == nil {
+			elem = apiservercel.DynType
+		}
+		return NewListType(elem)
+	case *exprpb.Type_MapType_:
+		key := getDeclType(k.MapType.GetKeyType(), objects)
+		if key == nil {
+			key = apiservercel.DynType
+		}
+		elem := getDeclType(k.MapType.GetValueType(), objects)
+		if elem == nil {
+			elem = apiservercel.DynType
+		}
+		return apiservercel.NewMapType(key, elem, -1)
+	case *exprpb.Type_MessageType:
+		return objects[k.MessageType]
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package celenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func testInterfaceSchema() *spec.Schema {
+	return &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"spec": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ipFamilyPolicy": *spec.StringProperty(),
+					"vlanID":         *spec.Int64Property(),
+				},
+			}},
+		},
+	}}
+}
+
+func TestCheck(t *testing.T) {
+	resolve := func(ref string) *apiservercel.DeclType {
+		switch ref {
+		case "input.interface", "input.n3-interface":
+			return NewListType(NewSchemaType("kform.schema.req.nephio.org.v1alpha1.Interface", testInterfaceSchema()))
+		case "count.index":
+			return apiservercel.IntType
+		}
+		return nil
+	}
+	vars := map[string]any{
+		"input": map[string]any{
+			"interface": []any{map[string]any{
+				"metadata": map[string]any{"name": "n3", "labels": map[string]any{"a": "b"}},
+				"spec":     map[string]any{"ipFamilyPolicy": "dualstack", "vlanID": 10},
+			}},
+			"other": []any{"x"},
+			"n3-interface": []any{map[string]any{
+				"spec": map[string]any{"ipFamilyPolicy": "ipv4only", "vlanID": 20},
+			}},
+		},
+		"count": map[string]any{"index": 0},
+	}
+	cases := map[string]struct {
+		expr      string
+		want      any
+		expectErr bool
+	}{
+		"Typed": {
+			expr: "$input.interface[$count.index].spec.ipFamilyPolicy == 'dualstack'",
+			want: true,
+		},
+		"TypedMetadata": {
+			expr: "$input.interface[0].metadata.labels.a",
+			want: "b",
+		},
+		"TypedInt": {
+			expr: "$input.interface[0].spec.vlanID + 1",
+			want: int64(11),
+		},
+		"TypedDash": {
+			expr: "$input.n3-interface[0].spec.vlanID + $input.interface[0].spec.vlanID",
+			want: int64(30),
+		},
+		"UndefinedFieldDash": {
+			expr:      "$input.n3-interface[0].spec.vlanId",
+			expectErr: true,
+		},
+		"Dyn": {
+			expr: "$input.other[0] + $local.x[0]",
+			want: "xy",
+		},
+		"UndefinedField": {
+			expr:      "$input.interface[0].spec.ipFamly",
+			expectErr: true,
+		},
+		"NoSuchOverload": {
+			expr:      "$input.interface[0].spec.vlanID + 'a'",
+			expectErr: true,
+		},
+		"UndeclaredFunction": {
+			expr:      "foo($input.other)",
+			expectErr: true,
+		},
+	}
+	vars["local"] = map[string]any{"x": []any{"y"}}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x, err := ParseExpression(tc.expr)
+			if !assert.NoError(t, err) {
+				return
+			}
+			c, err := Check(x, resolve)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			prog, err := c.Env.Program(c.Ast, ProgramOptions()...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			val, _, err := prog.Eval(vars)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.want, val.Value())
+		})
+	}
+}
+
+func TestCheckResultType(t *testing.T) {
+	resolve := func(ref string) *apiservercel.DeclType {
+		if ref == "input.interface" {
+			return NewListType(NewSchemaType("kform.schema.req.nephio.org.v1alpha1.Interface", testInterfaceSchema()))
+		}
+		return nil
+	}
+	cases := map[string]struct {
+		expr string
+		want string
+	}{
+		"Object": {
+			expr: "$input.interface[0]",
+			want: "kform.schema.req.nephio.org.v1alpha1.Interface",
+		},
+		"NestedObject": {
+			expr: "$input.interface[0].spec",
+			want: "kform.schema.req.nephio.org.v1alpha1.Interface.spec",
+		},
+		"List": {
+			expr: "$input.interface",
+			want: "list(kform.schema.req.nephio.org.v1alpha1.Interface)",
+		},
+		"Map": {
+			expr: "{'a': $input.interface[0].spec.vlanID}",
+			want: "map(string, int)",
+		},
+		"Dyn": {
+			expr: "$input.other",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x, err := ParseExpression(tc.expr)
+			if !assert.NoError(t, err) {
+				return
+			}
+			c, err := Check(x, resolve)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got := c.ResultType()
+			if tc.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NotNil(t, got) {
+				assert.Equal(t, tc.want, got.CelType().String())
+			}
+		})
+	}
+}
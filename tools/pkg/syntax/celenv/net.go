@@ -0,0 +1,318 @@
+package celenv
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+)
+
+// Net returns the kform networking library, the functions work on IPv4 and
+// IPv6 prefixes and addresses in their string representation.
+//
+//	cidrSubnet(<string>, <int>, <int>) <string>
+//
+// Returns the netnum-th subnet of the prefix extended with newbits.
+//
+//	cidrSubnet("10.0.0.0/16", 8, 2) // returns "10.0.2.0/24"
+//	cidrSubnet("2001:db8::/48", 16, 1) // returns "2001:db8:0:1::/64"
+//
+//	cidrSubnets(<string>, <list <int>>) <list <string>>
+//
+// Returns consecutive subnets of the prefix, one per newbits in the list.
+// Every subnet starts at the first address after the previous subnet that is
+// aligned to the size of the subnet.
+//
+//	cidrSubnets("10.0.0.0/24", [7, 7, 2]) // returns ["10.0.0.0/31", "10.0.0.2/31", "10.0.0.64/26"]
+//
+//	cidrHost(<string>, <int>) <string>
+//
+// Returns the hostnum-th address of the prefix, a negative hostnum counts
+// back from the last address of the prefix.
+//
+//	cidrHost("10.0.0.0/24", 1) // returns "10.0.0.1"
+//	cidrHost("10.0.0.0/24", -2) // returns "10.0.0.254"
+//
+//	cidrNetmask(<string>) <string>
+//
+// Returns the netmask of the prefix in address notation.
+//
+//	cidrNetmask("10.0.0.0/20") // returns "255.255.240.0"
+//	cidrNetmask("2001:db8::/32") // returns "ffff:ffff::"
+//
+//	cidrContains(<string>, <string>) <bool>
+//
+// Returns true when the prefix contains the address or the prefix.
+//
+//	cidrContains("10.0.0.0/16", "10.0.1.1") // returns true
+//	cidrContains("10.0.0.0/16", "10.0.0.0/8") // returns false
+//
+//	ipAdd(<string>, <int>) <string>
+//
+// Returns the address plus n, an address with prefix length keeps its
+// prefix length.
+//
+//	ipAdd("10.0.0.1", 1) // returns "10.0.0.2"
+//	ipAdd("10.0.0.1/24", 1) // returns "10.0.0.2/24"
+//	ipAdd("2001:db8::ffff", 1) // returns "2001:db8::1:0"
+//
+//	ipFamily(<string>) <string>
+//
+// Returns the family, ipv4 or ipv6, of the address or prefix.
+//
+//	ipFamily("10.0.0.0/8") // returns "ipv4"
+func Net() cel.EnvOption {
+	return cel.Lib(netLib)
+}
+
+var netLib = &netLibrary{}
+
+type netLibrary struct{}
+
+var netLibraryDecls = map[string][]cel.FunctionOpt{
+	"cidrSubnet": {
+		cel.Overload("cidr_subnet_string_int_int", []*cel.Type{cel.StringType, cel.IntType, cel.IntType}, cel.StringType,
+			cel.FunctionBinding(cidrSubnet))},
+	"cidrSubnets": {
+		cel.Overload("cidr_subnets_string_list_int", []*cel.Type{cel.StringType, cel.ListType(cel.IntType)}, cel.ListType(cel.StringType),
+			cel.BinaryBinding(cidrSubnets))},
+	"cidrHost": {
+		cel.Overload("cidr_host_string_int", []*cel.Type{cel.StringType, cel.IntType}, cel.StringType,
+			cel.BinaryBinding(cidrHost))},
+	"cidrNetmask": {
+		cel.Overload("cidr_netmask_string", []*cel.Type{cel.StringType}, cel.StringType,
+			cel.UnaryBinding(cidrNetmask))},
+	"cidrContains": {
+		cel.Overload("cidr_contains_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+			cel.BinaryBinding(cidrContains))},
+	"ipAdd": {
+		cel.Overload("ip_add_string_int", []*cel.Type{cel.StringType, cel.IntType}, cel.StringType,
+			cel.BinaryBinding(ipAdd))},
+	"ipFamily": {
+		cel.Overload("ip_family_string", []*cel.Type{cel.StringType}, cel.StringType,
+			cel.UnaryBinding(ipFamily))},
+}
+
+func (*netLibrary) CompileOptions() []cel.EnvOption {
+	options := []cel.EnvOption{}
+	for name, overloads := range netLibraryDecls {
+		options = append(options, cel.Function(name, overloads...))
+	}
+	return options
+}
+
+func (*netLibrary) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{}
+}
+
+func cidrSubnet(args ...ref.Val) ref.Val {
+	if len(args) != 3 {
+		return types.NewErr("cidrSubnet expects 3 arguments, got: %d", len(args))
+	}
+	p, err := parsePrefix(args[0])
+	if err != nil {
+		return types.NewErr("cidrSubnet: %s", err.Error())
+	}
+	newbits, ok := args[1].Value().(int64)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(args[1])
+	}
+	netnum, ok := args[2].Value().(int64)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(args[2])
+	}
+	subnet, err := getSubnet(p, newbits, big.NewInt(netnum))
+	if err != nil {
+		return types.NewErr("cidrSubnet: %s", err.Error())
+	}
+	return types.String(subnet.String())
+}
+
+func cidrSubnets(arg, newbitsList ref.Val) ref.Val {
+	p, err := parsePrefix(arg)
+	if err != nil {
+		return types.NewErr("cidrSubnets: %s", err.Error())
+	}
+	l, ok := newbitsList.(traits.Lister)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(newbitsList)
+	}
+	subnets := []string{}
+	// next is the offset of the first free address in the prefix
+	next := big.NewInt(0)
+	size := len(p.Addr().AsSlice()) * 8
+	for it := l.Iterator(); it.HasNext() == types.True; {
+		v := it.Next()
+		newbits, ok := v.Value().(int64)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(v)
+		}
+		if newbits < 0 || p.Bits()+int(newbits) > size {
+			return types.NewErr("cidrSubnets: cannot extend prefix %s with %d bits", p.String(), newbits)
+		}
+		// the number of the subnet is the first subnet that starts at or
+		// after the next free address
+		subnetSize := new(big.Int).Lsh(big.NewInt(1), uint(size-p.Bits()-int(newbits)))
+		netnum := new(big.Int).Add(next, new(big.Int).Sub(subnetSize, big.NewInt(1)))
+		netnum.Div(netnum, subnetSize)
+		subnet, err := getSubnet(p, newbits, netnum)
+		if err != nil {
+			return types.NewErr("cidrSubnets: not enough space in prefix %s for %d subnets", p.String(), len(subnets)+1)
+		}
+		subnets = append(subnets, subnet.String())
+		next = new(big.Int).Mul(new(big.Int).Add(netnum, big.NewInt(1)), subnetSize)
+	}
+	return types.NewStringList(types.DefaultTypeAdapter, subnets)
+}
+
+func cidrHost(arg, hostnumArg ref.Val) ref.Val {
+	p, err := parsePrefix(arg)
+	if err != nil {
+		return types.NewErr("cidrHost: %s", err.Error())
+	}
+	hostnum, ok := hostnumArg.Value().(int64)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(hostnumArg)
+	}
+	hostbits := len(p.Addr().AsSlice())*8 - p.Bits()
+	hosts := new(big.Int).Lsh(big.NewInt(1), uint(hostbits))
+	n := big.NewInt(hostnum)
+	if hostnum < 0 {
+		n.Add(n, hosts)
+	}
+	if n.Sign() < 0 || n.Cmp(hosts) >= 0 {
+		return types.NewErr("cidrHost: prefix %s has no host %d", p.String(), hostnum)
+	}
+	addr, err := addInt(p.Addr(), n)
+	if err != nil {
+		return types.NewErr("cidrHost: %s", err.Error())
+	}
+	return types.String(addr.String())
+}
+
+func cidrNetmask(arg ref.Val) ref.Val {
+	p, err := parsePrefix(arg)
+	if err != nil {
+		return types.NewErr("cidrNetmask: %s", err.Error())
+	}
+	b := make([]byte, len(p.Addr().AsSlice()))
+	for i := 0; i < p.Bits(); i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	mask, _ := netip.AddrFromSlice(b)
+	return types.String(mask.String())
+}
+
+func cidrContains(arg, other ref.Val) ref.Val {
+	p, err := parsePrefix(arg)
+	if err != nil {
+		return types.NewErr("cidrContains: %s", err.Error())
+	}
+	s, ok := other.Value().(string)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(other)
+	}
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return types.Bool(p.Contains(addr))
+	}
+	o, err := netip.ParsePrefix(s)
+	if err != nil {
+		return types.NewErr("cidrContains: invalid address or prefix %s", s)
+	}
+	return types.Bool(o.Bits() >= p.Bits() && p.Contains(o.Addr()))
+}
+
+func ipAdd(arg, nArg ref.Val) ref.Val {
+	s, ok := arg.Value().(string)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(arg)
+	}
+	n, ok := nArg.Value().(int64)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(nArg)
+	}
+	if addr, err := netip.ParseAddr(s); err == nil {
+		addr, err := addInt(addr, big.NewInt(n))
+		if err != nil {
+			return types.NewErr("ipAdd: %s", err.Error())
+		}
+		return types.String(addr.String())
+	}
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return types.NewErr("ipAdd: invalid address %s", s)
+	}
+	addr, err := addInt(p.Addr(), big.NewInt(n))
+	if err != nil {
+		return types.NewErr("ipAdd: %s", err.Error())
+	}
+	return types.String(netip.PrefixFrom(addr, p.Bits()).String())
+}
+
+func ipFamily(arg ref.Val) ref.Val {
+	s, ok := arg.Value().(string)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(arg)
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return types.NewErr("ipFamily: invalid address or prefix %s", s)
+		}
+		addr = p.Addr()
+	}
+	if addr.Is4() {
+		return types.String("ipv4")
+	}
+	return types.String("ipv6")
+}
+
+// parsePrefix returns the masked prefix, e.g. 10.0.0.0/24 for 10.0.0.1/24
+func parsePrefix(arg ref.Val) (netip.Prefix, error) {
+	s, ok := arg.Value().(string)
+	if !ok {
+		return netip.Prefix{}, fmt.Errorf("expected a prefix string, got: %v", arg.Type())
+	}
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid prefix %s", s)
+	}
+	return p.Masked(), nil
+}
+
+// getSubnet returns the netnum-th subnet of the prefix extended with newbits
+func getSubnet(p netip.Prefix, newbits int64, netnum *big.Int) (netip.Prefix, error) {
+	size := len(p.Addr().AsSlice()) * 8
+	if newbits < 0 || p.Bits()+int(newbits) > size {
+		return netip.Prefix{}, fmt.Errorf("cannot extend prefix %s with %d bits", p.String(), newbits)
+	}
+	max := new(big.Int).Lsh(big.NewInt(1), uint(newbits))
+	if netnum.Sign() < 0 || netnum.Cmp(max) >= 0 {
+		return netip.Prefix{}, fmt.Errorf("prefix %s extended with %d bits has no subnet %s", p.String(), newbits, netnum.String())
+	}
+	bits := p.Bits() + int(newbits)
+	offset := new(big.Int).Lsh(netnum, uint(size-bits))
+	addr, err := addInt(p.Addr(), offset)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, bits), nil
+}
+
+// addInt returns the address plus n, the address cannot overflow its family
+func addInt(addr netip.Addr, n *big.Int) (netip.Addr, error) {
+	x := new(big.Int).SetBytes(addr.AsSlice())
+	x.Add(x, n)
+	size := len(addr.AsSlice())
+	if x.Sign() < 0 || x.BitLen() > size*8 {
+		return netip.Addr{}, fmt.Errorf("address %s plus %s is out of range", addr.String(), n.String())
+	}
+	b := x.FillBytes(make([]byte, size))
+	newAddr, _ := netip.AddrFromSlice(b)
+	return newAddr.WithZone(addr.Zone()), nil
+}
@@ -0,0 +1,116 @@
+package celenv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+)
+
+var reflectStringSlice = reflect.TypeOf([]string{})
+
+func TestNet(t *testing.T) {
+	cases := map[string]struct {
+		expr      string
+		want      any
+		expectErr bool
+	}{
+		"CidrSubnetIPv4": {
+			expr: `cidrSubnet("10.0.0.0/16", 8, 2)`,
+			want: "10.0.2.0/24",
+		},
+		"CidrSubnetIPv6": {
+			expr: `cidrSubnet("2001:db8::/48", 16, 1)`,
+			want: "2001:db8:0:1::/64",
+		},
+		"CidrSubnetOutOfRange": {
+			expr:      `cidrSubnet("10.0.0.0/16", 8, 256)`,
+			expectErr: true,
+		},
+		"CidrSubnets": {
+			expr: `cidrSubnets("10.0.0.0/24", [7, 7, 2])`,
+			want: []string{"10.0.0.0/31", "10.0.0.2/31", "10.0.0.64/26"},
+		},
+		"CidrSubnetsNoSpace": {
+			expr:      `cidrSubnets("10.0.0.0/24", [1, 1, 1])`,
+			expectErr: true,
+		},
+		"CidrHostGateway": {
+			expr: `cidrHost(x.status.prefix, 1)`,
+			want: "10.0.0.1",
+		},
+		"CidrHostLast": {
+			expr: `cidrHost("2001:db8::/64", -1)`,
+			want: "2001:db8::ffff:ffff:ffff:ffff",
+		},
+		"CidrNetmaskIPv4": {
+			expr: `cidrNetmask("10.0.0.0/20")`,
+			want: "255.255.240.0",
+		},
+		"CidrNetmaskIPv6": {
+			expr: `cidrNetmask("2001:db8::/32")`,
+			want: "ffff:ffff::",
+		},
+		"CidrContainsAddress": {
+			expr: `cidrContains("10.0.0.0/16", "10.0.1.1")`,
+			want: true,
+		},
+		"CidrContainsPrefix": {
+			expr: `cidrContains("10.0.0.0/16", "10.0.0.0/8")`,
+			want: false,
+		},
+		"IPAdd": {
+			expr: `ipAdd("10.0.0.1/24", 1)`,
+			want: "10.0.0.2/24",
+		},
+		"IPAddIPv6": {
+			expr: `ipAdd("2001:db8::ffff", 1)`,
+			want: "2001:db8::1:0",
+		},
+		"IPAddOverflow": {
+			expr:      `ipAdd("255.255.255.255", 1)`,
+			expectErr: true,
+		},
+		"IPFamily": {
+			expr: `[ipFamily("10.0.0.0/8"), ipFamily("2001:db8::1")]`,
+			want: []string{"ipv4", "ipv6"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env, err := NewEnv(map[string]*cel.Type{"x": cel.DynType})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ast, iss := env.Compile(tc.expr)
+			if iss.Err() != nil {
+				t.Fatalf("cannot compile %s, err: %s", tc.expr, iss.Err().Error())
+			}
+			prog, err := env.Program(ast, ProgramOptions()...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			val, _, err := prog.Eval(map[string]any{
+				"x": map[string]any{"status": map[string]any{"prefix": "10.0.0.0/24"}},
+			})
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot evaluate %s, err: %s", tc.expr, err.Error())
+			}
+			if want, ok := tc.want.([]string); ok {
+				got, err := val.ConvertToNative(reflectStringSlice)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, want, got)
+				return
+			}
+			assert.Equal(t, tc.want, val.Value())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package celenv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// Optional returns the kform library for the intentional omission of a
+// value.
+//
+//	optional(<T>) <T>
+//
+// Returns the value, or the omitted value when the value cannot be
+// resolved, e.g. a reference without value or a key that does not exist.
+// Any other error, e.g. a division by zero or a type mismatch, is returned.
+// The renderer removes an omitted value from its map or list, a template
+// with an omitted value is omitted as a whole.
+//
+//	optional($input.interface[0].spec.vlanID) // omits the key when the interface has no vlanID
+func Optional() cel.EnvOption {
+	return cel.Lib(optionalLib)
+}
+
+var optionalLib = &optionalLibrary{}
+
+type optionalLibrary struct{}
+
+func (*optionalLibrary) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("optional",
+			cel.Overload("optional_T", []*cel.Type{cel.TypeParamType("T")}, cel.TypeParamType("T"),
+				// the argument can be unknown or an error
+				cel.OverloadIsNonStrict(),
+				cel.UnaryBinding(optional))),
+	}
+}
+
+func (*optionalLibrary) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{}
+}
+
+func optional(arg ref.Val) ref.Val {
+	if isUnresolved(arg) {
+		return Omitted
+	}
+	return arg
+}
+
+// isUnresolved returns true when the value is unknown, i.e. a reference
+// without value, or the error of a key, an index or an attribute that does
+// not exist. cel-go has no error codes, the errors are matched by message.
+func isUnresolved(v ref.Val) bool {
+	if types.IsUnknown(v) {
+		return true
+	}
+	err, ok := v.(*types.Err)
+	if !ok {
+		return false
+	}
+	msg := err.String()
+	return strings.HasPrefix(msg, "no such key:") ||
+		strings.HasPrefix(msg, "no such attribute") ||
+		strings.HasPrefix(msg, "index out of bounds:") ||
+		(strings.HasPrefix(msg, "index '") && strings.Contains(msg, "' out of range in list size '"))
+}
+
+// OmittedType is the type of the omitted value
+var OmittedType = types.NewTypeValue("kform.omitted")
+
+// Omitted is the value of an optional value that cannot be resolved
+var Omitted ref.Val = omitted{}
+
+// IsOmitted returns true if the value is the omitted value
+func IsOmitted(v any) bool {
+	_, ok := v.(omitted)
+	return ok
+}
+
+type omitted struct{}
+
+func (omitted) ConvertToNative(typeDesc reflect.Type) (any, error) {
+	return nil, fmt.Errorf("an omitted value cannot be converted to %v", typeDesc)
+}
+
+func (omitted) ConvertToType(typeVal ref.Type) ref.Val {
+	if typeVal == types.TypeType {
+		return OmittedType
+	}
+	return types.NewErr("an omitted value cannot be converted to %s", typeVal.TypeName())
+}
+
+func (omitted) Equal(other ref.Val) ref.Val {
+	return types.Bool(IsOmitted(other))
+}
+
+func (omitted) Type() ref.Type {
+	return OmittedType
+}
+
+func (omitted) Value() any {
+	return nil
+}
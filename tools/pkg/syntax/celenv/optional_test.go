@@ -0,0 +1,94 @@
+package celenv
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	cases := map[string]struct {
+		expr        string
+		want        any
+		wantOmitted bool
+		expectErr   bool
+	}{
+		"Value": {
+			expr: `optional(x.list[0])`,
+			want: "a",
+		},
+		"IndexOutOfRange": {
+			expr:        `optional(x.list[2])`,
+			wantOmitted: true,
+		},
+		"DynamicIndexOutOfRange": {
+			expr:        `optional(x.list[size(x.list)])`,
+			wantOmitted: true,
+		},
+		"NoSuchKey": {
+			expr:        `optional(x.map.b)`,
+			wantOmitted: true,
+		},
+		"NoSuchIndexKey": {
+			expr:        `optional(x.map["b"])`,
+			wantOmitted: true,
+		},
+		"Unknown": {
+			expr:        `optional(y.list[0])`,
+			wantOmitted: true,
+		},
+		"DivideByZero": {
+			expr:      `optional(x.count / 0)`,
+			expectErr: true,
+		},
+		"NoSuchOverload": {
+			expr:      `optional(x.list[0] + 1)`,
+			expectErr: true,
+		},
+		"Conversion": {
+			expr:      `optional(int(x.map.a))`,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env, err := NewEnv(map[string]*cel.Type{"x": cel.DynType, "y": cel.DynType})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ast, iss := env.Compile(tc.expr)
+			if iss.Err() != nil {
+				t.Fatalf("cannot compile %s, err: %s", tc.expr, iss.Err().Error())
+			}
+			prog, err := env.Program(ast, append(ProgramOptions(), cel.EvalOptions(cel.OptPartialEval))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			vars, err := cel.PartialVars(map[string]any{
+				"x": map[string]any{
+					"list":  []any{"a", "b"},
+					"map":   map[string]any{"a": "text"},
+					"count": 1,
+				},
+			}, cel.AttributePattern("y"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			val, _, err := prog.Eval(vars)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot evaluate %s, err: %s", tc.expr, err.Error())
+			}
+			if tc.wantOmitted {
+				assert.True(t, IsOmitted(val), "want omitted, got: %v", val)
+				return
+			}
+			assert.Equal(t, tc.want, val.Value())
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package celenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/sets"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+const (
+	// NamespaceModule is the namespace of the outputs of the modules, a
+	// reference to a module output is module.<name>.<output>
+	NamespaceModule = "module"
+	// NamespaceEach and NamespaceCount are the namespaces of the loop
+	// variables, e.g. each.value and count.index
+	NamespaceEach  = "each"
+	NamespaceCount = "count"
+)
+
+// Expression is a kform expression that is prepared for CEL. The references
+// of the expression, e.g. $input.interface, are rooted at the variable of
+// their namespace, e.g. input, such that CEL evaluates the selects and
+// indexes of the references.
+type Expression struct {
+	// Source is the CEL source of the expression, the $ of the references is
+	// removed and names with a dash are selected by index, e.g.
+	// $kubernetes_manifest.porch-repo becomes kubernetes_manifest["porch-repo"]
+	Source string
+	// References are the references of the expression, <namespace>.<name> or
+	// module.<name>.<output> for the output of a module
+	References sets.Set[string]
+	// Namespaces are the namespaces of the references, they are the
+	// variables of the expression
+	Namespaces sets.Set[string]
+}
+
+var (
+	parseEnvOnce sync.Once
+	parseEnv     *cel.Env
+	parseEnvErr  error
+)
+
+func getParseEnv() (*cel.Env, error) {
+	parseEnvOnce.Do(func() {
+		parseEnv, parseEnvErr = cel.NewEnv(EnvOptions()...)
+	})
+	return parseEnv, parseEnvErr
+}
+
+// ParseExpression parses the kform expression with the CEL parser and returns
+// the references of the expression. A reference starts with a $ outside of a
+// string literal and is the select or index chain rooted at its namespace,
+// e.g. $input.interface[0].spec references input.interface.
+func ParseExpression(expr string) (*Expression, error) {
+	return parseExpression(expr, false)
+}
+
+// parseExpression parses the expression, with bare every identifier that is
+// not bound by a macro is a namespace, such that the $ of the references is
+// optional, e.g. ${input.name}.
+func parseExpression(expr string, bare bool) (*Expression, error) {
+	src, namespaces := rewriteReferences(expr, bare)
+	x := &Expression{
+		Source:     src,
+		References: sets.New[string](),
+		Namespaces: sets.New[string](),
+	}
+	if namespaces.Len() == 0 && !bare {
+		return x, nil
+	}
+	env, err := getParseEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Parse(src)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("cannot parse expression %s, err: %s", expr, iss.Err().Error())
+	}
+	parsed, err := cel.AstToParsedExpr(ast)
+	if err != nil {
+		return nil, err
+	}
+	w := &refWalker{bare: bare, namespaces: namespaces, bound: map[string]int{}, expr: x}
+	if err := w.walk(parsed.GetExpr()); err != nil {
+		return nil, fmt.Errorf("invalid expression %s, err: %s", expr, err.Error())
+	}
+	return x, nil
+}
+
+// GetNamespace returns the namespace of a reference, e.g. input for
+// input.interface
+func GetNamespace(ref string) string {
+	namespace, _, _ := strings.Cut(ref, ".")
+	return namespace
+}
+
+// typeIdents are the identifiers of the CEL types, they are no namespace
+var typeIdents = sets.New[string]("bool", "bytes", "double", "duration", "dyn", "int", "list", "map", "null_type", "string", "timestamp", "type", "uint")
+
+type refWalker struct {
+	// bare is true when every free identifier is a namespace
+	bare bool
+	// namespaces are the identifiers that were prefixed with a $
+	namespaces sets.Set[string]
+	// bound are the variables of the macros in scope, e.g. i in
+	// input.x.all(i, i > 0)
+	bound map[string]int
+	expr  *Expression
+}
+
+func (r *refWalker) isNamespace(name string) bool {
+	if r.bound[name] > 0 {
+		return false
+	}
+	if r.bare {
+		return !typeIdents.Has(name)
+	}
+	return r.namespaces.Has(name)
+}
+
+// step is a select or index of a select or index chain
+type step struct {
+	name string
+	// dynamic is true for an index that is not a constant string
+	dynamic bool
+}
+
+func (r *refWalker) walk(e *exprpb.Expr) error {
+	if e == nil {
+		return nil
+	}
+	switch x := e.GetExprKind().(type) {
+	case *exprpb.Expr_IdentExpr:
+		if r.isNamespace(x.IdentExpr.GetName()) {
+			return fmt.Errorf("a reference always needs <namespace>.<name>, got: %s", x.IdentExpr.GetName())
+		}
+	case *exprpb.Expr_SelectExpr:
+		return r.walkChain(e)
+	case *exprpb.Expr_CallExpr:
+		if x.CallExpr.GetFunction() == "_[_]" && x.CallExpr.GetTarget() == nil && len(x.CallExpr.GetArgs()) == 2 {
+			return r.walkChain(e)
+		}
+		if err := r.walk(x.CallExpr.GetTarget()); err != nil {
+			return err
+		}
+		for _, arg := range x.CallExpr.GetArgs() {
+			if err := r.walk(arg); err != nil {
+				return err
+			}
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, elem := range x.ListExpr.GetElements() {
+			if err := r.walk(elem); err != nil {
+				return err
+			}
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range x.StructExpr.GetEntries() {
+			if err := r.walk(entry.GetMapKey()); err != nil {
+				return err
+			}
+			if err := r.walk(entry.GetValue()); err != nil {
+				return err
+			}
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		c := x.ComprehensionExpr
+		for _, e := range []*exprpb.Expr{c.GetIterRange(), c.GetAccuInit()} {
+			if err := r.walk(e); err != nil {
+				return err
+			}
+		}
+		// the iteration and accumulation variables are bound in the loop
+		// and the result
+		r.bound[c.GetIterVar()]++
+		r.bound[c.GetAccuVar()]++
+		defer func() {
+			r.bound[c.GetIterVar()]--
+			r.bound[c.GetAccuVar()]--
+		}()
+		for _, e := range []*exprpb.Expr{c.GetLoopCondition(), c.GetLoopStep(), c.GetResult()} {
+			if err := r.walk(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkChain walks a select or index chain down to its root, a chain rooted
+// at a namespace is a reference
+func (r *refWalker) walkChain(e *exprpb.Expr) error {
+	steps := []step{}
+	for {
+		switch x := e.GetExprKind().(type) {
+		case *exprpb.Expr_SelectExpr:
+			steps = append(steps, step{name: x.SelectExpr.GetField()})
+			e = x.SelectExpr.GetOperand()
+			continue
+		case *exprpb.Expr_CallExpr:
+			if x.CallExpr.GetFunction() == "_[_]" && x.CallExpr.GetTarget() == nil && len(x.CallExpr.GetArgs()) == 2 {
+				index := x.CallExpr.GetArgs()[1]
+				if s, ok := index.GetConstExpr().GetConstantKind().(*exprpb.Constant_StringValue); ok {
+					steps = append(steps, step{name: s.StringValue})
+				} else {
+					steps = append(steps, step{dynamic: true})
+					// the index can hold references, e.g. $input.x[$count.index]
+					if err := r.walk(index); err != nil {
+						return err
+					}
+				}
+				e = x.CallExpr.GetArgs()[0]
+				continue
+			}
+		case *exprpb.Expr_IdentExpr:
+			namespace := x.IdentExpr.GetName()
+			if !r.isNamespace(namespace) {
+				// e.g. the variable of a comprehension
+				return nil
+			}
+			return r.addReference(namespace, steps)
+		}
+		// the root of the chain is no identifier, e.g. a function call
+		return r.walk(e)
+	}
+}
+
+// addReference adds the reference of the namespace, the steps are in reverse
+// order, the last step is the first select of the namespace
+func (r *refWalker) addReference(namespace string, steps []step) error {
+	n := 1
+	if namespace == NamespaceModule {
+		n = 2
+	}
+	path := []string{namespace}
+	for i := 0; i < n; i++ {
+		idx := len(steps) - 1 - i
+		if idx < 0 || steps[idx].dynamic {
+			if namespace == NamespaceModule {
+				return fmt.Errorf("a module reference always needs module.<name>.<output>, got: %s", strings.Join(path, "."))
+			}
+			return fmt.Errorf("a reference always needs <namespace>.<name>, got: %s", strings.Join(path, "."))
+		}
+		path = append(path, steps[idx].name)
+	}
+	r.expr.References.Insert(strings.Join(path, "."))
+	r.expr.Namespaces.Insert(namespace)
+	return nil
+}
+
+// rewriteReferences removes the $ of the references outside of the string
+// literals of the expression and returns the namespaces of the references.
+// The name of a reference can contain a dash, such name is selected by
+// index, e.g. $kubernetes_manifest.porch-repo becomes
+// kubernetes_manifest["porch-repo"]. With bare every identifier that starts
+// a select chain is a reference, such that the names with a dash of
+// ${kubernetes_manifest.porch-repo} are rewritten as well, the namespaces
+// of the bare references are resolved when the expression is walked.
+func rewriteReferences(expr string, bare bool) (string, sets.Set[string]) {
+	namespaces := sets.New[string]()
+	var sb strings.Builder
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := scanStringLiteral(expr, i)
+			sb.WriteString(expr[i:end])
+			i = end
+		case c == '$' && i+1 < len(expr) && isIdentStart(expr[i+1]):
+			namespace := scanIdent(expr, i+1, false)
+			namespaces.Insert(namespace)
+			sb.WriteString(namespace)
+			i = rewriteNames(expr, i+1+len(namespace), namespace, &sb)
+		case isIdentChar(c):
+			// an identifier, a keyword or a number, the identifier of a
+			// select, e.g. b of a.b, does not start a reference
+			ident := scanIdent(expr, i, false)
+			sb.WriteString(ident)
+			if bare && isIdentStart(c) && !typeIdents.Has(ident) && !isSelect(expr, i) {
+				i = rewriteNames(expr, i+len(ident), ident, &sb)
+				continue
+			}
+			i += len(ident)
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String(), namespaces
+}
+
+// rewriteNames writes the names of the reference of the namespace that
+// start at i and returns the end of the names. The name of the reference,
+// and the output of a module reference, can contain a dash, such name is
+// selected by index.
+func rewriteNames(expr string, i int, namespace string, sb *strings.Builder) int {
+	names := 1
+	if namespace == NamespaceModule {
+		names = 2
+	}
+	dash := namespace != NamespaceEach && namespace != NamespaceCount
+	for n := 0; n < names && i+1 < len(expr) && expr[i] == '.' && isIdentStart(expr[i+1]); n++ {
+		name := scanIdent(expr, i+1, dash)
+		if strings.Contains(name, "-") {
+			sb.WriteString("[" + strconv.Quote(name) + "]")
+		} else {
+			sb.WriteString("." + name)
+		}
+		i += 1 + len(name)
+	}
+	return i
+}
+
+// isSelect returns true when the identifier at i is the field of a select,
+// e.g. b of a.b or a. b
+func isSelect(expr string, i int) bool {
+	j := i - 1
+	for j >= 0 && (expr[j] == ' ' || expr[j] == '\t' || expr[j] == '\n' || expr[j] == '\r') {
+		j--
+	}
+	return j >= 0 && expr[j] == '.'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// scanIdent returns the identifier that starts at i, with dash a dash
+// followed by an identifier character is part of the identifier
+func scanIdent(expr string, i int, dash bool) string {
+	j := i
+	for j < len(expr) {
+		if isIdentChar(expr[j]) || (dash && expr[j] == '-' && j+1 < len(expr) && isIdentChar(expr[j+1])) {
+			j++
+			continue
+		}
+		break
+	}
+	return expr[i:j]
+}
+
+// scanStringLiteral returns the end of the CEL string literal that starts
+// with the quote at i. A raw string literal, prefixed with r or R, has no
+// escapes. An unterminated literal ends at the end of the expression.
+func scanStringLiteral(expr string, i int) int {
+	raw := false
+	for j := i - 1; j >= 0 && j >= i-2; j-- {
+		if expr[j] == 'r' || expr[j] == 'R' {
+			raw = true
+		}
+		if expr[j] != 'r' && expr[j] != 'R' && expr[j] != 'b' && expr[j] != 'B' {
+			break
+		}
+	}
+	quote := expr[i : i+1]
+	if strings.HasPrefix(expr[i:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	j := i + len(quote)
+	for j < len(expr) {
+		if !raw && expr[j] == '\\' {
+			j += 2
+			continue
+		}
+		if strings.HasPrefix(expr[j:], quote) {
+			return j + len(quote)
+		}
+		j++
+	}
+	return len(expr)
+}
@@ -0,0 +1,131 @@
+package celenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	cases := map[string]struct {
+		expr      string
+		source    string
+		refs      []string
+		expectErr bool
+	}{
+		"NoReference": {
+			expr:   `"a.b" + "c"`,
+			source: `"a.b" + "c"`,
+			refs:   []string{},
+		},
+		"Select": {
+			expr:   `$input.interface[0].spec.networkInstance.name != 'default'`,
+			source: `input.interface[0].spec.networkInstance.name != 'default'`,
+			refs:   []string{"input.interface"},
+		},
+		"StringLiteral": {
+			expr:   `$local.a + "$input.b" + '$input.c' + r"$input.d\" + """$input.e"""`,
+			source: `local.a + "$input.b" + '$input.c' + r"$input.d\" + """$input.e"""`,
+			refs:   []string{"local.a"},
+		},
+		"NoCollision": {
+			expr:   `$input.b_c + $input_b.c`,
+			source: `input.b_c + input_b.c`,
+			refs:   []string{"input.b_c", "input_b.c"},
+		},
+		"Dash": {
+			expr:   `$kubernetes_manifest.porch-repo[0].metadata.name`,
+			source: `kubernetes_manifest["porch-repo"][0].metadata.name`,
+			refs:   []string{"kubernetes_manifest.porch-repo"},
+		},
+		"Index": {
+			expr:   `$input.x[$count.index]`,
+			source: `input.x[count.index]`,
+			refs:   []string{"input.x", "count.index"},
+		},
+		"Module": {
+			expr:   `$module.cluster.kubeconfig[0]`,
+			source: `module.cluster.kubeconfig[0]`,
+			refs:   []string{"module.cluster.kubeconfig"},
+		},
+		"Comprehension": {
+			expr:   `$input.interface.all(i, i.metadata.name == $local.name[0])`,
+			source: `input.interface.all(i, i.metadata.name == local.name[0])`,
+			refs:   []string{"input.interface", "local.name"},
+		},
+		"NoName": {
+			expr:      `size($input)`,
+			expectErr: true,
+		},
+		"DynamicName": {
+			expr:      `$input[$each.key]`,
+			expectErr: true,
+		},
+		"ModuleNoOutput": {
+			expr:      `$module.cluster`,
+			expectErr: true,
+		},
+		"Invalid": {
+			expr:      `$input.x +`,
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x, err := ParseExpression(tc.expr)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.source, x.Source)
+			assert.ElementsMatch(t, tc.refs, x.References.UnsortedList())
+		})
+	}
+}
+
+func TestParseBareExpression(t *testing.T) {
+	cases := map[string]struct {
+		expr   string
+		source string
+		refs   []string
+	}{
+		"Select": {
+			expr:   `input.interface[0].spec`,
+			source: `input.interface[0].spec`,
+			refs:   []string{"input.interface"},
+		},
+		"Dash": {
+			expr:   `kubernetes_manifest.porch-repo[0].spec`,
+			source: `kubernetes_manifest["porch-repo"][0].spec`,
+			refs:   []string{"kubernetes_manifest.porch-repo"},
+		},
+		"ModuleDash": {
+			expr:   `module.edge-cluster.kube-config[0]`,
+			source: `module["edge-cluster"]["kube-config"][0]`,
+			refs:   []string{"module.edge-cluster.kube-config"},
+		},
+		"EachNoDash": {
+			expr:   `each.value-1`,
+			source: `each.value-1`,
+			refs:   []string{"each.value"},
+		},
+		"Literals": {
+			expr:   `int(input.a) + 1e3 + size("local.b-c")`,
+			source: `int(input.a) + 1e3 + size("local.b-c")`,
+			refs:   []string{"input.a"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x, err := parseExpression(tc.expr, true)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.source, x.Source)
+			assert.ElementsMatch(t, tc.refs, x.References.UnsortedList())
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package celenv
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/util/sets"
+)
+
+// legacyExpression matches the strings that were evaluated as an expression
+// before the interpolation syntax was introduced
+var legacyExpression = regexp.MustCompile("[$&+,:;=?@#|'<>-^*()%!]")
+
+// Value is a config value, which is a literal string, a whole value
+// expression or a template that interpolates expressions in a string.
+//
+// A string that is a single ${...} or that holds $ references, e.g.
+// $input.interface[0].spec, is a whole value expression, which evaluates to
+// any type. A string that holds ${...} and literal text is a template,
+// e.g. prefix-${input.name}-suffix, which evaluates to a string. $${ escapes
+// the interpolation in a template. Every other string is a literal.
+type Value struct {
+	// Parts are the literal strings and the expressions of the value
+	Parts []ValuePart
+	// Ambiguous is true for a literal string that was evaluated as an
+	// expression before the interpolation syntax was introduced
+	Ambiguous bool
+}
+
+// ValuePart is a literal string or an expression of a value
+type ValuePart struct {
+	Literal    string
+	Expression *Expression
+}
+
+// ParseValue parses the config value
+func ParseValue(s string) (*Value, error) {
+	if !strings.Contains(s, "${") {
+		if _, namespaces := rewriteReferences(s, false); namespaces.Len() > 0 {
+			x, err := ParseExpression(s)
+			if err != nil {
+				return nil, err
+			}
+			return &Value{Parts: []ValuePart{{Expression: x}}}, nil
+		}
+		return &Value{
+			Parts:     []ValuePart{{Literal: s}},
+			Ambiguous: isAmbiguous(s),
+		}, nil
+	}
+
+	v := &Value{Parts: []ValuePart{}}
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "$${"):
+			sb.WriteString("${")
+			i += 3
+		case strings.HasPrefix(s[i:], "${"):
+			end := scanInterpolation(s, i+2)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated interpolation in %s", s)
+			}
+			x, err := parseExpression(s[i+2:end], true)
+			if err != nil {
+				return nil, err
+			}
+			if sb.Len() > 0 {
+				v.Parts = append(v.Parts, ValuePart{Literal: sb.String()})
+				sb.Reset()
+			}
+			v.Parts = append(v.Parts, ValuePart{Expression: x})
+			i = end + 1
+		default:
+			sb.WriteByte(s[i])
+			i++
+		}
+	}
+	if sb.Len() > 0 || len(v.Parts) == 0 {
+		v.Parts = append(v.Parts, ValuePart{Literal: sb.String()})
+	}
+	return v, nil
+}
+
+// IsLiteral returns true if the value has no expressions
+func (r *Value) IsLiteral() bool {
+	for _, p := range r.Parts {
+		if p.Expression != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// IsExpression returns true if the value is a whole value expression
+func (r *Value) IsExpression() bool {
+	return len(r.Parts) == 1 && r.Parts[0].Expression != nil
+}
+
+// GetReferences returns the references of the expressions of the value
+func (r *Value) GetReferences() sets.Set[string] {
+	refs := sets.New[string]()
+	for _, p := range r.Parts {
+		if p.Expression != nil {
+			refs.Insert(p.Expression.References.UnsortedList()...)
+		}
+	}
+	return refs
+}
+
+// isAmbiguous returns true if the literal string was evaluated as an
+// expression before and is a valid expression without variables, e.g.
+// 'a' + 'b', while a plain value like my-app or a url is not
+func isAmbiguous(s string) bool {
+	if !legacyExpression.MatchString(s) {
+		return false
+	}
+	env, err := getParseEnv()
+	if err != nil {
+		return false
+	}
+	_, iss := env.Compile(s)
+	return iss.Err() == nil
+}
+
+// scanInterpolation returns the index of the } that closes the interpolation
+// of which the expression starts at i, the braces of the map literals and
+// the string literals of the expression are skipped. It returns -1 when the
+// interpolation is not closed.
+func scanInterpolation(s string, i int) int {
+	depth := 0
+	for i < len(s) {
+		switch s[i] {
+		case '"', '\'':
+			i = scanStringLiteral(s, i)
+			continue
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+		i++
+	}
+	return -1
+}
@@ -0,0 +1,100 @@
+package celenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseValue(t *testing.T) {
+	cases := map[string]struct {
+		value      string
+		literal    bool
+		expression bool
+		ambiguous  bool
+		refs       []string
+		expectErr  bool
+	}{
+		"Literal": {
+			value:   "my-app",
+			literal: true,
+			refs:    []string{},
+		},
+		"LiteralKind": {
+			value:   "Interface",
+			literal: true,
+			refs:    []string{},
+		},
+		"LiteralURL": {
+			value:   "https://example.com/a?b=c",
+			literal: true,
+			refs:    []string{},
+		},
+		"LiteralAmbiguous": {
+			value:     "'a' + 'b'",
+			literal:   true,
+			ambiguous: true,
+			refs:      []string{},
+		},
+		"LegacyExpression": {
+			value:      "$input.interface[0].spec.networkInstance.name != 'default'",
+			expression: true,
+			refs:       []string{"input.interface"},
+		},
+		"WholeValue": {
+			value:      "${input.interface[0].spec}",
+			expression: true,
+			refs:       []string{"input.interface"},
+		},
+		"WholeValueDash": {
+			value:      "${kubernetes_manifest.porch-repo[0].spec}",
+			expression: true,
+			refs:       []string{"kubernetes_manifest.porch-repo"},
+		},
+		"TemplateDash": {
+			value: "${module.edge-cluster.name[0]}-${kubernetes_manifest.porch-repo[0].metadata.name}",
+			refs:  []string{"module.edge-cluster.name", "kubernetes_manifest.porch-repo"},
+		},
+		"Template": {
+			value: "prefix-${input.name[0]}-${module.cluster.name[0]}-suffix",
+			refs:  []string{"input.name", "module.cluster.name"},
+		},
+		"TemplateMap": {
+			value: "a-${ {'x': local.y[0]}['x'] }",
+			refs:  []string{"local.y"},
+		},
+		"TemplateMacro": {
+			value: "${input.x.filter(i, i > 1).size()} items",
+			refs:  []string{"input.x"},
+		},
+		"Escape": {
+			value:   "$${input.name}",
+			literal: true,
+			refs:    []string{},
+		},
+		"Unterminated": {
+			value:     "prefix-${input.name",
+			expectErr: true,
+		},
+		"NoName": {
+			value:     "${input}",
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := ParseValue(tc.value)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.literal, v.IsLiteral())
+			assert.Equal(t, tc.expression, v.IsExpression())
+			assert.Equal(t, tc.ambiguous, v.Ambiguous)
+			assert.ElementsMatch(t, tc.refs, v.GetReferences().UnsortedList())
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	kformpkgmetav1alpha1 "github.com/henderiw-nephio/kform/tools/apis/kform/pkg/meta/v1alpha1"
+	"github.com/henderiw-nephio/kform/tools/pkg/pkgio"
 	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/address"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
@@ -20,7 +21,7 @@ import (
 
 type KformParser interface {
 	Parse(ctx context.Context, init bool)
-	InitProviderInventory(ctx context.Context) (cache.Cache[types.Provider], error)
+	InitProviderInventory(ctx context.Context, m types.ProviderManager) (cache.Cache[types.Provider], error)
 	InitProviderInstances(ctx context.Context) cache.Cache[plugin.Provider]
 	GetRootModule(ctx context.Context) (*types.Module, error)
 	GetModules(ctx context.Context) map[cache.NSN]*types.Module
@@ -59,6 +60,7 @@ func (r *kformparser) Parse(ctx context.Context, init bool) {
 	if r.recorder.Get().HasError() {
 		return
 	}
+	r.addBuiltinProviderConfigs(ctx)
 	r.validateProviderConfigs(ctx)
 	r.validateModuleCalls(ctx)
 	r.validateUnreferencedProviderConfigs(ctx)
@@ -73,6 +75,7 @@ func (r *kformparser) Parse(ctx context.Context, init bool) {
 
 	r.generateProviderDAG(ctx, r.getUnReferencedProviderConfigs(ctx))
 	r.generateDAG(ctx)
+	r.validateExpressions(ctx, nil)
 }
 
 func (r *kformparser) parseModule(ctx context.Context, nsn cache.NSN, path string) {
@@ -185,18 +188,47 @@ func (r *kformparser) GetProviderConfigs(ctx context.Context) map[cache.NSN]*typ
 	return rootProviderConfigs
 }
 
-func (r *kformparser) InitProviderInventory(ctx context.Context) (cache.Cache[types.Provider], error) {
+// InitProviderInventory initializes the inventory of the providers, the
+// provider manager owns the lifecycle of the provider processes. The
+// expressions are type-checked again with the resource schemas of the
+// providers.
+func (r *kformparser) InitProviderInventory(ctx context.Context, m types.ProviderManager) (cache.Cache[types.Provider], error) {
 	inventory := cache.New[types.Provider]()
 
+	lock, err := pkgio.ReadProviderLock(r.rootModulePath)
+	if err != nil {
+		return nil, err
+	}
 	for nsn, pkg := range r.providers.List() {
 		execPath := filepath.Join(r.rootModulePath, ".kform", "providers", pkg.ExecPath())
 
-		p := types.Provider{}
-		if err := p.Init(ctx, execPath, nsn); err != nil {
+		p, err := m.Init(ctx, nsn, execPath, lock.GetChecksum(nsn.Name, pkg.ExecPath()))
+		if err != nil {
 			return nil, err
 		}
 		inventory.Add(ctx, nsn, p)
 	}
+	// built-in providers are not installed, they run in-process
+	for nsn := range r.GetProviderConfigs(ctx) {
+		name := types.GetProviderName(nsn.Name)
+		if !types.IsBuiltinProvider(ctx, name) {
+			continue
+		}
+		if _, err := inventory.Get(cache.NSN{Name: name}); err == nil {
+			continue
+		}
+		p, err := m.Init(ctx, cache.NSN{Name: name}, "", "")
+		if err != nil {
+			return nil, err
+		}
+		inventory.Add(ctx, cache.NSN{Name: name}, p)
+	}
+	// the resource schemas of the providers are only known once the providers
+	// are initialized, the expressions are checked again with these schemas
+	r.validateExpressions(ctx, inventory)
+	if r.recorder.Get().HasError() {
+		return nil, r.recorder.Get().Error()
+	}
 
 	return inventory, nil
 }
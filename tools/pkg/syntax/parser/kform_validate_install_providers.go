@@ -9,6 +9,8 @@ import (
 	"github.com/henderiw-nephio/kform/tools/pkg/pkgio"
 	"github.com/henderiw-nephio/kform/tools/pkg/pkgio/oras"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/address"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cctx"
 )
 
 // validateAndOrInstallProviders looks at the provider requirements
@@ -16,6 +18,10 @@ import (
 // 2. get the releases per provider (based in source <hostname>/<namespace>)
 func (r *kformparser) validateAndOrInstallProviders(ctx context.Context, init bool) {
 	for nsn, reqs := range r.GetProviderRequirements(ctx) {
+		// built-in providers run in-process and are not installed
+		if types.IsBuiltinProvider(ctx, nsn.Name) {
+			continue
+		}
 		// convert provider requirements to a package
 		// the source was validated to be aligned before so we can just pick the first one.
 		pkg, err := address.GetPackage(nsn, reqs[0].Source)
@@ -77,4 +83,22 @@ func (r *kformparser) validateAndOrInstallProviders(ctx context.Context, init bo
 		r.recorder.Record(diag.DiagFromErr(err))
 		return
 	}
+	if init {
+		// record the checksums of the installed providers, they are verified
+		// before the providers are executed. With insecure plugins a
+		// rebuilt provider is locked again.
+		lock, err := pkgio.ReadProviderLock(r.rootModulePath)
+		if err != nil {
+			r.recorder.Record(diag.DiagFromErr(err))
+			return
+		}
+		if err := lock.Update(r.rootModulePath, r.providers, cctx.GetContextValue[bool](ctx, types.CtxKeyInsecurePlugins)); err != nil {
+			r.recorder.Record(diag.DiagFromErr(err))
+			return
+		}
+		if err := lock.Write(r.rootModulePath); err != nil {
+			r.recorder.Record(diag.DiagFromErr(err))
+			return
+		}
+	}
 }
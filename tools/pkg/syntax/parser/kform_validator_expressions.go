@@ -0,0 +1,276 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/tools/pkg/dag"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// validateExpressions compiles and type-checks the expressions of the blocks
+// in the DAG(s) of the modules. The inputs, locals and resources have the type
+// of the custom resource definition of their schema when a package holds it,
+// a local without such schema has the type of its value and a resource the
+// type of the resource schema of its provider when the provider inventory is
+// known. count.index is an int, each.key and each.value have the types of the
+// items of the forEach and the other references are dyn. The checked
+// expressions are cached in the vertex context for the renderer.
+func (r *kformparser) validateExpressions(ctx context.Context, providers cache.Cache[types.Provider]) {
+	schemas := map[schema.GroupVersionKind]*spec.Schema{}
+	for _, m := range r.modules.List() {
+		for gvk, s := range m.Schemas {
+			schemas[gvk] = s
+		}
+	}
+	for nsn, m := range r.modules.List() {
+		resolver := newTypeResolver(m.DAG, schemas, providers)
+		r.validateDAGExpressions(ctx, m.DAG, resolver)
+		if nsn == r.rootModuleName && m.ProviderDAG != nil {
+			r.validateDAGExpressions(ctx, m.ProviderDAG, resolver)
+		}
+	}
+}
+
+func (r *kformparser) validateDAGExpressions(ctx context.Context, d dag.DAG[*types.VertexContext], resolver *typeResolver) {
+	if d == nil {
+		return
+	}
+	for vertexName, vCtx := range d.GetVertices() {
+		if vertexName == dag.Root {
+			continue
+		}
+		values, err := getBlockValues(ctx, vCtx.BlockContext)
+		if err != nil {
+			r.recorder.Record(diag.DiagFromErrWithContext(vCtx.GetContext(), err).WithPosition(vCtx.GetPosition()))
+			continue
+		}
+		resolve := resolver.getVertexResolver(vCtx)
+		expressions := map[string]*celenv.CheckedExpression{}
+		for s, v := range values {
+			for _, p := range v.Parts {
+				if p.Expression == nil {
+					continue
+				}
+				x, err := celenv.Check(p.Expression, resolve)
+				if err != nil {
+					r.recorder.Record(diag.DiagErrorfWithContext(vCtx.GetContext(), "invalid expression %s, err: %s", s, err.Error()).WithPosition(vCtx.BlockContext.GetExpressionPosition(vCtx.FileName, s)))
+					continue
+				}
+				expressions[p.Expression.Source] = x
+			}
+		}
+		vCtx.Expressions = expressions
+	}
+}
+
+// getBlockValues returns the values with expressions of the block
+func getBlockValues(ctx context.Context, bctx types.KformBlockContext) (map[string]*celenv.Value, error) {
+	rn := types.NewRenderer()
+	if bctx.Attributes != nil {
+		b, err := json.Marshal(bctx.Attributes)
+		if err != nil {
+			return nil, fmt.Errorf("err marshaling kform block context, err: %s", err.Error())
+		}
+		attributes := map[string]any{}
+		if err := json.Unmarshal(b, &attributes); err != nil {
+			return nil, fmt.Errorf("err unmarshaling kform block context, err: %s", err.Error())
+		}
+		if err := rn.GatherDependencies(ctx, attributes); err != nil {
+			return nil, err
+		}
+	}
+	for _, x := range []any{bctx.Value, bctx.Default, bctx.InputParams, bctx.Config} {
+		if err := rn.GatherDependencies(ctx, x); err != nil {
+			return nil, err
+		}
+	}
+	return rn.GetValues(), nil
+}
+
+// typeResolver resolves the types of the references of the blocks in the
+// DAG, the value of a block is a list with an entry per instance of the block
+type typeResolver struct {
+	vertices  map[string]*types.VertexContext
+	schemas   map[schema.GroupVersionKind]*spec.Schema
+	providers cache.Cache[types.Provider]
+	// locals are the resolved types of the locals, the locals being resolved
+	// are nil, such that a local that references itself is dyn
+	locals map[string]*apiservercel.DeclType
+}
+
+func newTypeResolver(d dag.DAG[*types.VertexContext], schemas map[schema.GroupVersionKind]*spec.Schema, providers cache.Cache[types.Provider]) *typeResolver {
+	r := &typeResolver{
+		vertices:  map[string]*types.VertexContext{},
+		schemas:   schemas,
+		providers: providers,
+		locals:    map[string]*apiservercel.DeclType{},
+	}
+	if d != nil {
+		for vertexName, vCtx := range d.GetVertices() {
+			if vertexName != dag.Root {
+				r.vertices[vertexName] = vCtx
+			}
+		}
+	}
+	return r
+}
+
+// resolve returns the type of the reference outside of a block
+func (r *typeResolver) resolve(ref string) *apiservercel.DeclType {
+	if ref == types.LoopKeyCountIndex {
+		return apiservercel.IntType
+	}
+	vCtx, ok := r.vertices[ref]
+	if !ok {
+		return nil
+	}
+	var t *apiservercel.DeclType
+	switch vCtx.BlockType {
+	case types.BlockTypeInput:
+		t = r.getSchemaType(vCtx.GVK)
+	case types.BlockTypeResource, types.BlockTypeData:
+		t = r.getSchemaType(vCtx.GVK)
+		if t == nil {
+			t = r.getProviderSchemaType(vCtx)
+		}
+	case types.BlockTypeLocal:
+		t = r.getLocalType(vCtx)
+	}
+	if t == nil {
+		return nil
+	}
+	return celenv.NewListType(t)
+}
+
+// getVertexResolver returns the resolver of the references in the block,
+// the loop variables each.key and each.value have the types of the items
+// of the forEach of the block
+func (r *typeResolver) getVertexResolver(vCtx *types.VertexContext) celenv.TypeResolver {
+	key, value := r.getForEachTypes(vCtx)
+	return func(ref string) *apiservercel.DeclType {
+		switch ref {
+		case types.LoopKeyForEachKey:
+			return key
+		case types.LoopKeyForEachVal:
+			return value
+		}
+		return r.resolve(ref)
+	}
+}
+
+// getSchemaType returns the type of the custom resource definition of the
+// gvk, nil when no package holds the custom resource definition
+func (r *typeResolver) getSchemaType(gvk schema.GroupVersionKind) *apiservercel.DeclType {
+	if gvk.Empty() {
+		return nil
+	}
+	s, ok := r.schemas[gvk]
+	if !ok {
+		return nil
+	}
+	return celenv.NewSchemaType(getSchemaTypeName(gvk), s)
+}
+
+// getProviderSchemaType returns the type of the resource schema the provider
+// of the resource reports, nil before the provider inventory is initialized
+func (r *typeResolver) getProviderSchemaType(vCtx *types.VertexContext) *apiservercel.DeclType {
+	if r.providers == nil || vCtx.Provider == "" {
+		return nil
+	}
+	p, err := r.providers.Get(cache.NSN{Name: types.GetProviderName(vCtx.Provider)})
+	if err != nil {
+		return nil
+	}
+	// the block name is <resourceType>.<name> or data.<resourceType>.<name>
+	idx := strings.LastIndex(vCtx.BlockName, ".")
+	if idx < 0 {
+		return nil
+	}
+	resourceType := strings.TrimPrefix(vCtx.BlockName[:idx], "data.")
+	s, ok := p.Schemas[resourceType]
+	if !ok || s == nil {
+		return nil
+	}
+	return celenv.NewSchemaType(fmt.Sprintf("kform.provider.%s.%s", p.NSN.Name, resourceType), s)
+}
+
+// getLocalType returns the type of the custom resource definition of the
+// schema of the local, or the type of the value of the local when the value
+// is a single expression
+func (r *typeResolver) getLocalType(vCtx *types.VertexContext) *apiservercel.DeclType {
+	if t := r.getSchemaType(vCtx.GVK); t != nil {
+		return t
+	}
+	if t, ok := r.locals[vCtx.BlockName]; ok {
+		return t
+	}
+	r.locals[vCtx.BlockName] = nil
+	s, ok := vCtx.BlockContext.Value.(string)
+	if !ok {
+		return nil
+	}
+	x := r.checkValue(s, r.getVertexResolver(vCtx))
+	if x == nil {
+		return nil
+	}
+	r.locals[vCtx.BlockName] = x.ResultType()
+	return r.locals[vCtx.BlockName]
+}
+
+// getForEachTypes returns the types of each.key and each.value of the block,
+// a list has int keys, a map the keys of the map and any other value is a
+// single item with key 0
+func (r *typeResolver) getForEachTypes(vCtx *types.VertexContext) (*apiservercel.DeclType, *apiservercel.DeclType) {
+	attrs := vCtx.BlockContext.Attributes
+	if attrs == nil || attrs.ForEach == nil {
+		return nil, nil
+	}
+	x := r.checkValue(*attrs.ForEach, r.resolve)
+	if x == nil {
+		return nil, nil
+	}
+	t := x.ResultType()
+	switch {
+	case t == nil:
+		return nil, nil
+	case t.IsList():
+		return apiservercel.IntType, t.ElemType
+	case t.IsMap():
+		return t.KeyType, t.ElemType
+	default:
+		return apiservercel.IntType, t
+	}
+}
+
+// checkValue returns the checked expression of a value that is a single
+// expression, nil for a literal, a template or an invalid expression, which
+// is reported when the expressions of the block are validated
+func (r *typeResolver) checkValue(s string, resolve celenv.TypeResolver) *celenv.CheckedExpression {
+	v, err := celenv.ParseValue(s)
+	if err != nil || !v.IsExpression() {
+		return nil
+	}
+	x, err := celenv.Check(v.Parts[0].Expression, resolve)
+	if err != nil {
+		return nil
+	}
+	return x
+}
+
+// getSchemaTypeName returns the CEL type name of the schema, e.g.
+// kform.schema.req.nephio.org.v1alpha1.Interface
+func getSchemaTypeName(gvk schema.GroupVersionKind) string {
+	if gvk.Group == "" {
+		return fmt.Sprintf("kform.schema.%s.%s", gvk.Version, gvk.Kind)
+	}
+	return fmt.Sprintf("kform.schema.%s.%s.%s", gvk.Group, gvk.Version, gvk.Kind)
+}
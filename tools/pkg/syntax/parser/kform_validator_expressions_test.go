@@ -0,0 +1,234 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/henderiw-nephio/kform/tools/pkg/dag"
+	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+var interfaceCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: interfaces.req.nephio.org
+spec:
+  group: req.nephio.org
+  names:
+    kind: Interface
+    plural: interfaces
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              ipFamilyPolicy:
+                type: string
+              vlanID:
+                type: integer
+`
+
+var kformExpressions = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: main
+data:
+  spec:
+  - input:
+      interface:
+        attributes:
+          schema:
+            apiVersion: req.nephio.org/v1alpha1
+            kind: Interface
+  - local:
+      iface:
+        attributes:
+          schema:
+            apiVersion: v1
+            kind: ConfigMap
+        value: $input.interface[0].spec
+  - local:
+      n3-interface:
+        attributes:
+          schema:
+            apiVersion: v1
+            kind: ConfigMap
+        value: $input.interface
+  - local:
+      policy:
+        attributes:
+          schema:
+            apiVersion: v1
+            kind: ConfigMap
+          forEach: $input.interface
+        value:
+          data:
+            policy: %s
+`
+
+func TestValidateExpressions(t *testing.T) {
+	cases := map[string]struct {
+		expr      string
+		expectErr bool
+	}{
+		"Typed": {
+			expr: "$input.interface[0].spec.ipFamilyPolicy",
+		},
+		"Interpolation": {
+			expr: "vlan-${string(input.interface[0].spec.vlanID)}",
+		},
+		"UndefinedField": {
+			expr:      "$input.interface[0].spec.ipFamly",
+			expectErr: true,
+		},
+		"NoSuchOverload": {
+			expr:      "${input.interface[0].spec.vlanID + 'a'}",
+			expectErr: true,
+		},
+		"TypedLocal": {
+			expr: "${local.iface[0].vlanID + 1}",
+		},
+		"TypedLocalUndefinedField": {
+			expr:      "$local.iface[0].vlanId",
+			expectErr: true,
+		},
+		"TypedDash": {
+			expr: "${local.n3-interface[0][0].spec.vlanID + 1}",
+		},
+		"TypedDashUndefinedField": {
+			expr:      "${local.n3-interface[0][0].spec.vlanId}",
+			expectErr: true,
+		},
+		"TypedEachValue": {
+			expr: "${each.value.spec.vlanID + 1}",
+		},
+		"TypedEachValueUndefinedField": {
+			expr:      "${each.value.spec.vlanId}",
+			expectErr: true,
+		},
+		"TypedEachKey": {
+			expr: "${each.key + 1}",
+		},
+		"TypedEachKeyNoSuchOverload": {
+			expr:      "${each.key + 'a'}",
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := recorder.New[diag.Diagnostic]()
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, types.CtxKeyRecorder, recorder)
+			ctx = context.WithValue(ctx, types.CtxKeyModuleKind, types.ModuleKindRoot)
+
+			path := "./example"
+			p := moduleparser{
+				path: path,
+				fsys: buildFs(path, map[string]string{
+					"KformFile.yaml": kformfile,
+					"crd.yaml":       interfaceCRD,
+					"main.yaml":      fmt.Sprintf(kformExpressions, tc.expr),
+				}),
+				recorder: recorder,
+			}
+			m := p.Parse(ctx)
+			if recorder.Get().HasError() {
+				t.Fatalf("want no parse error, got: %v", recorder.Get().Error())
+			}
+			m.GenerateDAG(ctx, false, []string{})
+			kfp := &kformparser{
+				recorder: recorder,
+				modules:  cache.New[*types.Module](),
+			}
+			kfp.modules.Add(ctx, m.NSN, m)
+			kfp.validateExpressions(ctx, nil)
+			if tc.expectErr != recorder.Get().HasError() {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, recorder.Get().Error())
+			}
+			if tc.expectErr {
+				return
+			}
+			vCtx, err := m.DAG.GetVertex("local.policy")
+			if err != nil {
+				t.Fatalf("want vertex local.policy, got err: %s", err.Error())
+			}
+			// the expressions of the forEach and of the value
+			if len(vCtx.Expressions) != 2 {
+				t.Errorf("want 2 checked expressions, got: %d", len(vCtx.Expressions))
+			}
+		})
+	}
+}
+
+func TestTypeResolverProviderSchema(t *testing.T) {
+	ctx := context.Background()
+	d := dag.New[*types.VertexContext]()
+	d.AddVertex(ctx, "kubernetes_manifest.repo", &types.VertexContext{
+		BlockType: types.BlockTypeResource,
+		BlockName: "kubernetes_manifest.repo",
+		Provider:  "kubernetes.edge01",
+	})
+	providers := cache.New[types.Provider]()
+	providers.Add(ctx, cache.NSN{Name: "kubernetes"}, types.Provider{
+		NSN: cache.NSN{Name: "kubernetes"},
+		Schemas: map[string]*spec.Schema{
+			"kubernetes_manifest": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{
+						Type:       []string{"object"},
+						Properties: map[string]spec.Schema{"url": *spec.StringProperty()},
+					}},
+				},
+			}},
+		},
+	})
+
+	cases := map[string]struct {
+		providers cache.Cache[types.Provider]
+		expr      string
+		expectErr bool
+	}{
+		"NoInventory": {
+			expr: "$kubernetes_manifest.repo[0].spec.urll",
+		},
+		"Typed": {
+			providers: providers,
+			expr:      "$kubernetes_manifest.repo[0].spec.url",
+		},
+		"UndefinedField": {
+			providers: providers,
+			expr:      "$kubernetes_manifest.repo[0].spec.urll",
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x, err := celenv.ParseExpression(tc.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resolver := newTypeResolver(d, map[schema.GroupVersionKind]*spec.Schema{}, tc.providers)
+			_, err = celenv.Check(x, resolver.resolve)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("want error %t, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 )
 
 func (r *kformparser) validateModuleCalls(ctx context.Context) {
+	rootProviderConfigs := r.getRootProviderConfigs(ctx)
+	providerConfigMaps := r.getProviderConfigMaps(ctx)
 	for nsn, m := range r.modules.List() {
 		// only process modules that call other modules
 		if len(m.ModuleCalls.List()) > 0 {
@@ -34,9 +36,10 @@ func (r *kformparser) validateModuleCalls(ctx context.Context) {
 						//fmt.Printf("    remote module %s input %s not found\n", mcNSN.Name, inputName)
 					}
 				}
-				// validate the sourceproviders in the module call
+				// validate the sourceproviders in the module call, the source
+				// provider resolves to a provider config of the root module
 				for targetProvider, sourceProvider := range mc.GetProviders() {
-					if _, err := m.ProviderConfigs.Get(cache.NSN{Name: sourceProvider}); err != nil {
+					if _, ok := rootProviderConfigs[cache.NSN{Name: providerConfigMaps[nsn].Get(sourceProvider)}]; !ok {
 						r.recorder.Record(diag.DiagErrorf("provider module call module from %s to %s source provider %s not found", nsn.Name, rmNSN.Name, sourceProvider))
 					}
 					if !rm.GetProvidersFromResources(ctx).Has(cache.NSN{Name: targetProvider}) {
@@ -71,19 +74,34 @@ func (r *kformparser) validateModuleCalls(ctx context.Context) {
 	}
 }
 
-// validateProviderConfigs validates if for each provider in a child resource
-// there is a provider config
-func (r *kformparser) validateProviderConfigs(ctx context.Context) {
+// addBuiltinProviderConfigs adds the implicit provider config of the built-in
+// providers the resources of the child modules use to the root module
+func (r *kformparser) addBuiltinProviderConfigs(ctx context.Context) {
 	rootModule, err := r.modules.Get(r.rootModuleName)
 	if err != nil {
-		r.recorder.Record(diag.DiagErrorf("cannot validate provider configs, root module %s not found", r.rootModuleName.Name))
+		r.recorder.Record(diag.DiagErrorf("cannot add built-in provider configs, root module %s not found", r.rootModuleName.Name))
+		return
 	}
-	rootProviderConfigs := rootModule.ProviderConfigs.List()
+	providerConfigMaps := r.getProviderConfigMaps(ctx)
+	for cmNSN, m := range r.modules.List() {
+		if m.Kind != types.ModuleKindRoot {
+			for _, provider := range m.GetProvidersFromResources(ctx).UnsortedList() {
+				rootModule.AddBuiltinProviderConfig(ctx, providerConfigMaps[cmNSN].Get(provider.Name))
+			}
+		}
+	}
+}
+
+// validateProviderConfigs validates if for each provider in a child resource
+// there is a provider config
+func (r *kformparser) validateProviderConfigs(ctx context.Context) {
+	rootProviderConfigs := r.getRootProviderConfigs(ctx)
+	providerConfigMaps := r.getProviderConfigMaps(ctx)
 
 	for cmNSN, m := range r.modules.List() {
 		if m.Kind != types.ModuleKindRoot {
 			for _, provider := range m.GetProvidersFromResources(ctx).UnsortedList() {
-				if _, ok := rootProviderConfigs[provider]; !ok {
+				if _, ok := rootProviderConfigs[cache.NSN{Name: providerConfigMaps[cmNSN].Get(provider.Name)}]; !ok {
 					r.recorder.Record(diag.DiagErrorf("no provider config in root module for child module %s, provider: %s", cmNSN.Name, provider.Name))
 				}
 			}
@@ -91,6 +109,42 @@ func (r *kformparser) validateProviderConfigs(ctx context.Context) {
 	}
 }
 
+func (r *kformparser) getRootProviderConfigs(ctx context.Context) map[cache.NSN]*types.ProviderConfig {
+	rootModule, err := r.modules.Get(r.rootModuleName)
+	if err != nil {
+		r.recorder.Record(diag.DiagErrorf("cannot validate provider configs, root module %s not found", r.rootModuleName.Name))
+		return map[cache.NSN]*types.ProviderConfig{}
+	}
+	return rootModule.ProviderConfigs.List()
+}
+
+// getProviderConfigMaps returns per module the map of the provider configs
+// referenced in the module to the provider configs of the root module. The
+// maps are derived from the providers of the module calls, starting from the
+// root module.
+func (r *kformparser) getProviderConfigMaps(ctx context.Context) map[cache.NSN]types.ProviderConfigMap {
+	modules := r.modules.List()
+	providerConfigMaps := map[cache.NSN]types.ProviderConfigMap{}
+
+	var walk func(nsn cache.NSN, providerConfigMap types.ProviderConfigMap)
+	walk = func(nsn cache.NSN, providerConfigMap types.ProviderConfigMap) {
+		m, ok := modules[nsn]
+		if !ok {
+			return
+		}
+		if _, ok := providerConfigMaps[nsn]; ok {
+			// already visited
+			return
+		}
+		providerConfigMaps[nsn] = providerConfigMap
+		for mcNSN, mc := range m.ModuleCalls.List() {
+			walk(mcNSN, providerConfigMap.ModuleCall(mc.GetProviders()))
+		}
+	}
+	walk(r.rootModuleName, types.ProviderConfigMap{})
+	return providerConfigMaps
+}
+
 // validateProviderRequirements validates if the source strings of all the provider
 // requirements are consistent
 // first we walk through all the provider requirements referenced by all modules
@@ -126,10 +180,11 @@ func (r *kformparser) getUnReferencedProviderConfigs(ctx context.Context) []stri
 		r.recorder.Record(diag.DiagErrorf("cannot validate provider config references, root module %s not found", r.rootModuleName.Name))
 	}
 	rootProviderConfigs := rootModule.ProviderConfigs.List()
+	providerConfigMaps := r.getProviderConfigMaps(ctx)
 
-	for _, m := range r.modules.List() {
+	for nsn, m := range r.modules.List() {
 		for _, provider := range m.GetProvidersFromResources(ctx).UnsortedList() {
-			delete(rootProviderConfigs, provider)
+			delete(rootProviderConfigs, cache.NSN{Name: providerConfigMaps[nsn].Get(provider.Name)})
 			if len(rootProviderConfigs) == 0 {
 				return unreferenceProviderConfigs
 			}
@@ -155,7 +210,8 @@ func (r *kformparser) validateUnreferencedProviderRequirements(ctx context.Conte
 	for cmNSN, m := range r.modules.List() {
 		rootProviderReqs := m.GetProviderRequirements(ctx)
 		for nsn := range rootModule.ProviderConfigs.List() {
-			delete(rootProviderReqs, nsn)
+			// aliased provider configs share the provider requirement
+			delete(rootProviderReqs, cache.NSN{Name: types.GetProviderName(nsn.Name)})
 			if len(rootProviderReqs) == 0 {
 				continue
 			}
@@ -185,15 +241,17 @@ func (r *kformparser) getProviderRequirements(ctx context.Context) map[cache.NSN
 	}
 
 	// we initialize all provider if they have aa req or not, if not the latest provider will be downloaded
+	// the requirements are keyed by provider, aliased provider configs
+	// share the provider
 	allprovreqs := map[cache.NSN][]kformpkgmetav1alpha1.Provider{}
 	for nsn := range rootProviderConfigs {
-		allprovreqs[nsn] = []kformpkgmetav1alpha1.Provider{}
+		allprovreqs[cache.NSN{Name: types.GetProviderName(nsn.Name)}] = []kformpkgmetav1alpha1.Provider{}
 	}
 
 	for _, m := range r.modules.List() {
 		provReqs := m.ProviderRequirements.List()
 		for provNSN, provReq := range provReqs {
-			if _, ok := rootProviderConfigs[provNSN]; ok {
+			if _, ok := allprovreqs[provNSN]; ok {
 				// since we initialized allprovreqs we dont need to check if the list is initialized
 				allprovreqs[provNSN] = append(allprovreqs[provNSN], provReq)
 			}
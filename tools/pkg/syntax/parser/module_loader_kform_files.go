@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/GoogleContainerTools/kpt-functions-sdk/go/fn"
+	kfschema "github.com/henderiw-nephio/kform/kform-sdk-go/pkg/schema"
 	kformpkgmetav1alpha1 "github.com/henderiw-nephio/kform/tools/apis/kform/pkg/meta/v1alpha1"
 	"github.com/henderiw-nephio/kform/tools/pkg/pkgio"
 	"github.com/henderiw-nephio/kform/tools/pkg/pkgio/data"
@@ -16,22 +17,28 @@ import (
 	koe "github.com/nephio-project/nephio/krm-functions/lib/kubeobject"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 // TODO need to enhance this to differentiate running incluster versus out of cluster
-func (r *moduleparser) getKforms(ctx context.Context) (*kformpkgmetav1alpha1.KformFile, map[string]*types.Kform, error) {
+// getKforms returns the kform file, the kforms and the schemas of the custom
+// resource definitions of the package
+func (r *moduleparser) getKforms(ctx context.Context) (*kformpkgmetav1alpha1.KformFile, map[string]*types.Kform, map[schema.GroupVersionKind]*spec.Schema, error) {
 	log := log.FromContext(ctx)
 	var kfile *kformpkgmetav1alpha1.KformFile
 	kforms := map[string]*types.Kform{}
+	schemas := map[schema.GroupVersionKind]*spec.Schema{}
 	// read the directory
 	/*
 		if err := fsys.ValidateDirPath(r.path); err != nil {
-			return kfile, kforms, fmt.Errorf("cannot parse module dir path invalid, err: %s", err.Error())
+			return kfile, kforms, schemas, fmt.Errorf("cannot parse module dir path invalid, err: %s", err.Error())
 		}
 	*/
 	// check the path from the current directory
 	if _, err := r.fsys.Stat("."); err != nil {
-		return kfile, kforms, fmt.Errorf("cannot parse module dir does not exist, err: %s", err.Error())
+		return kfile, kforms, schemas, fmt.Errorf("cannot parse module dir does not exist, err: %s", err.Error())
 	}
 	ignoreRules := ignore.Empty(pkgio.IgnoreFileMatch[0])
 	f, err := r.fsys.Open(pkgio.IgnoreFileMatch[0])
@@ -49,7 +56,7 @@ func (r *moduleparser) getKforms(ctx context.Context) (*kformpkgmetav1alpha1.Kfo
 	}
 	d, err := reader.Read(ctx, data.New())
 	if err != nil {
-		return kfile, kforms, err
+		return kfile, kforms, schemas, err
 	}
 	// extracts kforms from the configmaps
 	for path, data := range d.List() {
@@ -59,30 +66,81 @@ func (r *moduleparser) getKforms(ctx context.Context) (*kformpkgmetav1alpha1.Kfo
 			continue
 		}
 		if ko.GetKind() == reflect.TypeOf(corev1.ConfigMap{}).Name() {
-			kform, _, err := ko.NestedSubObject("data")
-			if err != nil {
-				return kfile, kforms, fmt.Errorf("data not present in configmap file: %s", path)
+			// decode the kform from the yaml node of the original file such that
+			// the positions of the yaml nodes refer to the kform file
+			n := &yaml.Node{}
+			if err := yaml.Unmarshal([]byte(data), n); err != nil {
+				return kfile, kforms, schemas, fmt.Errorf("unmarshal error kform in file: %s", path)
+			}
+			kformNode := getDataNode(n)
+			if kformNode == nil {
+				return kfile, kforms, schemas, fmt.Errorf("data not present in configmap file: %s", path)
 			}
 			kf := types.Kform{}
-			if err := yaml.Unmarshal([]byte(kform.String()), &kf); err != nil {
-				return kfile, kforms, fmt.Errorf("unmarshal error kform in file: %s", path)
+			if err := kformNode.Decode(&kf); err != nil {
+				return kfile, kforms, schemas, fmt.Errorf("unmarshal error kform in file: %s", path)
 			}
 			kforms[path] = &kf
 			log.Debug("kform", "path", path, "kform", kf.Blocks)
 		}
+		if ko.GetKind() == reflect.TypeOf(apiextv1.CustomResourceDefinition{}).Name() {
+			if err := addCRDSchemas(ko, schemas); err != nil {
+				return kfile, kforms, schemas, fmt.Errorf("invalid custom resource definition in file: %s, err: %s", path, err.Error())
+			}
+		}
 		if ko.GetKind() == reflect.TypeOf(kformpkgmetav1alpha1.KformFile{}).Name() {
 			if kfile != nil {
-				return kfile, kforms, fmt.Errorf("cannot have 2 kform file resource in the package")
+				return kfile, kforms, schemas, fmt.Errorf("cannot have 2 kform file resource in the package")
 			}
 			kfKOE, err := koe.NewFromKubeObject[kformpkgmetav1alpha1.KformFile](ko)
 			if err != nil {
-				return kfile, kforms, err
+				return kfile, kforms, schemas, err
 			}
 			kfile, err = kfKOE.GetGoStruct()
 			if err != nil {
-				return kfile, kforms, err
+				return kfile, kforms, schemas, err
 			}
 		}
 	}
-	return kfile, kforms, nil
+	return kfile, kforms, schemas, nil
+}
+
+// addCRDSchemas adds the openapi schemas of the versions of the custom resource
+// definition, the schemas are used to type-check the expressions
+func addCRDSchemas(ko *fn.KubeObject, schemas map[schema.GroupVersionKind]*spec.Schema) error {
+	crdKOE, err := koe.NewFromKubeObject[apiextv1.CustomResourceDefinition](ko)
+	if err != nil {
+		return err
+	}
+	crd, err := crdKOE.GetGoStruct()
+	if err != nil {
+		return err
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		s := &spec.Schema{}
+		if err := kfschema.ConvertJSONSchemaPropsWithPostProcess(v.Schema.OpenAPIV3Schema, s, kfschema.StripUnsupportedFormatsPostProcess); err != nil {
+			return err
+		}
+		schemas[schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind}] = s
+	}
+	return nil
+}
+
+// getDataNode returns the yaml node of the data field of the configmap
+func getDataNode(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == "data" {
+			return n.Content[i+1]
+		}
+	}
+	return nil
 }
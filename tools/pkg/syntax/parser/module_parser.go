@@ -42,7 +42,7 @@ type moduleparser struct {
 
 // Parse
 func (r *moduleparser) Parse(ctx context.Context) *types.Module {
-	kf, kforms, err := r.getKforms(ctx)
+	kf, kforms, schemas, err := r.getKforms(ctx)
 	if err != nil {
 		r.recorder.Record(diag.DiagErrorf("cannot get kfile and/or kforms for this path: %s, err: %s", r.path, err.Error()))
 		return nil
@@ -55,6 +55,7 @@ func (r *moduleparser) Parse(ctx context.Context) *types.Module {
 		cctx.GetContextValue[cache.NSN](ctx, types.CtxKeyModuleName),
 		cctx.GetContextValue[types.ModuleKind](ctx, types.CtxKeyModuleKind),
 		r.recorder)
+	m.Schemas = schemas
 	// add the required providers in the module
 	for providerRawName, providerReq := range kf.Spec.ProviderRequirements {
 		if err := providerReq.Validate(); err != nil {
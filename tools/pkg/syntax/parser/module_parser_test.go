@@ -8,10 +8,12 @@ import (
 	"testing"
 	"testing/fstest"
 
+	"github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/tools/pkg/fsys"
 	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
 	"github.com/henderiw-nephio/kform/tools/pkg/syntax/types"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
 	"github.com/henderiw/logger/log"
 )
 
@@ -156,3 +158,177 @@ func TestGetKforms(t *testing.T) {
 		})
 	}
 }
+
+var kformProviderAlias = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: main
+data:
+  spec:
+  - provider:
+      kubernetes:
+        attributes:
+          schema:
+            apiVersion: kubernetes.provider.kform.io
+            kind: ProviderConfig
+        config:
+          kubeConfig: cluster01
+  - provider:
+      kubernetes:
+        attributes:
+          alias: edge01
+          schema:
+            apiVersion: kubernetes.provider.kform.io
+            kind: ProviderConfig
+        config:
+          kubeConfig: edge01
+  - resource:
+      kubernetes_manifest:
+        network:
+          attributes:
+            provider: %s
+            schema:
+              apiVersion: infra.nephio.org/v1alpha1
+              kind: Network
+          config:
+            metadata:
+              name: network
+              namespace: default
+`
+
+func TestProviderAlias(t *testing.T) {
+	cases := map[string]struct {
+		provider        string
+		expectErr       bool
+		providerConfigs []string
+	}{
+		"Alias": {
+			provider:        "kubernetes.edge01",
+			providerConfigs: []string{"kubernetes", "kubernetes.edge01"},
+		},
+		"UnknownAlias": {
+			provider:  "kubernetes.edge02",
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := recorder.New[diag.Diagnostic]()
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, types.CtxKeyRecorder, recorder)
+			ctx = context.WithValue(ctx, types.CtxKeyModuleKind, types.ModuleKindRoot)
+
+			path := "./example"
+			p := moduleparser{
+				path: path,
+				fsys: buildFs(path, map[string]string{
+					"KformFile.yaml": kformfile,
+					"main.yaml":      fmt.Sprintf(kformProviderAlias, tc.provider),
+				}),
+				recorder: recorder,
+			}
+			m := p.Parse(ctx)
+			if tc.expectErr != recorder.Get().HasError() {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, recorder.Get().Error())
+			}
+			if tc.expectErr {
+				return
+			}
+			for _, name := range tc.providerConfigs {
+				providerConfig, err := m.ProviderConfigs.Get(cache.NSN{Name: name})
+				if err != nil {
+					t.Errorf("want provider config %s, got err: %s", name, err.Error())
+					continue
+				}
+				if providerConfig.GetName() != "kubernetes" {
+					t.Errorf("want provider kubernetes for provider config %s, got: %s", name, providerConfig.GetName())
+				}
+			}
+		})
+	}
+}
+
+var kformBuiltinProvider = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: main
+data:
+  spec:
+  - resource:
+      %s:
+        token:
+          attributes:
+            schema:
+              apiVersion: kform.provider.kform.io/v1alpha1
+              kind: RandomString
+          config:
+            apiVersion: kform.provider.kform.io/v1alpha1
+            kind: RandomString
+            metadata:
+              name: token
+            spec:
+              length: 32
+`
+
+// testBuiltinProviders is a registry with the kform provider built in
+type testBuiltinProviders struct{}
+
+func (testBuiltinProviders) IsBuiltin(name string) bool { return name == "kform" }
+
+func (testBuiltinProviders) GetAPIVersion(name string) string {
+	return "kform.provider.kform.io/v1alpha1"
+}
+
+func (testBuiltinProviders) NewProvider(name string) (plugin.Provider, error) {
+	return nil, fmt.Errorf("provider %s cannot run in the parser test", name)
+}
+
+func TestBuiltinProvider(t *testing.T) {
+	cases := map[string]struct {
+		resourceType string
+		expectErr    bool
+	}{
+		"Builtin": {
+			resourceType: "kform_random_string",
+		},
+		"NoProviderConfig": {
+			resourceType: "kubernetes_manifest",
+			expectErr:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := recorder.New[diag.Diagnostic]()
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, types.CtxKeyRecorder, recorder)
+			ctx = context.WithValue(ctx, types.CtxKeyModuleKind, types.ModuleKindRoot)
+			ctx = context.WithValue(ctx, types.CtxKeyBuiltinProviders, testBuiltinProviders{})
+
+			path := "./example"
+			p := moduleparser{
+				path: path,
+				fsys: buildFs(path, map[string]string{
+					"KformFile.yaml": kformfile,
+					"main.yaml":      fmt.Sprintf(kformBuiltinProvider, tc.resourceType),
+				}),
+				recorder: recorder,
+			}
+			m := p.Parse(ctx)
+			if tc.expectErr != recorder.Get().HasError() {
+				t.Fatalf("want error %t, got: %v", tc.expectErr, recorder.Get().Error())
+			}
+			if tc.expectErr {
+				return
+			}
+			providerConfig, err := m.ProviderConfigs.Get(cache.NSN{Name: "kform"})
+			if err != nil {
+				t.Fatalf("want implicit provider config kform, got err: %s", err.Error())
+			}
+			if schema := providerConfig.GetAttributes().Schema; schema == nil || schema.Kind != "ProviderConfig" {
+				t.Errorf("want provider config schema kind ProviderConfig, got: %v", schema)
+			}
+		})
+	}
+}
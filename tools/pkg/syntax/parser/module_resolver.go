@@ -13,6 +13,10 @@ func (r *moduleparser) resolve(ctx context.Context, m *types.Module) {
 	// for each resource we should have a required provider in root/child modules
 	//m.ResolveResource2ProviderRequirements(ctx)
 	if m.Kind == types.ModuleKindRoot {
+		// built-in providers of the resources get an implicit provider config
+		for _, provider := range m.GetProvidersFromResources(ctx).UnsortedList() {
+			m.AddBuiltinProviderConfig(ctx, provider.Name)
+		}
 		// validate that for each provider in a resource there is a related provider config
 		m.ResolveResource2ProviderConfig(ctx)
 		// validate that for each provider confif we have defined the provider requirements
@@ -1,6 +1,9 @@
 package types
 
-import "github.com/henderiw-nephio/kform/tools/pkg/dag"
+import (
+	"github.com/henderiw-nephio/kform/tools/pkg/dag"
+	"gopkg.in/yaml.v3"
+)
 
 type Kform struct {
 	Blocks []KformBlock `json:"spec" yaml:"spec"`
@@ -19,6 +22,9 @@ type KformBlockContext struct {
 	InputParams map[string]any `json:"inputParams,omitempty" yaml:"inputParams,omitempty"`
 	Config      any            `json:"config,omitempty" yaml:"config,omitempty"`
 	Value       any            `json:"value,omitempty" yaml:"value,omitempty"`
+	// Node is the yaml node of the block, used to map blocks, attributes
+	// and expressions back to their location in the kform file
+	Node *yaml.Node `json:"-" yaml:"-"`
 }
 
 type KformBlockAttributes struct {
@@ -42,6 +48,24 @@ type KformBlockAttributes struct {
 	Hostname      *string           `json:"hostname,omitempty" yaml:"hostname,omitempty"`
 	Organization  *string           `json:"organization,omitempty" yaml:"organization,omitempty"`
 	Workspaces    map[string]string `json:"workspaces,omitempty" yaml:"workspaces,omitempty"`
+	// Selector and Namespace select the objects of a list block
+	Selector  *KformBlockSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Namespace *string             `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// KformBlockSelector selects objects by their labels, the results of
+// matchLabels and matchExpressions are ANDed. The values can be expressions.
+type KformBlockSelector struct {
+	MatchLabels      map[string]string               `json:"matchLabels,omitempty" yaml:"matchLabels,omitempty"`
+	MatchExpressions []KformBlockSelectorRequirement `json:"matchExpressions,omitempty" yaml:"matchExpressions,omitempty"`
+}
+
+// KformBlockSelectorRequirement relates the key of a label to a set of
+// values, the operator is one of In, NotIn, Exists and DoesNotExist.
+type KformBlockSelectorRequirement struct {
+	Key      string   `json:"key" yaml:"key"`
+	Operator string   `json:"operator" yaml:"operator"`
+	Values   []string `json:"values,omitempty" yaml:"values,omitempty"`
 }
 
 type KformBlockSchema struct {
@@ -111,7 +135,7 @@ const (
 	MetaArgumentUnknown MetaArgument = "unknown"
 	MetaArgumentSchema  MetaArgument = "schema"
 	MetaArgumentSource  MetaArgument = "source"
-	//MetaArgumentAlias         MetaArgument = "alias"
+	MetaArgumentAlias   MetaArgument = "alias"
 	//MetaArgumentAliases       MetaArgument = "aliases"
 	MetaArgumentCount         MetaArgument = "count"
 	MetaArgumentForEach       MetaArgument = "forEach"
@@ -129,4 +153,6 @@ const (
 	MetaArgumentHostname      MetaArgument = "hostname"
 	MetaArgumentOrganization  MetaArgument = "organization"
 	MetaArgumentWorkspaces    MetaArgument = "workspaces"
+	MetaArgumentSelector      MetaArgument = "selector"
+	MetaArgumentNamespace     MetaArgument = "namespace"
 )
@@ -17,6 +17,7 @@ func (c CtxKey) String() string {
 const (
 	//CtxExecConfig      CtxKey = "execConfig"
 	CtxKeyRecorder     CtxKey = "recorder"
+	CtxKeyStore        CtxKey = "store"
 	CtxKeyModule       CtxKey = "module"
 	CtxKeyModuleName   CtxKey = "moduleName"
 	CtxKeyModuleKind   CtxKey = "moduleKind"
@@ -30,6 +31,12 @@ const (
 	//CtxKeyInstances  CtxKey = "instances"
 	//CtxKeyInput      CtxKey = "input"
 	//CtxKeyDefault    CtxKey = "default"
+
+	// CtxKeyInsecurePlugins disables the checksum verification of the
+	// providers and mutual TLS
+	CtxKeyInsecurePlugins CtxKey = "insecurePlugins"
+	// CtxKeyBuiltinProviders holds the registry of the built-in providers
+	CtxKeyBuiltinProviders CtxKey = "builtinProviders"
 )
 
 type ModuleKind = string
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML decodes the kform and keeps a reference to the yaml node of
+// every block such that blocks, attributes and expressions can be mapped back
+// to their location in the kform file.
+func (r *Kform) UnmarshalYAML(n *yaml.Node) error {
+	type kform Kform
+	kf := kform{}
+	if err := n.Decode(&kf); err != nil {
+		return err
+	}
+	*r = Kform(kf)
+
+	specNode := getMappingValue(n, "spec")
+	if specNode == nil || specNode.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for i := range r.Blocks {
+		if i < len(specNode.Content) {
+			setBlockNodes(&r.Blocks[i], specNode.Content[i])
+		}
+	}
+	return nil
+}
+
+func setBlockNodes(block *KformBlock, n *yaml.Node) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return
+	}
+	block.Node = n
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i].Value, n.Content[i+1]
+		switch BlockContextKey(key) {
+		case BlockContextKeyAttributes, BlockContextKeyDefault, BlockContextKeyInputParams, BlockContextKeyConfig, BlockContextKeyValue:
+		default:
+			nestedBlock, ok := block.NestedBlock[key]
+			if !ok {
+				continue
+			}
+			setBlockNodes(&nestedBlock, value)
+			block.NestedBlock[key] = nestedBlock
+		}
+	}
+}
+
+func getMappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// LookupNode walks the yaml node along the steps of a path and returns the
+// node the path points to. When the path cannot be fully resolved the deepest
+// node that was found is returned, together with false.
+func LookupNode(n *yaml.Node, steps []string) (*yaml.Node, bool) {
+	if n == nil {
+		return nil, false
+	}
+	for _, step := range steps {
+		var next *yaml.Node
+		switch n.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == step {
+					next = n.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if idx, err := strconv.Atoi(step); err == nil && idx >= 0 && idx < len(n.Content) {
+				next = n.Content[idx]
+			}
+		}
+		if next == nil {
+			return n, false
+		}
+		n = next
+	}
+	return n, true
+}
+
+// NewPosition returns the position of the yaml node in the file
+func NewPosition(fileName string, n *yaml.Node) diag.Position {
+	if n == nil {
+		return diag.Position{FileName: fileName}
+	}
+	return diag.Position{FileName: fileName, Line: n.Line, Column: n.Column}
+}
+
+// GetPosition returns the position of the block or of the attribute at the
+// path within the block, e.g. GetPosition(fileName, "attributes", "forEach").
+// When the path cannot be resolved the position of the closest parent is
+// returned.
+func (r KformBlockContext) GetPosition(fileName string, path ...string) diag.Position {
+	n, _ := LookupNode(r.Node, path)
+	return NewPosition(fileName, n)
+}
+
+// GetExpressionPosition returns the position of the first expression in the
+// block that contains the reference. For single line expressions the column
+// points to the reference itself.
+func (r KformBlockContext) GetExpressionPosition(fileName string, ref string) diag.Position {
+	n, idx := findExpressionNode(r.Node, ref)
+	if n == nil {
+		return NewPosition(fileName, r.Node)
+	}
+	pos := NewPosition(fileName, n)
+	switch n.Style {
+	case 0, yaml.TaggedStyle:
+		pos.Column += idx
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		if !strings.Contains(n.Value[:idx], "\n") {
+			pos.Column += idx + 1
+		}
+	}
+	return pos
+}
+
+func findExpressionNode(n *yaml.Node, ref string) (*yaml.Node, int) {
+	if n == nil {
+		return nil, -1
+	}
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if idx := strings.Index(n.Value, ref); idx >= 0 {
+			return n, idx
+		}
+	case yaml.MappingNode:
+		// only the values can hold expressions
+		for i := 1; i < len(n.Content); i += 2 {
+			if found, idx := findExpressionNode(n.Content[i], ref); found != nil {
+				return found, idx
+			}
+		}
+	default:
+		for _, c := range n.Content {
+			if found, idx := findExpressionNode(c, ref); found != nil {
+				return found, idx
+			}
+		}
+	}
+	return nil, -1
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var testNode = `
+config:
+  metadata:
+    name: nginx
+    annotations:
+      app.kubernetes.io/name: nginx
+      a~b: c
+  spec:
+    containers:
+    - name: nginx
+      image: nginx:1.14.2
+    - name: sidecar
+      image: busybox
+`
+
+func TestLookupNode(t *testing.T) {
+	cases := map[string]struct {
+		steps     []string
+		wantOk    bool
+		wantValue string
+		wantLine  int
+	}{
+		"Root": {
+			steps:    []string{},
+			wantOk:   true,
+			wantLine: 2,
+		},
+		"Mapping": {
+			steps:     []string{"config", "metadata", "name"},
+			wantOk:    true,
+			wantValue: "nginx",
+			wantLine:  4,
+		},
+		"Sequence": {
+			steps:     []string{"config", "spec", "containers", "1", "image"},
+			wantOk:    true,
+			wantValue: "busybox",
+			wantLine:  13,
+		},
+		"KeyWithSlash": {
+			steps:     []string{"config", "metadata", "annotations", "app.kubernetes.io/name"},
+			wantOk:    true,
+			wantValue: "nginx",
+			wantLine:  6,
+		},
+		"MissingKey": {
+			steps:    []string{"config", "metadata", "labels"},
+			wantOk:   false,
+			wantLine: 4,
+		},
+		"IndexOutOfRange": {
+			steps:    []string{"config", "spec", "containers", "2", "image"},
+			wantOk:   false,
+			wantLine: 10,
+		},
+		"InvalidIndex": {
+			steps:    []string{"config", "spec", "containers", "nginx"},
+			wantOk:   false,
+			wantLine: 10,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := getTestNode(t)
+			got, ok := LookupNode(n, tc.steps)
+			assert.Equal(t, tc.wantOk, ok)
+			if tc.wantValue != "" {
+				assert.Equal(t, tc.wantValue, got.Value)
+			}
+			assert.Equal(t, tc.wantLine, got.Line)
+		})
+	}
+}
+
+// TestLookupNodeFromPointer checks that the attribute path of a diagnostic,
+// which a provider returns as an escaped JSON pointer, maps back to the yaml
+// node of the attribute.
+func TestLookupNodeFromPointer(t *testing.T) {
+	cases := map[string]struct {
+		pointer   string
+		wantValue string
+	}{
+		"Slash": {
+			pointer:   "/config/metadata/annotations/app.kubernetes.io~1name",
+			wantValue: "nginx",
+		},
+		"Tilde": {
+			pointer:   "/config/metadata/annotations/a~0b",
+			wantValue: "c",
+		},
+		"Index": {
+			pointer:   "/config/spec/containers/0/image",
+			wantValue: "nginx:1.14.2",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := getTestNode(t)
+			got, ok := LookupNode(n, diag.AttributePathFromPointer(tc.pointer).Steps)
+			assert.True(t, ok)
+			assert.Equal(t, tc.wantValue, got.Value)
+		})
+	}
+}
+
+func getTestNode(t *testing.T) *yaml.Node {
+	t.Helper()
+	n := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(testNode), n); err != nil {
+		t.Fatalf("cannot unmarshal test node: %s", err.Error())
+	}
+	// the document node wraps the mapping node
+	return n.Content[0]
+}
+
+var testKform = `spec:
+- resource:
+    kubernetes_manifest:
+      nginx:
+        attributes:
+          count: $input.replicas
+        config:
+          metadata:
+            name: $input.name
+            namespace: "ns-${input.namespace}"
+`
+
+func TestKformBlockPosition(t *testing.T) {
+	kf := Kform{}
+	if err := yaml.Unmarshal([]byte(testKform), &kf); err != nil {
+		t.Fatalf("cannot unmarshal kform: %s", err.Error())
+	}
+	blockCtx := kf.Blocks[0].NestedBlock["resource"].NestedBlock["kubernetes_manifest"].NestedBlock["nginx"].KformBlockContext
+
+	cases := map[string]struct {
+		path []string
+		ref  string
+		want string
+	}{
+		"Block": {
+			want: "main.yaml:5:9",
+		},
+		"Attribute": {
+			path: []string{"config", "metadata", "name"},
+			want: "main.yaml:9:19",
+		},
+		"UnknownAttribute": {
+			path: []string{"config", "metadata", "labels"},
+			want: "main.yaml:9:13",
+		},
+		"Expression": {
+			ref:  "$input.replicas",
+			want: "main.yaml:6:18",
+		},
+		"QuotedExpression": {
+			ref:  "input.namespace",
+			want: "main.yaml:10:30",
+		},
+		"UnknownExpression": {
+			ref:  "input.unknown",
+			want: "main.yaml:5:9",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.ref != "" {
+				assert.Equal(t, tc.want, blockCtx.GetExpressionPosition("main.yaml", tc.ref).String())
+				return
+			}
+			assert.Equal(t, tc.want, blockCtx.GetPosition("main.yaml", tc.path...).String())
+		})
+	}
+}
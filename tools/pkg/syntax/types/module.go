@@ -12,6 +12,8 @@ import (
 	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 type Module struct {
@@ -32,6 +34,10 @@ type Module struct {
 	Resources   cache.Cache[*Resource]
 	ModuleCalls cache.Cache[*ModuleCall]
 
+	// Schemas are the openapi schemas of the custom resource definitions in
+	// the package of the module
+	Schemas map[schema.GroupVersionKind]*spec.Schema
+
 	DAG         dag.DAG[*VertexContext]
 	ProviderDAG dag.DAG[*VertexContext]
 }
@@ -49,6 +55,7 @@ func NewModule(nsn cache.NSN, kind ModuleKind, recorder recorder.Recorder[diag.D
 		Outputs:     cache.New[*Output](),
 		Resources:   cache.New[*Resource](),
 		ModuleCalls: cache.New[*ModuleCall](),
+		Schemas:     map[schema.GroupVersionKind]*spec.Schema{},
 	}
 }
 
@@ -66,6 +73,8 @@ type DependencyBlock interface {
 	GetModDependencies() map[string]string
 	GetContext(string) string
 	GetAttributes() *KformBlockAttributes
+	GetPosition(path ...string) diag.Position
+	GetExpressionPosition(ref string) diag.Position
 }
 
 type modDeps map[string]string
@@ -115,36 +124,36 @@ func (r *Module) ResolveDAGDependencies(ctx context.Context) {
 }
 
 func (r *Module) resolveDependencies(ctx context.Context, nsn cache.NSN, v DependencyBlock) {
-	for d, dctx := range v.GetDependencies() {
+	for d := range v.GetDependencies() {
 		switch strings.Split(d, ".")[0] {
 		case string(BlockTypeInput):
 			if _, err := r.Inputs.Get(cache.NSN{Name: d}); err != nil {
-				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed for %s, ctx: %s, err: %s", r.Kind, r.NSN.Name, d, dctx, err.Error()))
+				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "unknown reference $%s, %s module: %s, err: %s", d, r.Kind, r.NSN.Name, err.Error()).WithPosition(v.GetExpressionPosition("$" + d)))
 			}
 		case string(BlockTypeOutput):
 			if _, err := r.Outputs.Get(cache.NSN{Name: d}); err != nil {
-				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed for %s, ctx: %s, err: %s", r.Kind, r.NSN.Name, d, dctx, err.Error()))
+				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "unknown reference $%s, %s module: %s, err: %s", d, r.Kind, r.NSN.Name, err.Error()).WithPosition(v.GetExpressionPosition("$" + d)))
 			}
 		case string(BlockTypeLocal):
 			if _, err := r.Locals.Get(cache.NSN{Name: d}); err != nil {
-				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed for %s, ctx: %s, err: %s", r.Kind, r.NSN.Name, d, dctx, err.Error()))
+				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "unknown reference $%s, %s module: %s, err: %s", d, r.Kind, r.NSN.Name, err.Error()).WithPosition(v.GetExpressionPosition("$" + d)))
 			}
 		case string(BlockTypeModule):
 			if _, err := r.ModuleCalls.Get(cache.NSN{Name: d}); err != nil {
-				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed for %s, ctx: %s, err: %s", r.Kind, r.NSN.Name, d, dctx, err.Error()))
+				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "unknown reference $%s, %s module: %s, err: %s", d, r.Kind, r.NSN.Name, err.Error()).WithPosition(v.GetExpressionPosition("$" + d)))
 			}
 		case "each":
 			if v.GetAttributes().ForEach == nil {
-				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed each requires a for_each attribute dependency: %s, ctx: %s", r.Kind, r.NSN.Name, d, dctx))
+				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed each requires a for_each attribute dependency: %s", r.Kind, r.NSN.Name, d).WithPosition(v.GetExpressionPosition("$" + d)))
 			}
 		case "count":
 			if v.GetAttributes().Count == nil {
-				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed each requires a count attribute dependency: %s, ctx: %s", r.Kind, r.NSN.Name, d, dctx))
+				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed each requires a count attribute dependency: %s", r.Kind, r.NSN.Name, d).WithPosition(v.GetExpressionPosition("$" + d)))
 			}
 		default:
 			// resources - resource or data
 			if _, err := r.Resources.Get(cache.NSN{Name: d}); err != nil {
-				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s dependency resolution failed for %s, ctx: %s, err: %s", r.Kind, r.NSN.Name, d, dctx, err.Error()))
+				r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "unknown reference $%s, %s module: %s, err: %s", d, r.Kind, r.NSN.Name, err.Error()).WithPosition(v.GetExpressionPosition("$" + d)))
 			}
 		}
 	}
@@ -154,7 +163,7 @@ func (r *Module) ResolveResource2ProviderConfig(ctx context.Context) {
 	for nsn, v := range r.Resources.List() {
 		provider := v.GetProvider()
 		if _, err := r.ProviderConfigs.Get(cache.NSN{Name: provider}); err != nil {
-			r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s provider resolution resource2providerConfig failed for %s, err: %s", r.Kind, r.NSN.Name, provider, err.Error()))
+			r.recorder.Record(diag.DiagErrorfWithContext(v.GetContext(nsn.Name), "%s module: %s provider resolution resource2providerConfig failed for %s, err: %s", r.Kind, r.NSN.Name, provider, err.Error()).WithPosition(v.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentProvider))))
 		}
 	}
 }
@@ -197,16 +206,7 @@ func (r *Module) generateDAG(ctx context.Context, provider bool, unrefed []strin
 	})
 
 	for nsn, x := range r.Inputs.List() {
-		d.AddVertex(ctx, nsn.Name, &VertexContext{
-			FileName:        x.fileName,
-			ModuleName:      x.moduleName.Name,
-			BlockName:       nsn.Name,
-			BlockType:       x.blockType,
-			GVK:             x.gvk,
-			BlockContext:    x.KformBlockContext,
-			Dependencies:    x.dependencies,
-			ModDependencies: x.modDependencies,
-		})
+		d.AddVertex(ctx, nsn.Name, x.newVertexContext(nsn.Name))
 	}
 	if provider {
 		for nsn, x := range r.ProviderConfigs.List() {
@@ -219,16 +219,7 @@ func (r *Module) generateDAG(ctx context.Context, provider bool, unrefed []strin
 				}
 			}
 			if !found {
-				d.AddVertex(ctx, nsn.Name, &VertexContext{
-					FileName:        x.fileName,
-					ModuleName:      x.moduleName.Name,
-					BlockName:       nsn.Name,
-					BlockType:       x.blockType,
-					GVK:             x.gvk,
-					BlockContext:    x.KformBlockContext,
-					Dependencies:    x.dependencies,
-					ModDependencies: x.modDependencies,
-				})
+				d.AddVertex(ctx, nsn.Name, x.newVertexContext(nsn.Name))
 			}
 
 		}
@@ -241,58 +232,38 @@ func (r *Module) generateDAG(ctx context.Context, provider bool, unrefed []strin
 		// - modules
 		// - resources
 		for nsn, x := range r.Outputs.List() {
-			d.AddVertex(ctx, nsn.Name, &VertexContext{
-				FileName:        x.fileName,
-				ModuleName:      x.moduleName.Name,
-				BlockName:       nsn.Name,
-				BlockType:       x.blockType,
-				GVK:             x.gvk,
-				BlockContext:    x.KformBlockContext,
-				Dependencies:    x.dependencies,
-				ModDependencies: x.modDependencies,
-			})
+			d.AddVertex(ctx, nsn.Name, x.newVertexContext(nsn.Name))
 		}
 		for nsn, x := range r.Locals.List() {
-			d.AddVertex(ctx, nsn.Name, &VertexContext{
-				FileName:        x.fileName,
-				ModuleName:      x.moduleName.Name,
-				BlockName:       nsn.Name,
-				BlockType:       x.blockType,
-				GVK:             x.gvk,
-				BlockContext:    x.KformBlockContext,
-				Dependencies:    x.dependencies,
-				ModDependencies: x.modDependencies,
-			})
+			d.AddVertex(ctx, nsn.Name, x.newVertexContext(nsn.Name))
 		}
 		for nsn, x := range r.ModuleCalls.List() {
-			d.AddVertex(ctx, nsn.Name, &VertexContext{
-				FileName:        x.fileName,
-				ModuleName:      x.moduleName.Name,
-				BlockName:       nsn.Name,
-				BlockType:       x.blockType,
-				GVK:             x.gvk,
-				BlockContext:    x.KformBlockContext,
-				Dependencies:    x.dependencies,
-				ModDependencies: x.modDependencies,
-			})
+			d.AddVertex(ctx, nsn.Name, x.newVertexContext(nsn.Name))
 		}
 		for nsn, x := range r.Resources.List() {
-			d.AddVertex(ctx, nsn.Name, &VertexContext{
-				FileName:        x.fileName,
-				ModuleName:      x.moduleName.Name,
-				BlockName:       nsn.Name,
-				BlockType:       x.blockType,
-				GVK:             x.gvk,
-				BlockContext:    x.KformBlockContext,
-				Dependencies:    x.dependencies,
-				ModDependencies: x.modDependencies,
-				Provider:        x.provider,
-			})
+			vCtx := x.newVertexContext(nsn.Name)
+			vCtx.Provider = x.provider
+			d.AddVertex(ctx, nsn.Name, vCtx)
 		}
 	}
 	return d
 }
 
+// newVertexContext returns the vertex context of the block in the dag
+func (r *config) newVertexContext(blockName string) *VertexContext {
+	return &VertexContext{
+		FileName:        r.fileName,
+		ModuleName:      r.moduleName.Name,
+		BlockName:       blockName,
+		BlockType:       r.blockType,
+		GVK:             r.gvk,
+		Position:        r.GetPosition(),
+		BlockContext:    r.KformBlockContext,
+		Dependencies:    r.dependencies,
+		ModDependencies: r.modDependencies,
+	}
+}
+
 func (r *Module) ValidateChildProviderConfigs(ctx context.Context) {
 	if r.Kind == ModuleKindChild {
 		providerConfigs := []string{}
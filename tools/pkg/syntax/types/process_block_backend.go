@@ -56,7 +56,7 @@ func (r *backend) UpdateModule(ctx context.Context) {
 			fmt.Errorf("expecting only 1 backend config duplicate %s with %s",
 				fmt.Sprintf("filename: %s, blockName: %s, blockType: %s", m.Backend.GetFileName(), m.Backend.GetBlockName(), m.Backend.GetBlockType()),
 				fmt.Sprintf("filename: %s, blockName: %s, blockType: %s", x.GetFileName(), x.GetBlockName(), x.GetBlockType()),
-			)))
+			)).WithPosition(x.GetPosition()))
 	}
 	m.Backend = x
 }
@@ -59,7 +59,7 @@ func (r *input) UpdateModule(ctx context.Context) {
 				x.GetFileName(),
 				x.GetBlockName(),
 				x.GetBlockType(),
-			)))
+			)).WithPosition(x.GetPosition()))
 	}
 }
 
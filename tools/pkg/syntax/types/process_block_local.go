@@ -60,7 +60,7 @@ func (r *local) UpdateModule(ctx context.Context) {
 				x.GetFileName(),
 				x.GetBlockName(),
 				x.GetBlockType(),
-			)))
+			)).WithPosition(x.GetPosition()))
 	}
 }
 
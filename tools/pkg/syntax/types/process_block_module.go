@@ -59,7 +59,7 @@ func (r *module) UpdateModule(ctx context.Context) {
 				x.GetFileName(),
 				x.GetBlockName(),
 				x.GetBlockType(),
-			)))
+			)).WithPosition(x.GetPosition()))
 	}
 }
 
@@ -93,3 +93,31 @@ func (r *ModuleCall) getProviders(_ context.Context) {
 func (r *ModuleCall) GetProviders() map[string]string {
 	return r.providers
 }
+
+// ProviderConfigMap maps the provider config names referenced in a module to
+// the provider configs of the root module.
+type ProviderConfigMap map[string]string
+
+// Get returns the name of the root module provider config, a name that is
+// not mapped references the root module provider config directly.
+func (r ProviderConfigMap) Get(name string) string {
+	if providerConfig, ok := r[name]; ok {
+		return providerConfig
+	}
+	return name
+}
+
+// ModuleCall returns the provider config map of a called module, the
+// providers of the module call map the provider configs of the called module
+// to the provider configs of the calling module, e.g. kubernetes: kubernetes.edge01.
+// Provider configs that are not mapped are inherited from the calling module.
+func (r ProviderConfigMap) ModuleCall(providers map[string]string) ProviderConfigMap {
+	providerConfigMap := make(ProviderConfigMap, len(r)+len(providers))
+	for name, providerConfig := range r {
+		providerConfigMap[name] = providerConfig
+	}
+	for targetProvider, sourceProvider := range providers {
+		providerConfigMap[targetProvider] = r.Get(sourceProvider)
+	}
+	return providerConfigMap
+}
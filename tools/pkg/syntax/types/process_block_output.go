@@ -63,7 +63,7 @@ func (r *output) UpdateModule(ctx context.Context) {
 				x.GetFileName(),
 				x.GetBlockName(),
 				x.GetBlockType(),
-			)))
+			)).WithPosition(x.GetPosition()))
 	}
 }
 
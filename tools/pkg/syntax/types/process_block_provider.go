@@ -3,11 +3,13 @@ package types
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/tools/pkg/recorder"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cctx"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func newProvider(ctx context.Context, n string) Block {
@@ -21,6 +23,7 @@ func newProvider(ctx context.Context, n string) Block {
 			},
 			expectedAttributes: map[string]bool{
 				string(MetaArgumentSchema): mandatory,
+				string(MetaArgumentAlias):  optional,
 			},
 			recorder: cctx.GetContextValue[recorder.Recorder[diag.Diagnostic]](ctx, CtxKeyRecorder),
 		},
@@ -38,7 +41,7 @@ func (r *provider) UpdateModule(ctx context.Context) {
 		config: r.config,
 		name:   cctx.GetContextValue[string](ctx, CtxKeyVarName),
 	}
-	//x.GetAlias(ctx)
+	x.getAlias(ctx)
 
 	if len(r.dependencies) > 0 {
 		r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), fmt.Errorf("not expecting a dependency, got: %v", r.dependencies)))
@@ -58,7 +61,7 @@ func (r *provider) UpdateModule(ctx context.Context) {
 				x.GetFileName(),
 				x.GetBlockName(),
 				x.GetBlockType(),
-			)))
+			)).WithPosition(x.GetPosition()))
 	}
 }
 
@@ -66,12 +69,95 @@ type ProviderConfig struct {
 	config
 
 	name string
+	// alias allows multiple configs of the same provider, resources
+	// reference an aliased config as <provider>.<alias>
+	alias string
 }
 
+// GetBlockName returns the name of the provider config, e.g. kubernetes or
+// kubernetes.edge01 for an aliased provider config
 func (r *ProviderConfig) GetBlockName() string {
+	if r.alias != "" {
+		return fmt.Sprintf("%s.%s", r.name, r.alias)
+	}
 	return r.name
 }
 
+// GetName returns the name of the provider
 func (r *ProviderConfig) GetName() string {
 	return r.name
 }
+
+func (r *ProviderConfig) GetAlias() string {
+	return r.alias
+}
+
+func (r *ProviderConfig) getAlias(ctx context.Context) {
+	if r.KformBlockContext.Attributes != nil && r.KformBlockContext.Attributes.Alias != nil {
+		r.alias = *r.KformBlockContext.Attributes.Alias
+		if err := validateAlias(r.alias); err != nil {
+			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err).
+				WithPosition(r.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentAlias))))
+		}
+	}
+}
+
+func validateAlias(alias string) error {
+	if alias == "" || strings.Contains(alias, ".") {
+		return fmt.Errorf("invalid provider alias %q, an alias must be non empty and cannot contain a '.'", alias)
+	}
+	return nil
+}
+
+// GetProviderName returns the name of the provider from a provider config
+// name, e.g. kubernetes for kubernetes.edge01
+func GetProviderName(providerConfigName string) string {
+	return strings.Split(providerConfigName, ".")[0]
+}
+
+// AddBuiltinProviderConfig adds the implicit provider config of a built-in
+// provider to the module, when the module has no config for the provider.
+// A built-in provider has nothing to configure, such that resources can use
+// the provider without provider config.
+func (r *Module) AddBuiltinProviderConfig(ctx context.Context, name string) {
+	b := cctx.GetContextValue[BuiltinProviders](ctx, CtxKeyBuiltinProviders)
+	if b == nil || !b.IsBuiltin(name) {
+		return
+	}
+	if _, err := r.ProviderConfigs.Get(cache.NSN{Name: name}); err == nil {
+		return
+	}
+	gv, err := schema.ParseGroupVersion(b.GetAPIVersion(name))
+	if err != nil {
+		r.recorder.Record(diag.DiagFromErr(err))
+		return
+	}
+	x := &ProviderConfig{
+		config: config{
+			level:      1,
+			blockType:  BlockTypeProvider,
+			recorder:   r.recorder,
+			moduleName: r.NSN,
+			gvk:        gv.WithKind(BuiltinProviderConfigKind),
+			KformBlockContext: KformBlockContext{
+				Attributes: &KformBlockAttributes{
+					Schema: &KformBlockSchema{
+						ApiVersion: gv.String(),
+						Kind:       BuiltinProviderConfigKind,
+					},
+				},
+				Config: map[string]any{
+					"apiVersion": gv.String(),
+					"kind":       BuiltinProviderConfigKind,
+					"metadata": map[string]any{
+						"name": name,
+					},
+				},
+			},
+		},
+		name: name,
+	}
+	if err := r.ProviderConfigs.Add(ctx, cache.NSN{Name: name}, x); err != nil {
+		r.recorder.Record(diag.DiagFromErr(err))
+	}
+}
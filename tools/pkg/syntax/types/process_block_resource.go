@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
@@ -12,7 +13,7 @@ import (
 )
 
 func newResource(ctx context.Context, n string) Block {
-	return &resource{
+	r := &resource{
 		config: config{
 			level:     2,
 			blockType: GetBlockType(n),
@@ -21,8 +22,7 @@ func newResource(ctx context.Context, n string) Block {
 				BlockContextKeyConfig:     mandatory,
 			},
 			expectedAttributes: map[string]bool{
-				string(MetaArgumentSchema): mandatory,
-				//string(MetaArgumentAlias):         optional,
+				string(MetaArgumentSchema):        mandatory,
 				string(MetaArgumentProvider):      optional,
 				string(MetaArgumentDependsOn):     optional,
 				string(MetaArgumentCount):         optional,
@@ -39,6 +39,12 @@ func newResource(ctx context.Context, n string) Block {
 			recorder: cctx.GetContextValue[recorder.Recorder[diag.Diagnostic]](ctx, CtxKeyRecorder),
 		},
 	}
+	// a list selects the objects it lists
+	if r.blockType == BlockTypeList {
+		r.expectedAttributes[string(MetaArgumentSelector)] = optional
+		r.expectedAttributes[string(MetaArgumentNamespace)] = optional
+	}
+	return r
 }
 
 type resource struct {
@@ -59,6 +65,7 @@ func (r *resource) UpdateModule(ctx context.Context) {
 		return
 	}
 	x.getProvider(ctx)
+	x.validateSelector(ctx)
 
 	// update module
 	m := cctx.GetContextValue[*Module](ctx, CtxKeyModule)
@@ -74,7 +81,7 @@ func (r *resource) UpdateModule(ctx context.Context) {
 				x.GetFileName(),
 				x.GetBlockName(),
 				x.GetBlockType(),
-			)))
+			)).WithPosition(x.GetPosition()))
 	}
 }
 
@@ -101,3 +108,28 @@ func (r *Resource) getProvider(_ context.Context) {
 func (r *Resource) GetProvider() string {
 	return r.provider
 }
+
+// validateSelector validates the operators of the selector, the keys and
+// values can be expressions and are validated when the list runs
+func (r *Resource) validateSelector(ctx context.Context) {
+	if r.KformBlockContext.Attributes == nil || r.KformBlockContext.Attributes.Selector == nil {
+		return
+	}
+	for idx, req := range r.KformBlockContext.Attributes.Selector.MatchExpressions {
+		switch req.Operator {
+		case "In", "NotIn":
+			if len(req.Values) == 0 {
+				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "selector operator %s requires values", req.Operator).
+					WithPosition(r.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentSelector), "matchExpressions", strconv.Itoa(idx))))
+			}
+		case "Exists", "DoesNotExist":
+			if len(req.Values) != 0 {
+				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "selector operator %s does not support values", req.Operator).
+					WithPosition(r.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentSelector), "matchExpressions", strconv.Itoa(idx))))
+			}
+		default:
+			r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "invalid selector operator %q, expected one of [In NotIn Exists DoesNotExist]", req.Operator).
+				WithPosition(r.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentSelector), "matchExpressions", strconv.Itoa(idx))))
+		}
+	}
+}
@@ -3,21 +3,27 @@ package types
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"slices"
 	"strings"
 	"sync"
+
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
 )
 
 type Renderer interface {
 	GatherDependencies(ctx context.Context, x any) error
 	GetDependencies() map[string]string
 	GetModuleOutputDependencies() map[string]string
+	GetWarnings() []string
+	// GetValues returns the values with expressions, keyed by their string
+	GetValues() map[string]*celenv.Value
 }
 
 func NewRenderer() Renderer {
 	return &renderer{
 		deps:    map[string]string{},
 		modDeps: map[string]string{},
+		values:  map[string]*celenv.Value{},
 	}
 }
 
@@ -25,11 +31,14 @@ type renderer struct {
 	m       sync.RWMutex
 	deps    map[string]string
 	modDeps map[string]string
+	// warnings of the ambiguous legacy values
+	warnings []string
+	values   map[string]*celenv.Value
 }
 
 func (r *renderer) GetDependencies() map[string]string {
 	r.m.RLock()
-	defer r.m.RLock()
+	defer r.m.RUnlock()
 	d := make(map[string]string, len(r.deps))
 	for k, v := range r.deps {
 		d[k] = v
@@ -39,7 +48,7 @@ func (r *renderer) GetDependencies() map[string]string {
 
 func (r *renderer) GetModuleOutputDependencies() map[string]string {
 	r.m.RLock()
-	defer r.m.RLock()
+	defer r.m.RUnlock()
 	d := make(map[string]string, len(r.modDeps))
 	for k, v := range r.modDeps {
 		d[k] = v
@@ -47,6 +56,24 @@ func (r *renderer) GetModuleOutputDependencies() map[string]string {
 	return d
 }
 
+func (r *renderer) GetWarnings() []string {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	w := make([]string, len(r.warnings))
+	copy(w, r.warnings)
+	return w
+}
+
+func (r *renderer) GetValues() map[string]*celenv.Value {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	v := make(map[string]*celenv.Value, len(r.values))
+	for k, x := range r.values {
+		v[k] = x
+	}
+	return v
+}
+
 func (r *renderer) GatherDependencies(ctx context.Context, x any) error {
 	/*
 		blockType := cctx.GetContextValue[string](ctx, CtxKeyBlockType)
@@ -88,59 +115,24 @@ func (r *renderer) GatherDependencies(ctx context.Context, x any) error {
 }
 
 func (r *renderer) getRefsFromExpr(ctx context.Context, expr string) error {
-	depsSplit := strings.Split(expr, "$")
-	if len(depsSplit) == 1 {
-		return nil
+	v, err := celenv.ParseValue(expr)
+	if err != nil {
+		return err
 	}
-	for _, dep := range depsSplit[1:] {
-		depSplit := strings.Split(dep, ".")
-		if len(depSplit) < 2 {
-			return fmt.Errorf("a dependency always need <namespace>.<name>, got: %s", dep)
-		}
-
-		r.addDependency(false, ParseReferenceString(strings.Join(depSplit[:2], ".")), GetContext(ctx))
-
-		if depSplit[0] == "module" {
-			if len(depSplit) < 3 {
-				return fmt.Errorf("a module dependency always need <namespace>.<name>.<output>, got: %s", dep)
-			}
-			r.addDependency(true, ParseReferenceString(strings.Join(depSplit[:3], ".")), GetContext(ctx))
-			//r.modDeps = append(r.modDeps, parsedependencyString(strings.Join(depSplit[:3], ".")))
-		}
+	if v.Ambiguous {
+		r.addWarning(fmt.Sprintf("value %q is a literal string, use \"${%s}\" to evaluate it as an expression", expr, expr))
 	}
-	return nil
-}
-
-func ParseReferenceString(inputString string) string {
-	// Define a regular expression pattern to match special characters
-	specialCharPattern := "[$&+,:;=?@#|'<>-^*()%!]"
-
-	// Compile the regular expression
-	regex := regexp.MustCompile(specialCharPattern)
-
-	// Find all matches in the input string
-	matches := regex.FindAllStringIndex(inputString, -1)
-
-	//fmt.Println("inputString", inputString)
-	if matches == nil {
-		//fmt.Println("No special characters found.")
-		//fmt.Println("outputString no match", inputString)
-		return inputString
-	} else {
-		for matchIdx, match := range matches {
-			start, end := match[0], match[1]
-			//fmt.Printf("Special character found at positions %d to %d: %s\n", start, end-1, inputString[start:end])
-			// if the special char is a lowercase/upercase letter or - or _ we continue
-			re := regexp.MustCompile(`^[a-zA-Z]+[a-zA-Z0-9_-]*$`)
-			if re.Match([]byte(inputString[start:end])) {
-				continue
-			}
-			//fmt.Println("outputString match", inputString)
-			return inputString[0:matches[matchIdx][0]]
+	if !v.IsLiteral() {
+		r.addValue(expr, v)
+	}
+	for _, ref := range v.GetReferences().UnsortedList() {
+		split := strings.Split(ref, ".")
+		r.addDependency(false, strings.Join(split[:2], "."), GetContext(ctx))
+		if split[0] == celenv.NamespaceModule {
+			r.addDependency(true, ref, GetContext(ctx))
 		}
-		//fmt.Println("outputString match out", inputString)
-		return inputString
 	}
+	return nil
 }
 
 func (r *renderer) addDependency(mod bool, k string, v string) {
@@ -152,3 +144,18 @@ func (r *renderer) addDependency(mod bool, k string, v string) {
 		r.deps[k] = v
 	}
 }
+
+func (r *renderer) addWarning(w string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	// the attributes are gathered more than once
+	if !slices.Contains(r.warnings, w) {
+		r.warnings = append(r.warnings, w)
+	}
+}
+
+func (r *renderer) addValue(k string, v *celenv.Value) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.values[k] = v
+}
@@ -31,6 +31,7 @@ type config struct {
 	fileName   string
 	moduleName cache.NSN
 	gvk        schema.GroupVersionKind
+	position   diag.Position
 	KformBlockContext
 	dependencies    map[string]string
 	modDependencies map[string]string
@@ -49,7 +50,7 @@ func (r *config) getDependencies(ctx context.Context) {
 	r.getAttributeDependencies(ctx, rn)
 	if r.KformBlockContext.Attributes != nil {
 		if err := rn.GatherDependencies(ctx, r.KformBlockContext.Attributes); err != nil {
-			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err))
+			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err).WithPosition(r.GetPosition(string(BlockContextKeyAttributes))))
 		}
 	}
 	if r.KformBlockContext.Value != nil {
@@ -59,24 +60,27 @@ func (r *config) getDependencies(ctx context.Context) {
 			}
 		*/
 		if err := rn.GatherDependencies(ctx, r.KformBlockContext.Value); err != nil {
-			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err))
+			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err).WithPosition(r.GetPosition(string(BlockContextKeyValue))))
 		}
 	}
 	if r.KformBlockContext.Default != nil {
 		if err := rn.GatherDependencies(ctx, r.KformBlockContext.Default); err != nil {
-			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err))
+			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err).WithPosition(r.GetPosition(string(BlockContextKeyDefault))))
 		}
 	}
 	if r.KformBlockContext.InputParams != nil {
 		if err := rn.GatherDependencies(ctx, r.KformBlockContext.InputParams); err != nil {
-			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err))
+			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err).WithPosition(r.GetPosition(string(BlockContextKeyInputParams))))
 		}
 	}
 	if r.KformBlockContext.Config != nil {
 		if err := rn.GatherDependencies(ctx, r.KformBlockContext.Config); err != nil {
-			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err))
+			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err).WithPosition(r.GetPosition(string(BlockContextKeyConfig))))
 		}
 	}
+	for _, w := range rn.GetWarnings() {
+		r.recorder.Record(diag.DiagWarnfWithContext(GetContext(ctx), "%s", w))
+	}
 	r.dependencies = rn.GetDependencies()
 	r.modDependencies = rn.GetModuleOutputDependencies()
 }
@@ -94,7 +98,7 @@ func (r *config) getAttributeDependencies(ctx context.Context, rn Renderer) {
 			return
 		}
 		if err := rn.GatherDependencies(ctx, attributes); err != nil {
-			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err))
+			r.recorder.Record(diag.DiagFromErrWithContext(GetContext(ctx), err).WithPosition(r.GetPosition(string(BlockContextKeyAttributes))))
 		}
 	}
 }
@@ -245,6 +249,21 @@ func (r *config) GetModDependencies() map[string]string {
 	return r.modDependencies
 }
 
+// GetPosition returns the position of the block or of the attribute at the
+// path within the block in the kform file
+func (r *config) GetPosition(path ...string) diag.Position {
+	if len(path) == 0 && r.position.IsValid() {
+		return r.position
+	}
+	return r.KformBlockContext.GetPosition(r.fileName, path...)
+}
+
+// GetExpressionPosition returns the position of the expression in the block
+// that holds the reference
+func (r *config) GetExpressionPosition(ref string) diag.Position {
+	return r.KformBlockContext.GetExpressionPosition(r.fileName, ref)
+}
+
 func (r *config) GetContext(n string) string {
 	return getContext(r.GetFileName(), r.GetModuleName(), n, BlockType(r.GetBlockType()))
 }
@@ -258,7 +277,7 @@ func (r *config) getSchema(ctx context.Context, kfctx KformBlockContext) {
 	if kfctx.Attributes != nil {
 		if kfctx.Attributes.Schema != nil {
 			if kfctx.Attributes.Schema.ApiVersion == "" {
-				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "schema requires apiVersion but not present in schema attribute"))
+				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "schema requires apiVersion but not present in schema attribute").WithPosition(r.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentSchema))))
 			} else {
 				split := strings.Split(kfctx.Attributes.Schema.ApiVersion, "/")
 				switch len(split) {
@@ -268,11 +287,11 @@ func (r *config) getSchema(ctx context.Context, kfctx KformBlockContext) {
 					r.gvk.Version = split[1]
 					r.gvk.Group = split[0]
 				default:
-					r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "schema apiVersion expected syntax is <group>/<version>, got: %s", kfctx.Attributes.Schema.ApiVersion))
+					r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "schema apiVersion expected syntax is <group>/<version>, got: %s", kfctx.Attributes.Schema.ApiVersion).WithPosition(r.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentSchema), "apiVersion")))
 				}
 			}
 			if kfctx.Attributes.Schema.Kind == "" {
-				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "schema requires kind but not present in schema attribute"))
+				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "schema requires kind but not present in schema attribute").WithPosition(r.GetPosition(string(BlockContextKeyAttributes), string(MetaArgumentSchema))))
 			} else {
 				r.gvk.Kind = kfctx.Attributes.Schema.Kind
 			}
@@ -320,7 +339,7 @@ func (r *config) validateKeyWord(ctx context.Context, value any, keyword BlockCo
 		// validate if keywords are expected, if present and we dont expect it we create a warning
 		if _, ok := r.expectedKeywords[keyword]; !ok {
 			// unexpected keyword
-			r.recorder.Record(diag.DiagWarnfWithContext(GetContext(ctx), "keyword %s present but ignored", keyword))
+			r.recorder.Record(diag.DiagWarnfWithContext(GetContext(ctx), "keyword %s present but ignored", keyword).WithPosition(r.GetPosition(string(keyword))))
 		}
 	} else {
 		//fmt.Println("validate keyword value nil", GetContext(ctx), keyword, r.expectedAttributes)
@@ -328,7 +347,7 @@ func (r *config) validateKeyWord(ctx context.Context, value any, keyword BlockCo
 		if mandatory, ok := r.expectedKeywords[keyword]; ok {
 			if mandatory {
 				// keyword expected but not present
-				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "expected keyword %s but not present", keyword))
+				r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "expected keyword %s but not present", keyword).WithPosition(r.GetPosition()))
 			}
 		}
 	}
@@ -351,14 +370,14 @@ func (r *config) validateAttributes(ctx context.Context, kfctx KformBlockContext
 		for k := range attributes {
 			if _, ok := r.expectedAttributes[k]; !ok {
 				// unexpected keyword
-				r.recorder.Record(diag.DiagWarnfWithContext(GetContext(ctx), "attribute %s present but ignored", k))
+				r.recorder.Record(diag.DiagWarnfWithContext(GetContext(ctx), "attribute %s present but ignored", k).WithPosition(r.GetPosition(string(BlockContextKeyAttributes), k)))
 			}
 		}
 		// validate if the required attributes are present
 		for k, mandatory := range r.expectedAttributes {
 			if mandatory {
 				if _, ok := attributes[k]; !ok {
-					r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "expected attribute %s but not present", k))
+					r.recorder.Record(diag.DiagErrorfWithContext(GetContext(ctx), "expected attribute %s but not present", k).WithPosition(r.GetPosition(string(BlockContextKeyAttributes))))
 				}
 			}
 		}
@@ -378,6 +397,7 @@ func (r *config) initAndValidateBlockConfig(ctx context.Context) {
 
 func (r *config) validateKeyWordsAndAttributes(ctx context.Context) {
 	r.KformBlockContext = cctx.GetContextValue[KformBlockContext](ctx, CtxKeyKformContext)
+	r.position = r.KformBlockContext.GetPosition(r.fileName)
 	r.validateKeyWords(ctx, r.KformBlockContext)
 	r.validateAttributes(ctx, r.KformBlockContext)
 }
@@ -2,50 +2,139 @@ package types
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/henderiw-nephio/kform/kform-plugin/kfprotov1/kfplugin1"
 	kfplugin "github.com/henderiw-nephio/kform/kform-plugin/plugin"
 	"github.com/henderiw-nephio/kform/plugin"
 	"github.com/henderiw-nephio/kform/tools/pkg/exec/providers/logging"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/cache"
+	"github.com/henderiw-nephio/kform/tools/pkg/util/cctx"
 	"github.com/henderiw-nephio/kform/tools/pkg/util/sets"
 	"github.com/henderiw/logger/log"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 type Provider struct {
-	NSN cache.NSN
-	//ExecPath string
+	NSN      cache.NSN
+	ExecPath string
 	Initializer
 	Resources       sets.Set[string]
 	ReadDataSources sets.Set[string]
 	ListDataSources sets.Set[string]
+	// ConfigSchema is the schema of the provider config and Schemas are the
+	// schemas of the resources keyed by resource type, when the provider
+	// reports them
+	ConfigSchema *spec.Schema
+	Schemas      map[string]*spec.Schema
 }
 
 type Initializer func() (kfplugin.Provider, error)
 
-func (r *Provider) Init(ctx context.Context, execpath string, nsn cache.NSN) error {
+// BuiltinProviderConfigKind is the kind of the provider config of a built-in
+// provider
+const BuiltinProviderConfigKind = "ProviderConfig"
+
+// BuiltinProviders is the registry of the providers that are built into
+// kform. A built-in provider runs in-process, it needs no provider
+// requirement and when the provider has no config an empty provider config
+// is used. The registry is injected in the context with
+// CtxKeyBuiltinProviders.
+type BuiltinProviders interface {
+	// IsBuiltin returns true when the provider is built into kform
+	IsBuiltin(name string) bool
+	// GetAPIVersion returns the apiVersion of the provider config of a
+	// built-in provider
+	GetAPIVersion(name string) string
+	// NewProvider returns a new instance of the built-in provider which runs
+	// in-process
+	NewProvider(name string) (kfplugin.Provider, error)
+}
+
+// IsBuiltinProvider returns true when the provider is built into kform
+// according to the built-in providers registry in the context
+func IsBuiltinProvider(ctx context.Context, name string) bool {
+	b := cctx.GetContextValue[BuiltinProviders](ctx, CtxKeyBuiltinProviders)
+	return b != nil && b.IsBuiltin(name)
+}
+
+// ProviderManager initializes the providers of the inventory, the
+// implementation owns the lifecycle of the provider processes.
+type ProviderManager interface {
+	// Init returns the inventory entry of the provider with the executable
+	// in execPath, the checksum is the digest of the executable recorded in
+	// the lock file.
+	Init(ctx context.Context, nsn cache.NSN, execPath, checksum string) (Provider, error)
+}
+
+// PluginSecurity configures how the provider executable is verified and how
+// kform connects to the provider.
+type PluginSecurity struct {
+	// Checksum of the provider executable recorded in the lock file,
+	// e.g. sha256:<hex>
+	Checksum string
+	// Insecure disables the checksum verification and mutual TLS, used
+	// to develop providers.
+	Insecure bool
+}
+
+// GetSecureConfig returns the config to verify the checksum of the provider
+// executable before it is executed.
+func (r PluginSecurity) GetSecureConfig(name string) (*plugin.SecureConfig, error) {
+	if r.Checksum == "" {
+		return nil, fmt.Errorf("no checksum found for provider %s, run kform init to install the provider or use --insecure-plugins", name)
+	}
+	algo, digest, ok := strings.Cut(r.Checksum, ":")
+	if !ok || algo != "sha256" {
+		return nil, fmt.Errorf("unsupported checksum for provider %s, got: %s", name, r.Checksum)
+	}
+	checksum, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum for provider %s, err: %s", name, err.Error())
+	}
+	return &plugin.SecureConfig{
+		Checksum: checksum,
+		Hash:     sha256.New(),
+	}, nil
+}
+
+// Init initializes the provider with the executable in execpath, the
+// capabilities are set by the provider manager through SetCapabilities. The
+// state directory is passed to the provider in EnvStateDir.
+func (r *Provider) Init(ctx context.Context, execpath, stateDir string, nsn cache.NSN, sec PluginSecurity) {
 	log := log.FromContext(ctx)
 	log.Info("init provider", "execpath", execpath)
 	r.NSN = nsn
-	r.Initializer = ProviderInitializer(execpath)
+	r.ExecPath = execpath
+	r.Initializer = ProviderInitializer(ctx, nsn.Name, execpath, stateDir, sec)
 	r.Resources = sets.New[string]()
 	r.ReadDataSources = sets.New[string]()
 	r.ListDataSources = sets.New[string]()
-	// initialize the provider
-	provider, err := r.Initializer()
-	if err != nil {
-		log.Error("failed starting provider", "nsn", r.NSN.Name)
-		return fmt.Errorf("failed starting provider %s, err: %s", r.NSN.Name, err.Error())
-	}
-	defer provider.Close(ctx)
-	capResp, err := provider.Capabilities(ctx, &kfplugin1.Capabilities_Request{})
-	if err != nil {
-		log.Error("cannot get provider capabilities", "nsn", r.NSN.Name)
-		return fmt.Errorf("cannot get provider capabilities %s, err: %s", r.NSN.Name, err.Error())
+}
+
+// InitBuiltin initializes a provider that is built into kform, the provider
+// runs in-process
+func (r *Provider) InitBuiltin(ctx context.Context, nsn cache.NSN, b BuiltinProviders) {
+	log := log.FromContext(ctx)
+	log.Info("init built-in provider", "nsn", nsn.Name)
+	r.NSN = nsn
+	r.Initializer = func() (kfplugin.Provider, error) {
+		return b.NewProvider(nsn.Name)
 	}
+	r.Resources = sets.New[string]()
+	r.ReadDataSources = sets.New[string]()
+	r.ListDataSources = sets.New[string]()
+}
 
+// SetCapabilities sets the resources and data sources the provider supports
+func (r *Provider) SetCapabilities(ctx context.Context, capResp *kfplugin1.Capabilities_Response) {
+	log := log.FromContext(ctx)
 	if len(capResp.Resources) > 0 {
 		log.Info("resources", "nsn", r.NSN.Name, "resources", capResp.Resources)
 		r.Resources.Insert(capResp.Resources...)
@@ -58,24 +147,64 @@ func (r *Provider) Init(ctx context.Context, execpath string, nsn cache.NSN) err
 		log.Info("list data sources", "nsn", r.NSN.Name, "resources", capResp.ListDataSources)
 		r.ListDataSources.Insert(capResp.ListDataSources...)
 	}
-	return nil
+	if b := capResp.GetProviderConfigSchema(); len(b) > 0 {
+		s := &spec.Schema{}
+		if err := json.Unmarshal(b, s); err != nil {
+			log.Warn("invalid provider config schema", "nsn", r.NSN.Name, "error", err.Error())
+		} else {
+			r.ConfigSchema = s
+		}
+	}
+	r.Schemas = map[string]*spec.Schema{}
+	for resourceType, b := range capResp.GetResourceSchemas() {
+		s := &spec.Schema{}
+		if err := json.Unmarshal(b, s); err != nil {
+			log.Warn("invalid resource schema", "nsn", r.NSN.Name, "resourceType", resourceType, "error", err.Error())
+			continue
+		}
+		r.Schemas[resourceType] = s
+	}
 }
 
 // ProviderInitializer produces a provider factory that runs up the executable
 // file in the given path and uses go-plugin to implement
-// Provider Interface against it.
-func ProviderInitializer(execPath string) Initializer {
+// Provider Interface against it. The output of the provider is forwarded
+// to the logger of the context tagged with the provider name. Unless insecure, the
+// checksum of the executable is verified and mutual TLS is used.
+func ProviderInitializer(ctx context.Context, name, execPath, stateDir string, sec PluginSecurity) Initializer {
+	log := log.FromContext(ctx)
 	return func() (kfplugin.Provider, error) {
+		reattachProviders, err := kfplugin.GetReattachProviders()
+		if err != nil {
+			return nil, err
+		}
 
-		client := plugin.NewClient(&plugin.ClientConfig{
+		cfg := &plugin.ClientConfig{
 			HandshakeConfig:  kfplugin.Handshake,
 			VersionedPlugins: kfplugin.VersionedPlugins,
-			//AutoMTLS:         enableProviderAutoMTLS,
-			Cmd: exec.Command(execPath),
-			//Cmd:        exec.Command("./bin/provider-kubernetes"),
-			SyncStdout: logging.PluginOutputMonitor(fmt.Sprintf("%s:stdout", "test")),
-			SyncStderr: logging.PluginOutputMonitor(fmt.Sprintf("%s:stderr", "test")),
-		})
+			SyncStdout:       logging.ProviderOutput(log, name, "stdout"),
+			SyncStderr:       logging.ProviderOutput(log, name, "stderr"),
+			// the stderr of the process only holds the output written before
+			// the stdio of the provider is synced and the output of a crash
+			Stderr: logging.ProviderOutput(log, name, "process"),
+		}
+		// a provider running in debug mode is reattached iso started
+		if reattach, ok := reattachProviders[name]; ok {
+			cfg.Reattach = reattach
+		} else {
+			cfg.Cmd = exec.Command(execPath)
+			if stateDir != "" {
+				cfg.Cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", kfplugin.EnvStateDir, stateDir))
+			}
+			if !sec.Insecure {
+				cfg.AutoMTLS = true
+				cfg.SecureConfig, err = sec.GetSecureConfig(name)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		client := plugin.NewClient(cfg)
 
 		// Connect via RPC
 		rpcClient, err := client.Client()
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"github.com/henderiw-nephio/kform/kform-sdk-go/pkg/diag"
 	"github.com/henderiw-nephio/kform/tools/pkg/dag"
+	"github.com/henderiw-nephio/kform/tools/pkg/syntax/celenv"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -27,12 +29,18 @@ type VertexContext struct {
 	BlockName string
 	// schema relevant for this blockType
 	GVK schema.GroupVersionKind
+	// Position of the block in the file
+	Position diag.Position
 	// provides the contextual data
 	BlockContext    KformBlockContext
 	Dependencies    map[string]string
 	ModDependencies map[string]string
 	// only relevaant for blocktype resource and data
 	Provider string
+	// Expressions are the type-checked expressions of the block, keyed by
+	// their source, the parser checks the expressions such that they are
+	// compiled once
+	Expressions map[string]*celenv.CheckedExpression
 	// only relevant for blocktype module
 	// can be either a regular DAG or a provider DAG
 	DAG dag.DAG[*VertexContext]
@@ -40,6 +48,20 @@ type VertexContext struct {
 	//ProviderDAG dag.DAG[*VertexContext]
 }
 
+// GetPosition returns the position of the block or of the attribute at the
+// path within the block, e.g. GetPosition("config", "spec", "prefix")
+func (r *VertexContext) GetPosition(path ...string) diag.Position {
+	if len(path) == 0 && r.Position.IsValid() {
+		return r.Position
+	}
+	return r.BlockContext.GetPosition(r.FileName, path...)
+}
+
+// GetContext returns the context of the block for diagnostics
+func (r *VertexContext) GetContext() string {
+	return getContext(r.FileName, r.ModuleName, r.BlockName, r.BlockType)
+}
+
 func (r *VertexContext) AddDAG(d dag.DAG[*VertexContext]) {
 	r.DAG = d
 }